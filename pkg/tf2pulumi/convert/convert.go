@@ -167,9 +167,15 @@ type Options struct {
 	AllowMissingVariables bool
 	// AllowMissingComments allows binding to succeed even if there are errors extracting comments from the source.
 	AllowMissingComments bool
+	// RequireComments, if true, fails binding if any comment in the source cannot be preserved in the generated code.
+	// RequireComments takes precedence over AllowMissingComments.
+	RequireComments bool
 	// AnnotateNodesWithLocations is true if the generated source code should contain comments that annotate top-level
 	// nodes with their original source locations.
 	AnnotateNodesWithLocations bool
+	// ShortLocationAnnotations, if true, restricts the annotations added by AnnotateNodesWithLocations to resources and
+	// data sources and shortens them to the form `from main.tf:42`.
+	ShortLocationAnnotations bool
 	// FilterResourceNames, if true, removes the property indicated by ResourceNameProperty from all resources in the
 	// graph.
 	FilterResourceNames bool
@@ -199,6 +205,9 @@ type Options struct {
 
 	// TargetOptions captures any target-specific options.
 	TargetOptions interface{}
+	// TransformGraph, if non-nil, is applied to the graph of each module of a Terraform 0.11 configuration before code
+	// is generated from it, e.g. to rewrite the graph's properties using il.RewriteAllProperties.
+	TransformGraph func(g *il.Graph) error
 }
 
 // logf writes a formatted message to the configured logger, if any.
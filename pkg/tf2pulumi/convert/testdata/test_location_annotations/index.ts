@@ -0,0 +1,16 @@
+import * as pulumi from "@pulumi/pulumi";
+import * as aws from "@pulumi/aws";
+
+const config = new pulumi.Config();
+const ami = config.get("ami") || "some-ami";
+
+// from main.tf:6
+// The web server.
+const webInstance = new aws.ec2.Instance("web", {
+    ami: ami,
+    instanceType: "t2.micro",
+});
+// from main.tf:11
+const webEip = new aws.ec2.Eip("web", {
+    instance: webInstance.id,
+});
@@ -0,0 +1,13 @@
+variable "ami" {
+  default = "some-ami"
+}
+
+# The web server.
+resource "aws_instance" "web" {
+  ami           = "${var.ami}"
+  instance_type = "t2.micro"
+}
+
+resource "aws_eip" "web" {
+  instance = "${aws_instance.web.id}"
+}
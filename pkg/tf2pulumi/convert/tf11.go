@@ -44,6 +44,14 @@ func convertTF11(opts Options) (map[string][]byte, bool, error) {
 		return nil, true, fmt.Errorf("failed to build graphs: %w", err)
 	}
 
+	if opts.TransformGraph != nil {
+		for _, g := range gs {
+			if err = opts.TransformGraph(g); err != nil {
+				return nil, false, fmt.Errorf("failed to transform graph: %w", err)
+			}
+		}
+	}
+
 	if opts.TerraformVersion == "12" || opts.TargetLanguage != "typescript" {
 		// Generate TF12 code from the TF11 graph, then pass the result off to the TF12 pipeline.
 		g := &tf11generator{}
@@ -73,7 +81,7 @@ func convertTF11(opts Options) (map[string][]byte, bool, error) {
 	// Annotate nodes with the location of their original definition if requested.
 	if opts.AnnotateNodesWithLocations {
 		for _, g := range gs {
-			addLocationAnnotations(g)
+			addLocationAnnotations(g, opts.ShortLocationAnnotations)
 		}
 	}
 
@@ -94,7 +102,7 @@ func convertTF11(opts Options) (map[string][]byte, bool, error) {
 	return files, false, nil
 }
 
-func addLocationAnnotation(location token.Pos, comments **il.Comments) {
+func addLocationAnnotation(location token.Pos, comments **il.Comments, short bool) {
 	if !location.IsValid() {
 		return
 	}
@@ -105,31 +113,42 @@ func addLocationAnnotation(location token.Pos, comments **il.Comments) {
 		*comments = c
 	}
 
+	if short {
+		// Short annotations precede any existing comments so that they read as a header for the node.
+		c.Leading = append([]string{fmt.Sprintf(" from %v:%v", location.Filename, location.Line)}, c.Leading...)
+		return
+	}
+
 	if len(c.Leading) != 0 {
 		c.Leading = append(c.Leading, "")
 	}
 	c.Leading = append(c.Leading, fmt.Sprintf(" Originally defined at %v:%v", location.Filename, location.Line))
 }
 
-// addLocationAnnotations adds comments that record the original source location of each top-level node in a module.
-func addLocationAnnotations(m *il.Graph) {
+// addLocationAnnotations adds comments that record the original source location of each top-level node in a module. If
+// short is true, only resources are annotated, and their annotations are shortened to the form `from main.tf:42`.
+func addLocationAnnotations(m *il.Graph, short bool) {
+	for _, n := range m.Resources {
+		addLocationAnnotation(n.Location, &n.Comments, short)
+	}
+	if short {
+		return
+	}
+
 	for _, n := range m.Modules {
-		addLocationAnnotation(n.Location, &n.Comments)
+		addLocationAnnotation(n.Location, &n.Comments, false)
 	}
 	for _, n := range m.Providers {
-		addLocationAnnotation(n.Location, &n.Comments)
-	}
-	for _, n := range m.Resources {
-		addLocationAnnotation(n.Location, &n.Comments)
+		addLocationAnnotation(n.Location, &n.Comments, false)
 	}
 	for _, n := range m.Outputs {
-		addLocationAnnotation(n.Location, &n.Comments)
+		addLocationAnnotation(n.Location, &n.Comments, false)
 	}
 	for _, n := range m.Locals {
-		addLocationAnnotation(n.Location, &n.Comments)
+		addLocationAnnotation(n.Location, &n.Comments, false)
 	}
 	for _, n := range m.Variables {
-		addLocationAnnotation(n.Location, &n.Comments)
+		addLocationAnnotation(n.Location, &n.Comments, false)
 	}
 }
 
@@ -149,6 +168,7 @@ func buildGraphs(tree *tf11module.Tree, opts Options) ([]*il.Graph, error) {
 		AllowMissingProviders: opts.AllowMissingProviders,
 		AllowMissingVariables: opts.AllowMissingVariables,
 		AllowMissingComments:  opts.AllowMissingComments,
+		RequireComments:       opts.RequireComments,
 		ProviderInfoSource:    opts.ProviderInfoSource,
 		Logger:                opts.Logger,
 	}
@@ -167,7 +187,7 @@ func newGenerator(w io.Writer, projectName string, opts Options) (gen.Generator,
 		if !ok && opts.TargetOptions != nil {
 			return nil, "", errors.Errorf("invalid target options of type %T", opts.TargetOptions)
 		}
-		g, err := nodejs.New(projectName, opts.TargetSDKVersion, nodeOpts.UsePromptDataSources, w)
+		g, err := nodejs.NewWithOptions(projectName, opts.TargetSDKVersion, nodeOpts, w)
 		if err != nil {
 			return nil, "", err
 		}
@@ -390,7 +410,7 @@ func (g *tf11generator) genProvider(w io.Writer, p *il.ProviderNode) error {
 // local.
 func (g *tf11generator) genResource(w io.Writer, r *il.ResourceNode) error {
 	// Build the lifeycle block if necessary.
-	if len(r.IgnoreChanges) != 0 || r.Config.Lifecycle.PreventDestroy {
+	if len(r.IgnoreChanges) != 0 || r.Protect {
 		lifecycle := &il.BoundMapProperty{Elements: map[string]il.BoundNode{}}
 		if len(r.IgnoreChanges) != 0 {
 			ignoreChanges := &il.BoundListProperty{}
@@ -402,7 +422,7 @@ func (g *tf11generator) genResource(w io.Writer, r *il.ResourceNode) error {
 			}
 			lifecycle.Elements["ignore_changes"] = ignoreChanges
 		}
-		if r.Config.Lifecycle.PreventDestroy {
+		if r.Protect {
 			lifecycle.Elements["prevent_destroy"] = &il.BoundLiteral{ExprType: il.TypeBool, Value: true}
 		}
 		r.Properties.Elements["lifecycle"] = lifecycle
@@ -621,12 +641,7 @@ func (g *tf11generator) GenLiteral(w io.Writer, n *il.BoundLiteral) {
 	case il.TypeBool:
 		g.Fgenf(w, "%v", n.Value)
 	case il.TypeNumber:
-		f := n.Value.(float64)
-		if float64(int64(f)) == f {
-			g.Fgenf(w, "%d", int64(f))
-		} else {
-			g.Fgenf(w, "%g", n.Value)
-		}
+		g.Fgen(w, il.FormatNumber(n.Value))
 	case il.TypeString:
 		g.genStringLiteral(w, n.Value.(string))
 	default:
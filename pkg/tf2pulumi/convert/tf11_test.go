@@ -0,0 +1,44 @@
+// Copyright 2016-2022, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package convert
+
+import (
+	"os"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi-terraform-bridge/v3/pkg/tf2pulumi/gen/nodejs"
+	"github.com/pulumi/pulumi-terraform-bridge/v3/pkg/tf2pulumi/test"
+)
+
+func TestShortLocationAnnotations(t *testing.T) {
+	files, _, err := Convert(Options{
+		Root:                       afero.NewBasePathFs(afero.NewOsFs(), "testdata/test_location_annotations"),
+		ProviderInfoSource:         test.NewProviderInfoSource("../testdata/providers"),
+		AllowMissingProviders:      true,
+		AnnotateNodesWithLocations: true,
+		ShortLocationAnnotations:   true,
+		TargetLanguage:             LanguageTypescript,
+		TargetSDKVersion:           "1.0.0",
+		TargetOptions:              nodejs.Options{UsePromptDataSources: true},
+	})
+	assert.NoError(t, err)
+
+	expected, err := os.ReadFile("testdata/test_location_annotations/index.ts")
+	assert.NoError(t, err)
+	assert.Equal(t, string(expected), string(files["index.ts"]))
+}
@@ -31,6 +31,11 @@ type nameTable struct {
 	names        map[il.Node]string
 	assigned     map[string]bool
 	isRootModule bool
+
+	// cleaned maps each source name that is not a legal identifier to its cleaned form, and cleanedSources maps each
+	// cleaned form back to the source name that produced it.
+	cleaned        map[string]string
+	cleanedSources map[string]string
 }
 
 // isReservedWord returns true if s is a reserved word as per ECMA-262.
@@ -74,9 +79,33 @@ func camel(s string) string {
 	return string([]rune{unicode.ToLower(c)}) + s[sz:]
 }
 
+// cleanName cleans the given source name. Distinct source names that clean to the same name--e.g. `foo-bar_baz` and
+// `foo_bar-baz`--are disambiguated by appending an integer starting with 1 to all but the first name seen.
+func (nt *nameTable) cleanName(name string) string {
+	if clean, ok := nt.cleaned[name]; ok {
+		return clean
+	}
+
+	clean := cleanName(name)
+	root := clean
+	for i := 1; ; i++ {
+		if _, ok := nt.cleanedSources[clean]; !ok {
+			break
+		}
+		clean = fmt.Sprintf("%s%d", root, i)
+	}
+	nt.cleaned[name], nt.cleanedSources[clean] = clean, name
+	return clean
+}
+
 // tsName computes the TypeScript form of the given name.
 func (nt *nameTable) tsName(name string) (string, bool) {
-	n := camel(tsName(name, nil, nil, false))
+	var n string
+	if isLegalIdentifier(name) {
+		n = camel(tsName(name, nil, nil, false))
+	} else {
+		n = camel(nt.cleanName(name))
+	}
 	return n, isReservedWord(n)
 }
 
@@ -91,8 +120,14 @@ func (nt *nameTable) disambiguate(name string) string {
 
 // assignOutput assigns an unambiguous name to an output node.
 func (nt *nameTable) assignOutput(n *il.OutputNode) {
-	// We use the global tsName function here so that we can pass an argument for isObjectKey.
-	name := tsName(n.Name, nil, nil, !nt.isRootModule)
+	// We use the global tsName function here so that we can pass an argument for isObjectKey. Outputs of the root
+	// module are exported as variables, so their names must be cleaned.
+	var name string
+	if nt.isRootModule && !isLegalIdentifier(n.Name) {
+		name = nt.cleanName(n.Name)
+	} else {
+		name = tsName(n.Name, nil, nil, !nt.isRootModule)
+	}
 	contract.Assert(!nt.assigned[name])
 
 	nt.names[n] = name
@@ -167,7 +202,7 @@ func (nt *nameTable) disambiguateResourceName(n *il.ResourceNode) string {
 	// process. If these names cannot be determined, return an ugly name comprised of the TF type and name.
 	packageName, moduleName, typeName, err := resourceTypeName(n)
 	if err != nil {
-		return cleanName(n.Type + "_" + n.Name)
+		return nt.disambiguate(nt.cleanName(n.Type + "_" + n.Name))
 	}
 	packageName, moduleName = title(packageName), title(moduleName)
 
@@ -204,6 +239,9 @@ func assignNames(g *il.Graph, importNames map[string]bool, isRootModule bool) ma
 		names:        make(map[il.Node]string),
 		assigned:     make(map[string]bool),
 		isRootModule: isRootModule,
+
+		cleaned:        make(map[string]string),
+		cleanedSources: make(map[string]string),
 	}
 
 	// Seed the set of assigned names with the names of imported modules.
@@ -170,6 +170,41 @@ func TestAssignNames(t *testing.T) {
 	assert.Equal(t, "mainInstances", names[g.Resources["aws:ec2:getInstances::main"]])
 }
 
+func TestAssignNamesCleanNameCollisions(t *testing.T) {
+	// Each pair of names below is distinct in TF, but the names clean to the same JavaScript identifier.
+	g := &il.Graph{
+		Outputs: outputs([]string{
+			"instance-id_primary",
+			"instance_id-primary",
+		}),
+		Locals: locals([]string{
+			"web-server_name",
+			"web_server-name",
+		}),
+		Variables: variables([]string{
+			"web-server_name",
+		}),
+		Resources: resources([]string{
+			"aws:ec2:Instance::db-server_primary",
+			"aws:ec2:Vpc::db_server-primary",
+		}),
+	}
+
+	names := assignNames(g, map[string]bool{}, true)
+
+	assert.Equal(t, "instance_id_primary", names[g.Outputs["instance-id_primary"]])
+	assert.Equal(t, "instance_id_primary1", names[g.Outputs["instance_id-primary"]])
+
+	assert.Equal(t, "web_server_name", names[g.Locals["web-server_name"]])
+	assert.Equal(t, "web_server_name1", names[g.Locals["web_server-name"]])
+
+	// The same source name is still disambiguated across kinds of nodes as usual.
+	assert.Equal(t, "web_server_nameInput", names[g.Variables["web-server_name"]])
+
+	assert.Equal(t, "db_server_primary", names[g.Resources["aws:ec2:Instance::db-server_primary"]])
+	assert.Equal(t, "db_server_primary1", names[g.Resources["aws:ec2:Vpc::db_server-primary"]])
+}
+
 func boundRef(v string, typ il.Type, node il.Node) *il.BoundVariableAccess {
 	tfVar, err := config.NewInterpolatedVariable(v)
 	contract.Assert(err == nil)
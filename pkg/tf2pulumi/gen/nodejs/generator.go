@@ -16,6 +16,8 @@ package nodejs
 
 import (
 	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"golang.org/x/text/cases"
 	"golang.org/x/text/language"
@@ -27,6 +29,7 @@ import (
 	"github.com/blang/semver"
 	"github.com/pkg/errors"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/util/contract"
+	"github.com/spf13/afero"
 
 	"github.com/pulumi/pulumi-terraform-bridge/v3/pkg/tf2pulumi/gen"
 	"github.com/pulumi/pulumi-terraform-bridge/v3/pkg/tf2pulumi/il"
@@ -39,10 +42,55 @@ import (
 type Options struct {
 	// UsePromptDataSources is true if the target provider supports prompt invocation of data sources.
 	UsePromptDataSources bool
+	// UseOutputDataSources is true if every data source should be invoked through its Output-returning form (e.g.
+	// `aws.getAmiOutput`), which accepts Input-typed arguments. This takes precedence over UsePromptDataSources.
+	UseOutputDataSources bool
+	// EmitTODOs is true if unconvertible expressions should be replaced with TODO stubs that include their original
+	// Terraform source.
+	EmitTODOs bool
+	// EmitComponents is true if each child module should be generated as a ComponentResource class rather than as a
+	// factory function.
+	EmitComponents bool
+	// EmitProvisioners is true if `local-exec` and `remote-exec` provisioners should be converted into
+	// `@pulumi/command` resources that depend on the resource that owns the provisioner.
+	EmitProvisioners bool
+	// EmitESM is true if the program should only use ECMAScript module imports, for projects that target ESM rather
+	// than CommonJS. Only the providers that the program uses are imported.
+	EmitESM bool
+	// PostProcess, if non-nil, is applied to the complete generated program before it is written, e.g. to format the
+	// program or to prepend a license header.
+	PostProcess func(string) (string, error)
+	// ResourceNamePrefix is prepended to the logical name of each resource, provider, and module instance of the root
+	// module, e.g. "prod-" for "prod-web". The resources of child modules are named after their module instance, so
+	// they inherit the prefix.
+	ResourceNamePrefix string
+	// MaxResourceNameLength, if positive, is the maximum length of the logical names that ResourceNamePrefix applies
+	// to, not counting the index suffix of counted resources. Longer names are truncated and suffixed with a hash of
+	// the full name, so that truncation is deterministic and truncated names remain unique. It must be at least 16.
+	MaxResourceNameLength int
+	// EmitPropertyDocs is true if each resource or data source property whose Terraform schema carries a description
+	// should be preceded by a TSDoc comment that holds the description, for the benefit of IDE hover text.
+	EmitPropertyDocs bool
 }
 
-// New creates a new NodeJS code generator.
+// minResourceNameLength is the smallest positive value of Options.MaxResourceNameLength, which leaves room for the
+// hash that suffixes truncated names.
+const minResourceNameLength = 16
+
+// New creates a new NodeJS code generator. If usePromptDataSources is true, data sources whose inputs are all known
+// promptly are invoked directly and their results are used as plain values; otherwise, the results of data sources are
+// wrapped in outputs. The remaining code generation options are available through NewWithOptions.
 func New(projectName string, targetSDKVersion string, usePromptDataSources bool, w io.Writer) (gen.Generator, error) {
+	return NewWithOptions(projectName, targetSDKVersion, Options{UsePromptDataSources: usePromptDataSources}, w)
+}
+
+// NewWithOptions creates a new NodeJS code generator that writes the program to w. See Options for the meaning of each
+// option.
+func NewWithOptions(projectName, targetSDKVersion string, opts Options, w io.Writer) (gen.Generator, error) {
+	if opts.MaxResourceNameLength > 0 && opts.MaxResourceNameLength < minResourceNameLength {
+		return nil, errors.Errorf("the maximum resource name length must be at least %d", minResourceNameLength)
+	}
+
 	supportsProxyApplies := true
 	if targetSDKVersion != "" {
 		v, err := semver.Parse(targetSDKVersion)
@@ -52,10 +100,22 @@ func New(projectName string, targetSDKVersion string, usePromptDataSources bool,
 		supportsProxyApplies = v.GTE(semver.MustParse("0.17.0"))
 	}
 	g := &generator{
-		ProjectName:          projectName,
-		supportsProxyApplies: supportsProxyApplies,
-		usePromptDataSources: usePromptDataSources,
-		importNames:          make(map[string]bool),
+		ProjectName:           projectName,
+		supportsProxyApplies:  supportsProxyApplies,
+		usePromptDataSources:  opts.UsePromptDataSources && !opts.UseOutputDataSources,
+		useOutputDataSources:  opts.UseOutputDataSources,
+		emitTODOs:             opts.EmitTODOs,
+		emitComponents:        opts.EmitComponents,
+		emitProvisioners:      opts.EmitProvisioners,
+		emitESM:               opts.EmitESM,
+		emitPropertyDocs:      opts.EmitPropertyDocs,
+		resourceNamePrefix:    opts.ResourceNamePrefix,
+		maxResourceNameLength: opts.MaxResourceNameLength,
+		importNames:           make(map[string]bool),
+	}
+	if opts.PostProcess != nil {
+		// Buffer the program so that it can be post-processed once the last module has been generated.
+		g.postProcess, g.output, w = opts.PostProcess, w, &g.buffer
 	}
 	g.Emitter = gen.NewEmitter(w, g)
 	return g, nil
@@ -72,6 +132,42 @@ type generator struct {
 	supportsProxyApplies bool
 	// usePromptDataSources is true if the target provider supports prompt invocation of data sources.
 	usePromptDataSources bool
+	// useOutputDataSources is true if data sources should be invoked through their Output-returning forms.
+	useOutputDataSources bool
+	// emitTODOs is true if binding errors should be generated as TODO stubs that include their original source.
+	emitTODOs bool
+	// emitComponents is true if child modules should be generated as ComponentResource classes.
+	emitComponents bool
+	// emitProvisioners is true if provisioners should be converted into Command resources.
+	emitProvisioners bool
+	// emitESM is true if the program should only use ECMAScript module imports.
+	emitESM bool
+	// postProcess, if non-nil, is applied to the buffered program before it is written to output.
+	postProcess func(string) (string, error)
+	// resourceNamePrefix is prepended to the logical names of the root module's resources. See logicalName.
+	resourceNamePrefix string
+	// maxResourceNameLength, if positive, is the length beyond which logical names are truncated. See logicalName.
+	maxResourceNameLength int
+	// emitPropertyDocs is true if properties should be preceded by TSDoc comments that hold their descriptions.
+	emitPropertyDocs bool
+	// buffer holds the generated program until it is post-processed.
+	buffer bytes.Buffer
+	// output is the writer that receives the post-processed program.
+	output io.Writer
+	// fs is the filesystem to which the files of a split program are written. The program is split iff fs is non-nil.
+	fs afero.Fs
+	// dir is the directory to which the files of a split program are written.
+	dir string
+	// out forwards the emitter's output to the file of a split program that is currently being generated.
+	out switchWriter
+	// files maps the path of each file of a split program to the file.
+	files map[string]*splitFile
+	// nodeFiles maps each top-level node of a split program to the file that defines it.
+	nodeFiles map[il.Node]*splitFile
+	// moduleFiles maps the name of each child module of a split program to the file that defines it.
+	moduleFiles map[string]*splitFile
+	// lastModule is the last module that will be generated.
+	lastModule *il.Graph
 	// rootPath is the path to the directory that contains the root module.
 	rootPath string
 	// module is the module currently being generated;.
@@ -173,6 +269,11 @@ func (g *generator) variableName(n *il.BoundVariableAccess) string {
 	switch v := n.TFVar.(type) {
 	case *config.CountVariable:
 		return g.countIndex
+	case *config.IteratorVariable:
+		if v.Field == "key" {
+			return iteratorName(v.Name) + "Key"
+		}
+		return iteratorName(v.Name)
 	case *config.LocalVariable:
 		return "local_" + cleanName(v.Name)
 	case *config.ModuleVariable:
@@ -209,6 +310,11 @@ func (g *generator) isConditionalResource(r *il.ResourceNode) bool {
 
 // genError generates code for a node that represents a binding error.
 func (g *generator) GenError(w io.Writer, v *il.BoundError) {
+	if g.emitTODOs {
+		g.genTODOStub(w, v)
+		return
+	}
+
 	g.Fgen(w, "(() => {\n")
 	g.Indented(func() {
 		g.Fgenf(w, "%sthrow \"tf2pulumi error: %v\";\n", g.Indent, v.Error.Error())
@@ -217,6 +323,23 @@ func (g *generator) GenError(w io.Writer, v *il.BoundError) {
 	g.Fgen(w, g.Indent, "})()")
 }
 
+// genTODOStub generates a placeholder for a node that represents a binding error. The placeholder notes the error and
+// the original Terraform source of the expression so that it can be converted by hand.
+func (g *generator) genTODOStub(w io.Writer, v *il.BoundError) {
+	g.Fgen(w, "(() => {\n")
+	g.Indented(func() {
+		g.Fgenf(w, "%s// TODO: %v\n", g.Indent, v.Error.Error())
+		if v.Source != "" {
+			g.Fgenf(w, "%s// Original Terraform source:\n", g.Indent)
+			for _, line := range strings.Split(strings.TrimRight(v.Source, "\n"), "\n") {
+				g.Fgenf(w, "%s//     %s\n", g.Indent, line)
+			}
+		}
+		g.Fgenf(w, "%sthrow \"tf2pulumi TODO: this expression requires manual conversion\";\n", g.Indent)
+	})
+	g.Fgen(w, g.Indent, "})()")
+}
+
 // computeProperty generates code for the given property into a string ala fmt.Sprintf. It returns both the generated
 // code and a bool value that indicates whether or not any output-typed values were nested in the property value.
 func (g *generator) computeProperty(prop il.BoundNode, indent bool, count string) (string, bool, error) {
@@ -261,6 +384,11 @@ func (g *generator) computeProperty(prop il.BoundNode, indent bool, count string
 		}
 	}
 
+	p, err = g.lowerSensitiveCalls(p)
+	if err != nil {
+		return "", false, err
+	}
+
 	// Finally, generate code for the property.
 	if indent {
 		g.Indent += "    "
@@ -277,6 +405,24 @@ func (g *generator) isRoot() bool {
 	return g.module.IsRoot
 }
 
+// isComponent returns true if we are generating code for a child module as a ComponentResource class.
+func (g *generator) isComponent() bool {
+	return g.emitComponents && !g.isRoot()
+}
+
+// componentClassName returns the name of the ComponentResource class generated for the named module.
+func componentClassName(moduleName string) string {
+	return title(cleanName(tfbridge.TerraformToPulumiName(moduleName, nil, nil, true))) + "Module"
+}
+
+// componentField returns the expression that accesses the given output field of the component under construction.
+func componentField(name string) string {
+	if isLegalIdentifier(name) {
+		return "this." + name
+	}
+	return "this[" + name + "]"
+}
+
 // genLeadingComment generates a leading comment into the output.
 func (g *generator) genLeadingComment(w io.Writer, comments *il.Comments) {
 	if comments == nil {
@@ -287,6 +433,32 @@ func (g *generator) genLeadingComment(w io.Writer, comments *il.Comments) {
 	}
 }
 
+// genPropertyDoc generates a TSDoc comment that holds the given property description into the output. Multi-line
+// descriptions are generated as a block with one line of the description per line of the comment.
+func (g *generator) genPropertyDoc(w io.Writer, description string) {
+	description = strings.TrimSpace(strings.ReplaceAll(description, "\r\n", "\n"))
+	if description == "" {
+		return
+	}
+	// Keep the description from terminating the comment early.
+	description = strings.ReplaceAll(description, "*/", "*\\/")
+
+	lines := strings.Split(description, "\n")
+	if len(lines) == 1 {
+		g.Fgenf(w, "%s/** %s */\n", g.Indent, lines[0])
+		return
+	}
+	g.Fgenf(w, "%s/**\n", g.Indent)
+	for _, l := range lines {
+		if l = strings.TrimRight(l, " \t"); l == "" {
+			g.Fgenf(w, "%s *\n", g.Indent)
+		} else {
+			g.Fgenf(w, "%s * %s\n", g.Indent, l)
+		}
+	}
+	g.Fgenf(w, "%s */\n", g.Indent)
+}
+
 // genTrailing comment generates a trailing comment into the output.
 func (g *generator) genTrailingComment(w io.Writer, comments *il.Comments) {
 	if comments == nil {
@@ -303,8 +475,20 @@ func (g *generator) genTrailingComment(w io.Writer, comments *il.Comments) {
 	}
 }
 
+// defaultImport returns the statement that imports the given CommonJS module as a whole under the given name.
+func (g *generator) defaultImport(name, module string) string {
+	if g.emitESM {
+		return fmt.Sprintf(`import %s from "%s";`, name, module)
+	}
+	return fmt.Sprintf(`import %s = require("%s");`, name, module)
+}
+
 // GeneratePreamble generates appropriate import statements based on the providers referenced by the set of modules.
 func (g *generator) GeneratePreamble(modules []*il.Graph) error {
+	if len(modules) > 0 {
+		g.lastModule = modules[len(modules)-1]
+	}
+
 	// Find the root module and stash its path.
 	for _, m := range modules {
 		if m.IsRoot {
@@ -316,8 +500,71 @@ func (g *generator) GeneratePreamble(modules []*il.Graph) error {
 		g.rootPath = "."
 	}
 
-	// Print the @pulumi/pulumi import at the top.
+	// Compute the imports up front so that their names are known when names are assigned. A split program computes
+	// the imports of each file separately when the file is written.
+	imports := g.collectImports(modules)
+	if g.fs != nil {
+		g.assignModuleFiles(modules)
+		return nil
+	}
+
+	// Print the @pulumi/pulumi import at the top, followed by the other imports.
 	g.Println(`import * as pulumi from "@pulumi/pulumi";`)
+	for _, line := range imports {
+		g.Println(line)
+	}
+	g.Printf("\n")
+
+	return nil
+}
+
+// collectImports returns the sorted list of import statements required by the providers and functions referenced by
+// the given modules, excluding the @pulumi/pulumi import. The names bound by the imports are added to the generator's
+// set of import names.
+func (g *generator) collectImports(modules []*il.Graph) []string {
+	names := make(map[string]bool)
+
+	// Collect the Terraform version constraints of each provider so that they can be noted alongside its import.
+	versionConstraints := make(map[string]map[string]bool)
+	for _, m := range modules {
+		for _, p := range m.Providers {
+			if p.VersionConstraint == "" {
+				continue
+			}
+			if versionConstraints[p.PluginName] == nil {
+				versionConstraints[p.PluginName] = make(map[string]bool)
+			}
+			versionConstraints[p.PluginName][p.VersionConstraint] = true
+		}
+	}
+	versionNote := func(name string) string {
+		if len(versionConstraints[name]) == 0 {
+			return ""
+		}
+		var constraints []string
+		for c := range versionConstraints[name] {
+			constraints = append(constraints, c)
+		}
+		sort.Strings(constraints)
+		return fmt.Sprintf(" // Terraform provider %s version %s", name, strings.Join(constraints, ", "))
+	}
+
+	// ESM projects only import the providers that are used by a resource, a data source, or an aliased provider
+	// instance, as a provider block that merely configures the default provider generates no code.
+	var usedProviders map[string]bool
+	if g.emitESM {
+		usedProviders = make(map[string]bool)
+		for _, m := range modules {
+			for _, r := range m.Resources {
+				usedProviders[r.Provider.PluginName] = true
+			}
+			for _, p := range m.Providers {
+				if p.Alias != "" {
+					usedProviders[p.PluginName] = true
+				}
+			}
+		}
+	}
 
 	// Accumulate other imports for the various providers. Don't emit them yet, as we need to sort them later on.
 	var imports []string
@@ -325,20 +572,35 @@ func (g *generator) GeneratePreamble(modules []*il.Graph) error {
 	for _, m := range modules {
 		for _, p := range m.Providers {
 			name := p.PluginName
+			if usedProviders != nil && !usedProviders[name] {
+				continue
+			}
 			if !providers[name] {
 				providers[name] = true
 				switch name {
-				case "archive":
+				case "archive", "null", "terraform":
 					// Nothing to do
 				case "http":
-					imports = append(imports,
-						`import rpn = require("request-promise-native");`)
-					g.importNames["rpn"] = true
+					imports = append(imports, g.defaultImport("rpn", "request-promise-native"))
+					names["rpn"] = true
 				default:
 					importName := cleanName(name)
 					imports = append(imports,
-						fmt.Sprintf(`import * as %s from "@pulumi/%s";`, importName, name))
-					g.importNames[importName] = true
+						fmt.Sprintf(`import * as %s from "@pulumi/%s";%s`, importName, name, versionNote(name)))
+					names[importName] = true
+				}
+			}
+		}
+	}
+
+	// Provisioners and null_resources are converted into resources from the Command provider.
+	if !names["command"] {
+		for _, m := range modules {
+			for _, r := range m.Resources {
+				needsCommand := r.IsNullResource() || g.emitProvisioners && len(r.Provisioners) != 0
+				if needsCommand && !names["command"] {
+					imports = append(imports, `import * as command from "@pulumi/command";`)
+					names["command"] = true
 				}
 			}
 		}
@@ -349,21 +611,21 @@ func (g *generator) GeneratePreamble(modules []*il.Graph) error {
 		switch n := n.(type) {
 		case *il.BoundCall:
 			switch n.Func {
-			case "file":
-				if !g.importNames["fs"] {
+			case "file", "templatefile":
+				if !names["fs"] {
 					imports = append(imports, `import * as fs from "fs";`)
-					g.importNames["fs"] = true
+					names["fs"] = true
 				}
 			case "format":
-				if !g.importNames["sprintf"] {
-					imports = append(imports, `import sprintf = require("sprintf-js");`)
-					g.importNames["sprintf"] = true
+				if !names["sprintf"] {
+					imports = append(imports, g.defaultImport("sprintf", "sprintf-js"))
+					names["sprintf"] = true
 				}
 			}
 		case *il.BoundVariableAccess:
-			if v, ok := n.TFVar.(*config.PathVariable); ok && v.Type == config.PathValueCwd && !g.importNames["process"] {
+			if v, ok := n.TFVar.(*config.PathVariable); ok && v.Type == config.PathValueCwd && !names["process"] {
 				imports = append(imports, `import * as process from "process";`)
-				g.importNames["process"] = true
+				names["process"] = true
 			}
 		}
 		return n, nil
@@ -371,16 +633,28 @@ func (g *generator) GeneratePreamble(modules []*il.Graph) error {
 	for _, m := range modules {
 		err := il.VisitAllProperties(m, findOptionals, il.IdentityVisitor)
 		contract.Assert(err == nil)
+
+		if g.emitProvisioners {
+			for _, r := range m.Resources {
+				for _, p := range r.Provisioners {
+					_, err = il.VisitBoundNode(p.Properties, findOptionals, il.IdentityVisitor)
+					contract.Assert(err == nil)
+					if p.Connection != nil {
+						_, err = il.VisitBoundNode(p.Connection, findOptionals, il.IdentityVisitor)
+						contract.Assert(err == nil)
+					}
+				}
+			}
+		}
 	}
 
-	// Now sort the imports, so we emit them deterministically, and emit them.
-	sort.Strings(imports)
-	for _, line := range imports {
-		g.Println(line)
+	for name := range names {
+		g.importNames[name] = true
 	}
-	g.Printf("\n")
 
-	return nil
+	// Sort the imports so that they are emitted deterministically.
+	sort.Strings(imports)
+	return imports
 }
 
 // BeginModule saves the indicated module in the generator and emits an appropriate function declaration if the module
@@ -388,10 +662,6 @@ func (g *generator) GeneratePreamble(modules []*il.Graph) error {
 func (g *generator) BeginModule(m *il.Graph) error {
 	g.module = m
 	if !g.isRoot() {
-		g.Printf("const new_mod_%s = function(mod_name: string, mod_args: pulumi.Inputs) {\n",
-			cleanName(m.Name))
-		g.Indent += "    "
-
 		// Discover the set of input variables that may have unknown values. This is the complete set of inputs minus
 		// the set of variables used in count interpolations, as Terraform requires that the latter are known at graph
 		// generation time (and thus at Pulumi run time).
@@ -440,22 +710,223 @@ func (g *generator) BeginModule(m *il.Graph) error {
 
 	// Compute unambiguous names for this module's top-level nodes.
 	g.nameTable = assignNames(m, g.importNames, g.isRoot())
+
+	// Each child module of a split program is exported from its own file.
+	export := ""
+	if g.fs != nil {
+		g.assignNodeFiles(m)
+		if !g.isRoot() {
+			g.out.w, export = &g.moduleFiles[m.Name].body, "export "
+		}
+	}
+
+	switch {
+	case g.isComponent():
+		// Each output of the module becomes a field of the component.
+		g.Printf("%sclass %s extends pulumi.ComponentResource {\n", export, componentClassName(m.Name))
+		g.Indented(func() {
+			for _, o := range g.sortedOutputs(m) {
+				g.Printf("%spublic readonly %s: pulumi.Output<any>;\n", g.Indent, g.nodeName(o))
+			}
+			if len(m.Outputs) != 0 {
+				g.Printf("\n")
+			}
+			g.Printf("%sconstructor(mod_name: string, mod_args: pulumi.Inputs, "+
+				"opts?: pulumi.ComponentResourceOptions) {\n", g.Indent)
+			g.Printf("%s    super(\"tf2pulumi:module:%s\", mod_name, {}, opts);\n\n", g.Indent,
+				componentClassName(m.Name))
+		})
+		g.Indent += "        "
+	case !g.isRoot():
+		g.Printf("%sconst new_mod_%s = function(mod_name: string, mod_args: pulumi.Inputs) {\n", export,
+			cleanName(m.Name))
+		g.Indent += "    "
+	}
+
+	if g.isRoot() && m.BackendType != "" {
+		if g.fs != nil {
+			g.out.w = &g.file(indexFile).body
+		}
+		g.genBackendNote(m.BackendType)
+	}
 	return nil
 }
 
+// stateBackendURLs maps Terraform backend types to the form of the URL of the equivalent Pulumi state backend.
+var stateBackendURLs = map[string]string{
+	"azurerm": "azblob://<container-name>",
+	"gcs":     "gs://<bucket-name>",
+	"local":   "file://<path>",
+	"s3":      "s3://<bucket-name>",
+}
+
+// genBackendNote generates a comment that explains how the state that Terraform keeps in the given type of backend is
+// kept by Pulumi. Terraform backends have no direct counterpart in the generated program.
+func (g *generator) genBackendNote(backendType string) {
+	g.Printf("// The Terraform configuration stores its state in the %q backend. Pulumi does not configure state in\n",
+		backendType)
+	g.Printf("// the program: state is kept by the backend that the Pulumi CLI is logged in to.\n")
+	switch backendType {
+	case "remote", "cloud":
+		g.Printf("// The equivalent of Terraform Cloud is Pulumi Cloud, which is used after `pulumi login`.\n")
+	default:
+		if url, ok := stateBackendURLs[backendType]; ok {
+			g.Printf("// To keep state in the same kind of store, run `pulumi login %s`.\n", url)
+		}
+	}
+	g.Printf("// See https://www.pulumi.com/docs/concepts/state/ for more information.\n\n")
+}
+
+// sortedOutputs returns the outputs of the given module ordered by their generated names.
+func (g *generator) sortedOutputs(m *il.Graph) []*il.OutputNode {
+	outputs := make([]*il.OutputNode, 0, len(m.Outputs))
+	for _, o := range m.Outputs {
+		outputs = append(outputs, o)
+	}
+	sort.Slice(outputs, func(i, j int) bool {
+		return g.nodeName(outputs[i]) < g.nodeName(outputs[j])
+	})
+	return outputs
+}
+
 // EndModule closes the current module definition if the module is a child module and clears the generator's module
 // field.
 func (g *generator) EndModule(m *il.Graph) error {
-	if !g.isRoot() {
+	if g.isComponent() {
+		if len(m.Outputs) == 0 {
+			g.Printf("%sthis.registerOutputs();\n", g.Indent)
+		} else {
+			g.Printf("%sthis.registerOutputs({\n", g.Indent)
+			for _, o := range g.sortedOutputs(m) {
+				g.Printf("%s    %s: %s,\n", g.Indent, g.nodeName(o), componentField(g.nodeName(o)))
+			}
+			g.Printf("%s});\n", g.Indent)
+		}
+		g.Indent = g.Indent[:len(g.Indent)-8]
+		g.Printf("    }\n")
+		g.Printf("}\n")
+	} else if !g.isRoot() {
 		g.Indent = g.Indent[:len(g.Indent)-4]
 		g.Printf("};\n")
 	}
 	g.module = nil
+
+	if g.fs != nil {
+		g.linkFiles(m)
+		if m == g.lastModule {
+			return g.writeFiles()
+		}
+		return nil
+	}
+	if g.postProcess != nil && m == g.lastModule {
+		return g.flush()
+	}
 	return nil
 }
 
+// flush passes the buffered program through the post-processor and writes the result to the generator's output.
+func (g *generator) flush() error {
+	program, err := g.postProcess(g.buffer.String())
+	if err != nil {
+		return errors.Wrap(err, "post-processing generated program")
+	}
+	g.buffer.Reset()
+	_, err = io.WriteString(g.output, program)
+	return err
+}
+
+// configGetter returns the name of the config method (e.g. `requireNumber` or `getObject<string[]>`) to use for a
+// variable with the given type constraint. The prefix is either `get` or `require`. If the variable has no type
+// constraint, the method is chosen according to the type of its default value, if any.
+func configGetter(prefix string, constraint *il.TypeConstraint, defaultType il.Type) string {
+	if constraint == nil {
+		switch defaultType {
+		case il.TypeBool:
+			return prefix + "Boolean"
+		case il.TypeNumber:
+			return prefix + "Number"
+		}
+		return prefix
+	}
+
+	switch constraint.Kind {
+	case il.TypeConstraintString:
+		return prefix
+	case il.TypeConstraintBool:
+		return prefix + "Boolean"
+	case il.TypeConstraintNumber:
+		return prefix + "Number"
+	case il.TypeConstraintAny:
+		return configGetter(prefix, nil, defaultType)
+	default:
+		return fmt.Sprintf("%sObject<%s>", prefix, tsConstraintType(constraint))
+	}
+}
+
+// tsConstraintType returns the TypeScript type that corresponds to the given type constraint.
+func tsConstraintType(constraint *il.TypeConstraint) string {
+	switch constraint.Kind {
+	case il.TypeConstraintString:
+		return "string"
+	case il.TypeConstraintNumber:
+		return "number"
+	case il.TypeConstraintBool:
+		return "boolean"
+	case il.TypeConstraintList, il.TypeConstraintSet:
+		return tsConstraintType(constraint.ElementType) + "[]"
+	case il.TypeConstraintMap:
+		return "Record<string, " + tsConstraintType(constraint.ElementType) + ">"
+	case il.TypeConstraintObject:
+		attrs := make([]string, 0, len(constraint.Attributes))
+		for _, name := range constraint.SortedAttributeNames() {
+			key := name
+			if !isLegalIdentifier(key) {
+				key = fmt.Sprintf("%q", key)
+			}
+			attrs = append(attrs, fmt.Sprintf("%s: %s", key, tsConstraintType(constraint.Attributes[name])))
+		}
+		if len(attrs) == 0 {
+			return "{}"
+		}
+		return "{" + strings.Join(attrs, ", ") + "}"
+	case il.TypeConstraintTuple:
+		elements := make([]string, len(constraint.Elements))
+		for i, e := range constraint.Elements {
+			elements[i] = tsConstraintType(e)
+		}
+		return "[" + strings.Join(elements, ", ") + "]"
+	default:
+		return "any"
+	}
+}
+
 // GenerateVariables generates definitions for the set of user variables in the context of the current module.
 func (g *generator) GenerateVariables(vs []*il.VariableNode) error {
+	if g.fs == nil || !g.isRoot() {
+		return g.generateVariables(vs)
+	}
+
+	// Each file of a split program defines its own config object for the variables that it declares.
+	var files []*splitFile
+	byFile := make(map[*splitFile][]*il.VariableNode)
+	for _, v := range vs {
+		f := g.nodeFiles[v]
+		if _, ok := byFile[f]; !ok {
+			files = append(files, f)
+		}
+		byFile[f] = append(byFile[f], v)
+	}
+	for _, f := range files {
+		g.out.w = &f.body
+		if err := g.generateVariables(byFile[f]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// generateVariables generates definitions for the given user variables in the context of the current module.
+func (g *generator) generateVariables(vs []*il.VariableNode) error {
 	// If there are no variables, we're done.
 	if len(vs) == 0 {
 		return nil
@@ -473,16 +944,24 @@ func (g *generator) GenerateVariables(vs []*il.VariableNode) error {
 
 		g.genLeadingComment(g, v.Comments)
 
+		// The values of sensitive variables are secrets. As secrets are outputs, they need no further wrapping.
+		wrap := ""
+		switch {
+		case v.Sensitive:
+			wrap = "pulumi.secret"
+		case isUnknown && !isRoot:
+			wrap = "pulumi.output"
+		}
+
 		g.Printf("%sconst %s = ", g.Indent, g.nodeName(v))
+		if wrap != "" {
+			g.Printf("%s(", wrap)
+		}
 		if v.DefaultValue == nil {
 			if isRoot {
-				g.Printf("config.require(\"%s\")", configName)
+				g.Printf("config.%v(\"%s\")", configGetter("require", v.Type, il.TypeUnknown), configName)
 			} else {
-				f := "mod_args[\"%s\"]"
-				if isUnknown {
-					f = "pulumi.output(" + f + ")"
-				}
-				g.Printf(f, configName)
+				g.Printf("mod_args[\"%s\"]", configName)
 			}
 		} else {
 			def, _, err := g.computeProperty(v.DefaultValue, false, "")
@@ -491,22 +970,15 @@ func (g *generator) GenerateVariables(vs []*il.VariableNode) error {
 			}
 
 			if isRoot {
-				get := "get"
-				switch v.DefaultValue.Type() {
-				case il.TypeBool:
-					get = "getBoolean"
-				case il.TypeNumber:
-					get = "getNumber"
-				}
+				get := configGetter("get", v.Type, v.DefaultValue.Type())
 				g.Printf("config.%v(\"%s\") || %s", get, configName, def)
 			} else {
-				f := "mod_args[\"%s\"] || %s"
-				if isUnknown {
-					f = "pulumi.output(" + f + ")"
-				}
-				g.Printf(f, configName, def)
+				g.Printf("mod_args[\"%s\"] || %s", configName, def)
 			}
 		}
+		if wrap != "" {
+			g.Printf(")")
+		}
 		g.Printf(";")
 
 		g.genTrailingComment(g, v.Comments)
@@ -519,6 +991,8 @@ func (g *generator) GenerateVariables(vs []*il.VariableNode) error {
 
 // GenerateLocal generates a single local value. These values are generated as local variable definitions.
 func (g *generator) GenerateLocal(l *il.LocalNode) error {
+	g.selectFile(l)
+
 	value, _, err := g.computeProperty(l.Value, false, "")
 	if err != nil {
 		return err
@@ -535,6 +1009,8 @@ func (g *generator) GenerateLocal(l *il.LocalNode) error {
 // GenerateModule generates a single module instantiation. A module instantiation is generated as a call to the
 // appropriate module factory function; the result is assigned to a local variable.
 func (g *generator) GenerateModule(m *il.ModuleNode) error {
+	g.selectFile(m)
+
 	// generate a call to the module constructor
 	args, _, err := g.computeProperty(m.Properties, false, "")
 	if err != nil {
@@ -543,7 +1019,19 @@ func (g *generator) GenerateModule(m *il.ModuleNode) error {
 
 	instanceName, modName := g.nodeName(m), cleanName(m.Name)
 	g.genLeadingComment(g, m.Comments)
-	g.Printf("%sconst %s = new_mod_%s(\"%s\", %s);", g.Indent, instanceName, modName, instanceName, args)
+	switch {
+	case g.emitComponents && g.isRoot():
+		g.Printf("%sconst %s = new %s(\"%s\", %s);", g.Indent, instanceName, componentClassName(m.Name),
+			g.logicalName(instanceName), args)
+	case g.emitComponents:
+		g.Printf("%sconst %s = new %s(`${mod_name}_%s`, %s, { parent: this });", g.Indent, instanceName,
+			componentClassName(m.Name), instanceName, args)
+	case g.isRoot():
+		g.Printf("%sconst %s = new_mod_%s(\"%s\", %s);", g.Indent, instanceName, modName, g.logicalName(instanceName),
+			args)
+	default:
+		g.Printf("%sconst %s = new_mod_%s(\"%s\", %s);", g.Indent, instanceName, modName, instanceName, args)
+	}
 	g.genTrailingComment(g, m.Comments)
 	g.Print("\n")
 
@@ -553,6 +1041,8 @@ func (g *generator) GenerateModule(m *il.ModuleNode) error {
 // GenerateProvider generates a single provider instantiation. Each provider instantiation is generated as a call to
 // the appropriate provider constructor that is assigned to a local variable.
 func (g *generator) GenerateProvider(p *il.ProviderNode) error {
+	g.selectFile(p)
+
 	// If this provider has no alias, ignore it.
 	if p.Alias == "" {
 		return nil
@@ -570,12 +1060,17 @@ func (g *generator) GenerateProvider(p *il.ProviderNode) error {
 
 	var resName string
 	if g.isRoot() {
-		resName = fmt.Sprintf("\"%s\"", p.Alias)
+		resName = fmt.Sprintf("\"%s\"", g.logicalName(p.Alias))
 	} else {
 		resName = fmt.Sprintf("`${mod_name}_%s`", p.Alias)
 	}
 
-	g.Printf("%sconst %s = new %s(%s, %s);", g.Indent, name, qualifiedMemberName, resName, inputs)
+	optionsBag := ""
+	if g.isComponent() {
+		optionsBag = ", { parent: this }"
+	}
+
+	g.Printf("%sconst %s = new %s(%s, %s%s);", g.Indent, name, qualifiedMemberName, resName, inputs, optionsBag)
 	g.genTrailingComment(g, p.Comments)
 	g.Print("\n")
 	return nil
@@ -621,6 +1116,7 @@ func resourceTypeName(r *il.ResourceNode) (string, string, string, error) {
 // and the count variable name, if any.
 func (g *generator) makeResourceName(baseName, count string) string {
 	if g.isRoot() {
+		baseName = g.logicalName(baseName)
 		if count == "" {
 			return fmt.Sprintf(`"%s"`, baseName)
 		}
@@ -633,6 +1129,156 @@ func (g *generator) makeResourceName(baseName, count string) string {
 	return fmt.Sprintf("`%s-${%s}`", baseName, count)
 }
 
+// logicalName returns the logical name of the root module's resource, provider, or module instance with the given
+// base name: the base name with the generator's resource name prefix, truncated to the generator's maximum resource
+// name length, if any. A truncated name ends with a hash of the untruncated name so that distinct names remain
+// distinct.
+func (g *generator) logicalName(baseName string) string {
+	name := g.resourceNamePrefix + baseName
+	if g.maxResourceNameLength <= 0 || len(name) <= g.maxResourceNameLength {
+		return name
+	}
+
+	sum := sha256.Sum256([]byte(name))
+	hash := hex.EncodeToString(sum[:4])
+	return name[:g.maxResourceNameLength-len(hash)-1] + "-" + hash
+}
+
+// generateProvisionersTODO notes that the provisioners of a resource with a count are not converted. The provisioner
+// folded into a null_resource, if any, is converted regardless.
+func (g *generator) generateProvisionersTODO(r *il.ResourceNode) {
+	unconverted := len(r.Provisioners)
+	if _, ok := g.foldedProvisioner(r); ok {
+		unconverted--
+	}
+	if g.emitProvisioners && unconverted != 0 {
+		g.Printf("%s// TODO: the provisioners of resources with a count are not converted\n", g.Indent)
+	}
+}
+
+// generateProvisioners generates a `@pulumi/command` resource for each provisioner of the given single-instance
+// resource. Each command depends on the resource, which mirrors the point at which Terraform runs the provisioner.
+// Provisioners that have no Command equivalent are noted with a TODO. The generated statements are each preceded by a
+// newline, as the resource's own statement is not terminated by one. The provisioner folded into a null_resource, if
+// any, is skipped.
+func (g *generator) generateProvisioners(r *il.ResourceNode, name string) error {
+	if !g.emitProvisioners {
+		return nil
+	}
+
+	folded, _ := g.foldedProvisioner(r)
+	for i, p := range r.Provisioners {
+		if p == folded {
+			continue
+		}
+
+		var qualifiedMemberName string
+		var args map[string]il.BoundNode
+		switch p.Type {
+		case "local-exec":
+			qualifiedMemberName = "command.local.Command"
+			args = localCommandArgs(p)
+		case "remote-exec":
+			if _, ok := p.Properties.Elements["inline"]; !ok {
+				g.Printf("\n%s// TODO: only inline remote-exec provisioners are converted", g.Indent)
+				continue
+			}
+			qualifiedMemberName = "command.remote.Command"
+			args = remoteCommandArgs(p)
+		default:
+			g.Printf("\n%s// TODO: %s provisioners are not converted", g.Indent, p.Type)
+			continue
+		}
+
+		inputs, _, err := g.computeProperty(&il.BoundMapProperty{Elements: args}, false, "")
+		if err != nil {
+			return err
+		}
+
+		commandName, resName := name+"Provisioner", r.Name+"-provisioner"
+		if i > 0 {
+			commandName, resName = fmt.Sprintf("%s%d", commandName, i), fmt.Sprintf("%s-%d", resName, i)
+		}
+		options := "dependsOn: [" + name + "]"
+		if g.isComponent() {
+			options = "parent: this, " + options
+		}
+		g.Printf("\n%sconst %s = new %s(%s, %s, { %s });", g.Indent, commandName, qualifiedMemberName,
+			g.makeResourceName(resName, ""), inputs, options)
+	}
+	return nil
+}
+
+// commandProperty returns the name of the Command property that holds a provisioner's command: `delete` for
+// destroy-time provisioners and `create` otherwise.
+func commandProperty(p *il.Provisioner) string {
+	if p.Config != nil && p.Config.When == config.ProvisionerWhenDestroy {
+		return "delete"
+	}
+	return "create"
+}
+
+// localCommandArgs maps the properties of a `local-exec` provisioner to the arguments of a `local.Command`.
+func localCommandArgs(p *il.Provisioner) map[string]il.BoundNode {
+	args := map[string]il.BoundNode{}
+	for tfName, pulumiName := range map[string]string{
+		"command":     commandProperty(p),
+		"working_dir": "dir",
+		"environment": "environment",
+		"interpreter": "interpreter",
+	} {
+		if v, ok := p.Properties.Elements[tfName]; ok {
+			args[pulumiName] = v
+		}
+	}
+	return args
+}
+
+// remoteCommandArgs maps the properties and connection info of an inline `remote-exec` provisioner to the arguments of
+// a `remote.Command`. The inline commands are joined into a single script.
+func remoteCommandArgs(p *il.Provisioner) map[string]il.BoundNode {
+	args := map[string]il.BoundNode{}
+
+	script := &il.BoundOutput{}
+	switch inline := p.Properties.Elements["inline"].(type) {
+	case *il.BoundListProperty:
+		for i, e := range inline.Elements {
+			expr, ok := e.(il.BoundExpr)
+			if !ok {
+				continue
+			}
+			if i > 0 {
+				script.Exprs = append(script.Exprs, &il.BoundLiteral{ExprType: il.TypeString, Value: "\n"})
+			}
+			if output, ok := expr.(*il.BoundOutput); ok {
+				script.Exprs = append(script.Exprs, output.Exprs...)
+			} else {
+				script.Exprs = append(script.Exprs, expr)
+			}
+		}
+	case il.BoundExpr:
+		script.Exprs = append(script.Exprs, inline)
+	}
+	args[commandProperty(p)] = script
+
+	if p.Connection != nil {
+		connection := map[string]il.BoundNode{}
+		for tfName, pulumiName := range map[string]string{
+			"host":        "host",
+			"user":        "user",
+			"password":    "password",
+			"private_key": "privateKey",
+			"port":        "port",
+		} {
+			if v, ok := p.Connection.Elements[tfName]; ok {
+				connection[pulumiName] = v
+			}
+		}
+		args["connection"] = &il.BoundMapProperty{Elements: connection}
+	}
+	return args
+}
+
 // generateResource handles the generation of instantiations of non-builtin resources.
 func (g *generator) generateResource(r *il.ResourceNode) error {
 	provider, module, memberName, err := resourceTypeName(r)
@@ -644,6 +1290,9 @@ func (g *generator) generateResource(r *il.ResourceNode) error {
 	}
 
 	var resourceOptions []string
+	if g.isComponent() {
+		resourceOptions = append(resourceOptions, "parent: this")
+	}
 	if r.Provider.Alias != "" {
 		resourceOptions = append(resourceOptions, "provider: "+g.nodeName(r.Provider))
 	}
@@ -689,6 +1338,24 @@ func (g *generator) generateResource(r *il.ResourceNode) error {
 		resourceOptions = append(resourceOptions, buf.String())
 	}
 
+	if r.Protect && !r.IsDataSource {
+		resourceOptions = append(resourceOptions, "protect: true")
+	}
+
+	// Each previous name of a moved resource becomes an alias. The instances of a counted resource are aliased to the
+	// instances of the same index, whose names refer to the loop variable.
+	if len(r.Aliases) != 0 && !r.IsDataSource {
+		count := ""
+		if r.Count != nil && !g.isConditionalResource(r) {
+			count = "i"
+		}
+		aliases := make([]string, len(r.Aliases))
+		for i, name := range r.Aliases {
+			aliases[i] = fmt.Sprintf("{ name: %s }", g.makeResourceName(name, count))
+		}
+		resourceOptions = append(resourceOptions, fmt.Sprintf("aliases: [%s]", strings.Join(aliases, ", ")))
+	}
+
 	optionsBag := ""
 	if len(resourceOptions) != 0 {
 		optionsBag = fmt.Sprintf("{ %s }", strings.Join(resourceOptions, ", "))
@@ -698,11 +1365,26 @@ func (g *generator) generateResource(r *il.ResourceNode) error {
 	qualifiedMemberName := fmt.Sprintf("%s%s.%s", provider, module, memberName)
 
 	// Because data sources are treated as normal function calls, we treat them a little bit differently by first
-	// rewriting them into calls to the `__dataSource` intrinsic.
+	// rewriting them into calls to the `__dataSource` intrinsic. A null_resource has no Pulumi equivalent, so it is
+	// replaced by a `local.Command`.
 	properties := il.BoundNode(r.Properties)
-	if r.IsDataSource {
+	switch {
+	case r.IsNullResource():
+		qualifiedMemberName, properties = "command.local.Command", g.nullResourceArgs(r)
+	case r.IsDataSource:
 		properties = newDataSourceCall(qualifiedMemberName, properties, optionsBag)
 	}
+	dataSourceOptions := optionsBag
+	computeInputs := func(indent bool, count string) (string, bool, error) {
+		if r.IsDataSource && g.useOutputDataSources {
+			// The Output-returning form of a data source accepts Input-typed arguments and needs no further wrapping,
+			// so it is reported as transformed.
+			call, err := g.computeOutputDataSourceCall(qualifiedMemberName, r.Properties, dataSourceOptions, indent,
+				count)
+			return call, true, err
+		}
+		return g.computeProperty(properties, indent, count)
+	}
 
 	if optionsBag != "" {
 		optionsBag = ", " + optionsBag
@@ -710,7 +1392,7 @@ func (g *generator) generateResource(r *il.ResourceNode) error {
 
 	if r.Count == nil {
 		// If count is nil, this is a single-instance resource.
-		inputs, transformed, err := g.computeProperty(properties, false, "")
+		inputs, transformed, err := computeInputs(false, "")
 		if err != nil {
 			return err
 		}
@@ -718,6 +1400,9 @@ func (g *generator) generateResource(r *il.ResourceNode) error {
 		if !r.IsDataSource {
 			resName := g.makeResourceName(r.Name, "")
 			g.Printf("%sconst %s = new %s(%s, %s%s);", g.Indent, name, qualifiedMemberName, resName, inputs, optionsBag)
+			if err = g.generateProvisioners(r, name); err != nil {
+				return err
+			}
 		} else {
 			// TODO: explicit dependencies
 
@@ -733,46 +1418,24 @@ func (g *generator) generateResource(r *il.ResourceNode) error {
 		}
 	} else if g.isConditionalResource(r) {
 		// If this is a confitional resource, we need to generate a resource that is instantiated inside an if statement.
-
-		// If this resource's properties reference its count, we need to generate its code slightly differently:
-		// a) We need to assign the value of the count to a local s.t. the properties have something to reference
-		// b) We want to avoid changing the type of the count if it is not a boolean so that downstream code does not
-		//    require changes.
-		hasCountReference, countVariableName := false, ""
-		_, err = il.VisitBoundNode(properties, il.IdentityVisitor, func(n il.BoundNode) (il.BoundNode, error) {
-			if n, ok := n.(*il.BoundVariableAccess); ok {
-				_, isCountVar := n.TFVar.(*config.CountVariable)
-				hasCountReference = hasCountReference || isCountVar
-			}
-			return n, nil
-		})
-		contract.Assert(err == nil)
-
-		// If the resource's properties do not reference the count, we can simplify the condition expression for
-		// cleaner-looking code. We don't do this if the count is referenced because it can change the type of the
-		// expression (e.g. from a number to a boolean, if the number is statically coerceable to a boolean).
-		count := r.Count
-		if !hasCountReference {
-			count = il.SimplifyBooleanExpressions(count.(il.BoundExpr))
-		}
+		// We can simplify the condition expression for cleaner-looking code.
+		count := il.SimplifyBooleanExpressions(r.Count.(il.BoundExpr))
 		condition, _, err := g.computeProperty(count, false, "")
 		if err != nil {
 			return err
 		}
 
-		// If the resoure's properties reference the count, assign its value to a local s.t. the properties have
-		// something to refer to.
-		if hasCountReference {
-			countVariableName = fmt.Sprintf("create%s", title(name))
-			g.Printf("%sconst %s = %s;\n", g.Indent, countVariableName, condition)
-			condition = countVariableName
+		// A conditional resource has at most one instance, so any references to `count.index` in its properties
+		// refer to the index of that instance.
+		if properties, err = il.BindCountIndex(properties, 0); err != nil {
+			return err
 		}
-
-		inputs, transformed, err := g.computeProperty(properties, true, countVariableName)
+		inputs, transformed, err := computeInputs(true, "0")
 		if err != nil {
 			return err
 		}
 
+		g.generateProvisionersTODO(r)
 		g.Printf("%slet %s: %s | undefined;\n", g.Indent, name, qualifiedMemberName)
 		ifFmt := "%sif (%s) {\n"
 		if count.Type() != il.TypeBool {
@@ -799,12 +1462,18 @@ func (g *generator) generateResource(r *il.ResourceNode) error {
 		})
 		g.Printf("%s}", g.Indent)
 	} else {
-		// Otherwise we need to Generate multiple resources in a loop.
-		count, _, err := g.computeProperty(r.Count, false, "")
+		// Otherwise we need to Generate multiple resources in a loop. The elements of the resulting array are indexed
+		// by count.index. Counts that are typed as strings--e.g. references to untyped variables--are converted to
+		// numbers so that the loop bound is numeric.
+		countExpr := r.Count
+		if e, ok := countExpr.(il.BoundExpr); ok && e.Type() == il.TypeString {
+			countExpr = il.NewCoerceCall(e, il.TypeNumber)
+		}
+		count, _, err := g.computeProperty(countExpr, false, "")
 		if err != nil {
 			return err
 		}
-		inputs, transformed, err := g.computeProperty(properties, true, "i")
+		inputs, transformed, err := computeInputs(true, "i")
 		if err != nil {
 			return err
 		}
@@ -818,6 +1487,7 @@ func (g *generator) generateResource(r *il.ResourceNode) error {
 			arrElementType = fmt.Sprintf(fmtStr, provider, module, cases.Title(language.Und, cases.NoLower).String(memberName))
 		}
 
+		g.generateProvisionersTODO(r)
 		g.Printf("%sconst %s: %s[] = [];\n", g.Indent, name, arrElementType)
 		g.Printf("%sfor (let i = 0; i < %s; i++) {\n", g.Indent, count)
 		g.Indented(func() {
@@ -845,20 +1515,45 @@ func (g *generator) generateResource(r *il.ResourceNode) error {
 	return nil
 }
 
+// computeOutputDataSourceCall computes the invocation of the Output-returning form of the given data source function,
+// e.g. `aws.getAmiOutput`. As that form accepts Input-typed arguments, the inputs are computed like those of a managed
+// resource rather than being lifted into an apply.
+func (g *generator) computeOutputDataSourceCall(function string, inputs *il.BoundMapProperty, optionsBag string,
+	indent bool, count string) (string, error) {
+
+	args := ""
+	if len(inputs.Elements) != 0 {
+		computed, _, err := g.computeProperty(inputs, indent, count)
+		if err != nil {
+			return "", err
+		}
+		args = computed
+	} else if optionsBag != "" {
+		args = "{}"
+	}
+	if optionsBag != "" {
+		args += ", " + optionsBag
+	}
+	return fmt.Sprintf("%sOutput(%s)", function, args), nil
+}
+
 // GenerateResource generates a single resource instantiation. Each resource instantiation is generated as a call or
 // sequence of calls (in the case of a counted resource) to the approriate resource constructor or data source
 // function. Single-instance resources are assigned to a local variable; counted resources are stored in an array-typed
 // local.
 func (g *generator) GenerateResource(r *il.ResourceNode) error {
+	g.selectFile(r)
 	g.genLeadingComment(g, r.Comments)
 
 	// If this resource's provider is one of the built-ins, perform whatever provider-specific code generation is
 	// required.
 	var err error
-	switch r.Provider.Name {
-	case "archive":
+	switch {
+	case r.IsRemoteState():
+		err = g.generateRemoteState(r)
+	case r.Provider.Name == "archive":
 		err = g.generateArchive(r)
-	case "http":
+	case r.Provider.Name == "http":
 		err = g.generateHTTP(r)
 	default:
 		err = g.generateResource(r)
@@ -878,13 +1573,15 @@ func (g *generator) GenerateOutputs(os []*il.OutputNode) error {
 	if len(os) == 0 {
 		return nil
 	}
+	g.selectFile(os[0])
 
 	// Otherwise, what we do depends on whether or not we're the root module: if we are, we generate a list of exports;
-	// if we are not, we generate an appropriate return statement with the outputs as properties in a map.
+	// if we are not, we generate an appropriate return statement with the outputs as properties in a map, or, if we
+	// are generating a component, assignments to the component's output fields.
 	isRoot := g.isRoot()
 
 	g.Printf("\n")
-	if !isRoot {
+	if !isRoot && !g.emitComponents {
 		g.Printf("%sreturn {\n", g.Indent)
 		g.Indent += "    "
 	}
@@ -893,6 +1590,9 @@ func (g *generator) GenerateOutputs(os []*il.OutputNode) error {
 		if err != nil {
 			return err
 		}
+		if o.Sensitive {
+			outputs = fmt.Sprintf("pulumi.secret(%s)", outputs)
+		}
 
 		// We combine the leading and trailing comments for the output itself and its value.
 
@@ -907,16 +1607,22 @@ func (g *generator) GenerateOutputs(os []*il.OutputNode) error {
 
 		g.genLeadingComment(g, comments)
 
-		if !isRoot {
+		switch {
+		case g.isComponent():
+			if !o.Sensitive {
+				outputs = fmt.Sprintf("pulumi.output(%s)", outputs)
+			}
+			g.Printf("%s%s = %s;", g.Indent, componentField(g.nodeName(o)), outputs)
+		case !isRoot:
 			g.Printf("%s%s: %s,", g.Indent, g.nodeName(o), outputs)
-		} else {
+		default:
 			g.Printf("export const %s = %s;", g.nodeName(o), outputs)
 		}
 
 		g.genTrailingComment(g, comments)
 		g.Print("\n")
 	}
-	if !isRoot {
+	if !isRoot && !g.emitComponents {
 		g.Indent = g.Indent[:len(g.Indent)-4]
 		g.Printf("%s};\n", g.Indent)
 	}
@@ -2,11 +2,15 @@ package nodejs
 
 import (
 	"bytes"
+	"errors"
 	"os"
 	"path/filepath"
+	"regexp"
+	"sort"
 	"strings"
 	"testing"
 
+	"github.com/spf13/afero"
 	"github.com/stretchr/testify/assert"
 
 	"github.com/pulumi/pulumi-terraform-bridge/v3/pkg/tf2pulumi/gen"
@@ -14,6 +18,9 @@ import (
 	"github.com/pulumi/pulumi-terraform-bridge/v3/pkg/tf2pulumi/internal/config"
 	"github.com/pulumi/pulumi-terraform-bridge/v3/pkg/tf2pulumi/internal/config/module"
 	"github.com/pulumi/pulumi-terraform-bridge/v3/pkg/tf2pulumi/test"
+	"github.com/pulumi/pulumi-terraform-bridge/v3/pkg/tfbridge"
+	shim "github.com/pulumi/pulumi-terraform-bridge/v3/pkg/tfshim"
+	"github.com/pulumi/pulumi-terraform-bridge/v3/pkg/tfshim/schema"
 )
 
 func TestLegalIdentifiers(t *testing.T) {
@@ -104,6 +111,65 @@ func TestLowerToLiteral(t *testing.T) {
 	assert.Equal(t, "{\n    key: `module: "+expectedPath+" root: .`,\n}", computed)
 }
 
+func TestLowerFilePaths(t *testing.T) {
+	call := func(path string) *il.BoundCall {
+		return &il.BoundCall{
+			Func:     "file",
+			ExprType: il.TypeString,
+			Args:     []il.BoundExpr{&il.BoundLiteral{ExprType: il.TypeString, Value: path}},
+		}
+	}
+
+	g := &generator{
+		rootPath: ".",
+		module:   &il.Graph{Path: "./foo/bar"},
+	}
+	g.Emitter = gen.NewEmitter(nil, g)
+
+	for path, expected := range map[string]string{
+		"policy.json":  filepath.Join("foo", "bar", "policy.json"),
+		"/policy.json": "/policy.json",
+	} {
+		lowered, err := g.lowerToLiterals(call(path))
+		assert.NoError(t, err)
+		assert.Equal(t, expected, lowered.(*il.BoundCall).Args[0].(*il.BoundLiteral).Value)
+	}
+
+	// Paths in the root module are left as-is.
+	g.module = &il.Graph{IsRoot: true, Path: "."}
+	lowered, err := g.lowerToLiterals(call("./policy.json"))
+	assert.NoError(t, err)
+	assert.Equal(t, "./policy.json", lowered.(*il.BoundCall).Args[0].(*il.BoundLiteral).Value)
+}
+
+func TestNumericLiterals(t *testing.T) {
+	cases := []struct {
+		value    interface{}
+		expected string
+	}{
+		{int64(42), "42"},
+		{int64(-7), "-7"},
+		{int64(9007199254740993), "9007199254740993"},
+		{3.14159, "3.14159"},
+		{2.0, "2"},
+		{0.1, "0.1"},
+		{1e10, "1e10"},
+		{1.5e-7, "1.5e-7"},
+		{123456789.5, "123456789.5"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.expected, func(t *testing.T) {
+			g := &generator{}
+			g.Emitter = gen.NewEmitter(nil, g)
+
+			computed, _, err := g.computeProperty(&il.BoundLiteral{ExprType: il.TypeNumber, Value: c.value}, false, "")
+			assert.NoError(t, err)
+			assert.Equal(t, c.expected, computed)
+		})
+	}
+}
+
 func loadConfig(t *testing.T, path string) *config.Config {
 	conf, err := config.LoadDir(path)
 	if err != nil {
@@ -155,7 +221,7 @@ func TestComments(t *testing.T) {
 	}
 
 	b.Reset()
-	lang, err = New("main", "0.17.1", false /*prompt*/, &b)
+	lang, err = New("main", "0.17.1", false, &b)
 	assert.NoError(t, err)
 	err = gen.Generate([]*il.Graph{g}, lang)
 	assert.NoError(t, err)
@@ -237,7 +303,7 @@ func TestConditionals(t *testing.T) {
 	}
 
 	var b bytes.Buffer
-	lang, err := New("main", "1.0.0", true, &b)
+	lang, err := New("main", "1.0.0", true /*prompt*/, &b)
 	assert.NoError(t, err)
 	err = gen.Generate([]*il.Graph{g}, lang)
 	assert.NoError(t, err)
@@ -258,7 +324,7 @@ func TestMetaProperties(t *testing.T) {
 	}
 
 	var b bytes.Buffer
-	lang, err := New("main", "1.0.0", true, &b)
+	lang, err := New("main", "1.0.0", true /*prompt*/, &b)
 	assert.NoError(t, err)
 	err = gen.Generate([]*il.Graph{g}, lang)
 	assert.NoError(t, err)
@@ -266,3 +332,854 @@ func TestMetaProperties(t *testing.T) {
 	expectedText := readFile(t, "testdata/test_meta_properties/index.ts")
 	assert.Equal(t, expectedText, b.String())
 }
+
+func TestFunctions(t *testing.T) {
+	info := test.NewProviderInfoSource("../../testdata/providers")
+	conf := loadConfig(t, "testdata/test_functions")
+	g, err := il.BuildGraph(module.NewTree("main", conf), &il.BuildOptions{
+		ProviderInfoSource:    info,
+		AllowMissingProviders: true,
+	})
+	if err != nil {
+		t.Fatalf("could not build graph: %v", err)
+	}
+
+	var b bytes.Buffer
+	lang, err := New("main", "1.0.0", true /*prompt*/, &b)
+	assert.NoError(t, err)
+	err = gen.Generate([]*il.Graph{g}, lang)
+	assert.NoError(t, err)
+
+	expectedText := readFile(t, "testdata/test_functions/index.ts")
+	assert.Equal(t, expectedText, b.String())
+}
+
+func TestLookup(t *testing.T) {
+	info := test.NewProviderInfoSource("../../testdata/providers")
+	conf := loadConfig(t, "testdata/test_lookup")
+	g, err := il.BuildGraph(module.NewTree("main", conf), &il.BuildOptions{
+		ProviderInfoSource:    info,
+		AllowMissingProviders: true,
+	})
+	if err != nil {
+		t.Fatalf("could not build graph: %v", err)
+	}
+
+	var b bytes.Buffer
+	lang, err := New("main", "1.0.0", true /*prompt*/, &b)
+	assert.NoError(t, err)
+	err = gen.Generate([]*il.Graph{g}, lang)
+	assert.NoError(t, err)
+
+	expectedText := readFile(t, "testdata/test_lookup/index.ts")
+	assert.Equal(t, expectedText, b.String())
+}
+
+func TestElement(t *testing.T) {
+	info := test.NewProviderInfoSource("../../testdata/providers")
+	conf := loadConfig(t, "testdata/test_element")
+	g, err := il.BuildGraph(module.NewTree("main", conf), &il.BuildOptions{
+		ProviderInfoSource:    info,
+		AllowMissingProviders: true,
+	})
+	if err != nil {
+		t.Fatalf("could not build graph: %v", err)
+	}
+
+	var b bytes.Buffer
+	lang, err := New("main", "1.0.0", true /*prompt*/, &b)
+	assert.NoError(t, err)
+	err = gen.Generate([]*il.Graph{g}, lang)
+	assert.NoError(t, err)
+
+	expectedText := readFile(t, "testdata/test_element/index.ts")
+	assert.Equal(t, expectedText, b.String())
+}
+
+func TestMerge(t *testing.T) {
+	info := test.NewProviderInfoSource("../../testdata/providers")
+	conf := loadConfig(t, "testdata/test_merge")
+	g, err := il.BuildGraph(module.NewTree("main", conf), &il.BuildOptions{
+		ProviderInfoSource:    info,
+		AllowMissingProviders: true,
+	})
+	if err != nil {
+		t.Fatalf("could not build graph: %v", err)
+	}
+
+	var b bytes.Buffer
+	lang, err := New("main", "1.0.0", true /*prompt*/, &b)
+	assert.NoError(t, err)
+	err = gen.Generate([]*il.Graph{g}, lang)
+	assert.NoError(t, err)
+
+	expectedText := readFile(t, "testdata/test_merge/index.ts")
+	assert.Equal(t, expectedText, b.String())
+}
+
+func TestLocals(t *testing.T) {
+	info := test.NewProviderInfoSource("../../testdata/providers")
+	conf := loadConfig(t, "testdata/test_locals")
+	g, err := il.BuildGraph(module.NewTree("main", conf), &il.BuildOptions{
+		ProviderInfoSource:    info,
+		AllowMissingProviders: true,
+	})
+	if err != nil {
+		t.Fatalf("could not build graph: %v", err)
+	}
+
+	var b bytes.Buffer
+	lang, err := New("main", "1.0.0", true /*prompt*/, &b)
+	assert.NoError(t, err)
+	err = gen.Generate([]*il.Graph{g}, lang)
+	assert.NoError(t, err)
+
+	expectedText := readFile(t, "testdata/test_locals/index.ts")
+	assert.Equal(t, expectedText, b.String())
+}
+
+func TestDynamicBlocks(t *testing.T) {
+	info := test.NewProviderInfoSource("../../testdata/providers")
+	conf := loadConfig(t, "testdata/test_dynamic_blocks")
+	g, err := il.BuildGraph(module.NewTree("main", conf), &il.BuildOptions{
+		ProviderInfoSource:    info,
+		AllowMissingProviders: true,
+	})
+	if err != nil {
+		t.Fatalf("could not build graph: %v", err)
+	}
+
+	var b bytes.Buffer
+	lang, err := New("main", "1.0.0", true /*prompt*/, &b)
+	assert.NoError(t, err)
+	err = gen.Generate([]*il.Graph{g}, lang)
+	assert.NoError(t, err)
+
+	expectedText := readFile(t, "testdata/test_dynamic_blocks/index.ts")
+	assert.Equal(t, expectedText, b.String())
+}
+
+func TestSplat(t *testing.T) {
+	info := test.NewProviderInfoSource("../../testdata/providers")
+	conf := loadConfig(t, "testdata/test_splat")
+	g, err := il.BuildGraph(module.NewTree("main", conf), &il.BuildOptions{
+		ProviderInfoSource:    info,
+		AllowMissingProviders: true,
+	})
+	if err != nil {
+		t.Fatalf("could not build graph: %v", err)
+	}
+
+	var b bytes.Buffer
+	lang, err := New("main", "1.0.0", true /*prompt*/, &b)
+	assert.NoError(t, err)
+	err = gen.Generate([]*il.Graph{g}, lang)
+	assert.NoError(t, err)
+
+	expectedText := readFile(t, "testdata/test_splat/index.ts")
+	assert.Equal(t, expectedText, b.String())
+}
+
+func TestTODOStubs(t *testing.T) {
+	info := test.NewProviderInfoSource("../../testdata/providers")
+	conf := loadConfig(t, "testdata/test_todo_stubs")
+	g, err := il.BuildGraph(module.NewTree("main", conf), &il.BuildOptions{
+		ProviderInfoSource:    info,
+		AllowMissingProviders: true,
+	})
+	if err != nil {
+		t.Fatalf("could not build graph: %v", err)
+	}
+
+	var b bytes.Buffer
+	lang, err := NewWithOptions("main", "1.0.0", Options{UsePromptDataSources: true, EmitTODOs: true}, &b)
+	assert.NoError(t, err)
+	err = gen.Generate([]*il.Graph{g}, lang)
+	assert.NoError(t, err)
+
+	expectedText := readFile(t, "testdata/test_todo_stubs/index.ts")
+	assert.Equal(t, expectedText, b.String())
+}
+
+func TestSensitiveOutputs(t *testing.T) {
+	info := test.NewProviderInfoSource("../../testdata/providers")
+	conf := loadConfig(t, "testdata/test_sensitive_outputs")
+	g, err := il.BuildGraph(module.NewTree("main", conf), &il.BuildOptions{
+		ProviderInfoSource:    info,
+		AllowMissingProviders: true,
+	})
+	if err != nil {
+		t.Fatalf("could not build graph: %v", err)
+	}
+
+	var b bytes.Buffer
+	lang, err := New("main", "1.0.0", true /*prompt*/, &b)
+	assert.NoError(t, err)
+	err = gen.Generate([]*il.Graph{g}, lang)
+	assert.NoError(t, err)
+
+	expectedText := readFile(t, "testdata/test_sensitive_outputs/index.ts")
+	assert.Equal(t, expectedText, b.String())
+}
+
+func TestJSONEncode(t *testing.T) {
+	info := test.NewProviderInfoSource("../../testdata/providers")
+	conf := loadConfig(t, "testdata/test_jsonencode")
+	g, err := il.BuildGraph(module.NewTree("main", conf), &il.BuildOptions{
+		ProviderInfoSource:    info,
+		AllowMissingProviders: true,
+	})
+	if err != nil {
+		t.Fatalf("could not build graph: %v", err)
+	}
+
+	var b bytes.Buffer
+	lang, err := New("main", "1.0.0", true /*prompt*/, &b)
+	assert.NoError(t, err)
+	err = gen.Generate([]*il.Graph{g}, lang)
+	assert.NoError(t, err)
+
+	expectedText := readFile(t, "testdata/test_jsonencode/index.ts")
+	assert.Equal(t, expectedText, b.String())
+}
+
+func TestLifecycle(t *testing.T) {
+	info := test.NewProviderInfoSource("../../testdata/providers")
+	conf := loadConfig(t, "testdata/test_lifecycle")
+	g, err := il.BuildGraph(module.NewTree("main", conf), &il.BuildOptions{
+		ProviderInfoSource:    info,
+		AllowMissingProviders: true,
+	})
+	if err != nil {
+		t.Fatalf("could not build graph: %v", err)
+	}
+
+	var b bytes.Buffer
+	lang, err := New("main", "1.0.0", true /*prompt*/, &b)
+	assert.NoError(t, err)
+	err = gen.Generate([]*il.Graph{g}, lang)
+	assert.NoError(t, err)
+
+	expectedText := readFile(t, "testdata/test_lifecycle/index.ts")
+	assert.Equal(t, expectedText, b.String())
+}
+
+func TestTypedVariables(t *testing.T) {
+	info := test.NewProviderInfoSource("../../testdata/providers")
+	conf := loadConfig(t, "testdata/test_typed_variables")
+	g, err := il.BuildGraph(module.NewTree("main", conf), &il.BuildOptions{
+		ProviderInfoSource:    info,
+		AllowMissingProviders: true,
+	})
+	if err != nil {
+		t.Fatalf("could not build graph: %v", err)
+	}
+
+	var b bytes.Buffer
+	lang, err := New("main", "1.0.0", true /*prompt*/, &b)
+	assert.NoError(t, err)
+	err = gen.Generate([]*il.Graph{g}, lang)
+	assert.NoError(t, err)
+
+	expectedText := readFile(t, "testdata/test_typed_variables/index.ts")
+	assert.Equal(t, expectedText, b.String())
+}
+
+func TestComponents(t *testing.T) {
+	info := test.NewProviderInfoSource("../../testdata/providers")
+	conf := loadConfig(t, "testdata/test_components")
+	tree := module.NewTree("", conf)
+	storage := module.NewStorage(t.TempDir())
+	storage.Mode = module.GetModeGet
+	err := tree.Load(storage)
+	if err != nil {
+		t.Fatalf("could not load modules: %v", err)
+	}
+
+	children := tree.Children()
+	names := make([]string, 0, len(children))
+	for name := range children {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var graphs []*il.Graph
+	trees := []*module.Tree{}
+	for _, name := range names {
+		trees = append(trees, children[name])
+	}
+	for _, m := range append(trees, tree) {
+		g, err := il.BuildGraph(m, &il.BuildOptions{
+			ProviderInfoSource:    info,
+			AllowMissingProviders: true,
+		})
+		if err != nil {
+			t.Fatalf("could not build graph: %v", err)
+		}
+		graphs = append(graphs, g)
+	}
+
+	var b bytes.Buffer
+	lang, err := NewWithOptions("main", "1.0.0", Options{UsePromptDataSources: true, EmitComponents: true}, &b)
+	assert.NoError(t, err)
+	err = gen.Generate(graphs, lang)
+	assert.NoError(t, err)
+
+	expectedText := readFile(t, "testdata/test_components/index.ts")
+	assert.Equal(t, expectedText, b.String())
+}
+
+func TestCounts(t *testing.T) {
+	info := test.NewProviderInfoSource("../../testdata/providers")
+	conf := loadConfig(t, "testdata/test_counts")
+	g, err := il.BuildGraph(module.NewTree("main", conf), &il.BuildOptions{
+		ProviderInfoSource:    info,
+		AllowMissingProviders: true,
+	})
+	if err != nil {
+		t.Fatalf("could not build graph: %v", err)
+	}
+
+	var b bytes.Buffer
+	lang, err := New("main", "1.0.0", true /*prompt*/, &b)
+	assert.NoError(t, err)
+	err = gen.Generate([]*il.Graph{g}, lang)
+	assert.NoError(t, err)
+
+	expectedText := readFile(t, "testdata/test_counts/index.ts")
+	assert.Equal(t, expectedText, b.String())
+}
+
+func TestPostProcess(t *testing.T) {
+	info := test.NewProviderInfoSource("../../testdata/providers")
+	conf := loadConfig(t, "testdata/test_locals")
+	g, err := il.BuildGraph(module.NewTree("main", conf), &il.BuildOptions{
+		ProviderInfoSource:    info,
+		AllowMissingProviders: true,
+	})
+	if err != nil {
+		t.Fatalf("could not build graph: %v", err)
+	}
+
+	const header = "// Copyright 2022, Widgets Inc.\n\n"
+	addHeader := func(program string) (string, error) {
+		return header + program, nil
+	}
+
+	var b bytes.Buffer
+	lang, err := NewWithOptions("main", "1.0.0", Options{UsePromptDataSources: true, PostProcess: addHeader}, &b)
+	assert.NoError(t, err)
+	err = gen.Generate([]*il.Graph{g}, lang)
+	assert.NoError(t, err)
+
+	expectedText := readFile(t, "testdata/test_locals/index.ts")
+	assert.Equal(t, header+expectedText, b.String())
+
+	// Errors from the post-processor are reported and nothing is written.
+	fail := func(program string) (string, error) {
+		return "", errors.New("formatter failed")
+	}
+
+	b.Reset()
+	lang, err = NewWithOptions("main", "1.0.0", Options{UsePromptDataSources: true, PostProcess: fail}, &b)
+	assert.NoError(t, err)
+	err = gen.Generate([]*il.Graph{g}, lang)
+	assert.ErrorContains(t, err, "formatter failed")
+	assert.Empty(t, b.String())
+}
+
+func TestTry(t *testing.T) {
+	info := test.NewProviderInfoSource("../../testdata/providers")
+	conf := loadConfig(t, "testdata/test_try")
+	g, err := il.BuildGraph(module.NewTree("main", conf), &il.BuildOptions{
+		ProviderInfoSource:    info,
+		AllowMissingProviders: true,
+	})
+	if err != nil {
+		t.Fatalf("could not build graph: %v", err)
+	}
+
+	var b bytes.Buffer
+	lang, err := New("main", "1.0.0", true /*prompt*/, &b)
+	assert.NoError(t, err)
+	err = gen.Generate([]*il.Graph{g}, lang)
+	assert.NoError(t, err)
+
+	expectedText := readFile(t, "testdata/test_try/index.ts")
+	assert.Equal(t, expectedText, b.String())
+}
+
+func TestProviderVersions(t *testing.T) {
+	info := test.NewProviderInfoSource("../../testdata/providers")
+	conf := loadConfig(t, "testdata/test_provider_versions")
+	g, err := il.BuildGraph(module.NewTree("main", conf), &il.BuildOptions{
+		ProviderInfoSource:    info,
+		AllowMissingProviders: true,
+	})
+	if err != nil {
+		t.Fatalf("could not build graph: %v", err)
+	}
+
+	var b bytes.Buffer
+	lang, err := New("main", "1.0.0", true /*prompt*/, &b)
+	assert.NoError(t, err)
+	err = gen.Generate([]*il.Graph{g}, lang)
+	assert.NoError(t, err)
+
+	expectedText := readFile(t, "testdata/test_provider_versions/index.ts")
+	assert.Equal(t, expectedText, b.String())
+}
+
+func TestOutputDataSources(t *testing.T) {
+	info := test.NewProviderInfoSource("../../testdata/providers")
+	conf := loadConfig(t, "testdata/test_output_data_sources")
+	g, err := il.BuildGraph(module.NewTree("main", conf), &il.BuildOptions{
+		ProviderInfoSource:    info,
+		AllowMissingProviders: true,
+	})
+	if err != nil {
+		t.Fatalf("could not build graph: %v", err)
+	}
+
+	// Output-returning data sources take precedence over prompt data sources.
+	var b bytes.Buffer
+	lang, err := NewWithOptions("main", "1.0.0", Options{UsePromptDataSources: true, UseOutputDataSources: true}, &b)
+	assert.NoError(t, err)
+	err = gen.Generate([]*il.Graph{g}, lang)
+	assert.NoError(t, err)
+
+	expectedText := readFile(t, "testdata/test_output_data_sources/index.ts")
+	assert.Equal(t, expectedText, b.String())
+}
+
+func TestFiles(t *testing.T) {
+	info := test.NewProviderInfoSource("../../testdata/providers")
+	conf := loadConfig(t, "testdata/test_files")
+	g, err := il.BuildGraph(module.NewTree("main", conf), &il.BuildOptions{
+		ProviderInfoSource:    info,
+		AllowMissingProviders: true,
+	})
+	if err != nil {
+		t.Fatalf("could not build graph: %v", err)
+	}
+
+	var b bytes.Buffer
+	lang, err := New("main", "1.0.0", true /*prompt*/, &b)
+	assert.NoError(t, err)
+	err = gen.Generate([]*il.Graph{g}, lang)
+	assert.NoError(t, err)
+
+	expectedText := readFile(t, "testdata/test_files/index.ts")
+	assert.Equal(t, expectedText, b.String())
+}
+
+func TestProvisioners(t *testing.T) {
+	info := test.NewProviderInfoSource("../../testdata/providers")
+	conf := loadConfig(t, "testdata/test_provisioners")
+	g, err := il.BuildGraph(module.NewTree("main", conf), &il.BuildOptions{
+		ProviderInfoSource:    info,
+		AllowMissingProviders: true,
+	})
+	if err != nil {
+		t.Fatalf("could not build graph: %v", err)
+	}
+
+	var b bytes.Buffer
+	lang, err := NewWithOptions("main", "1.0.0", Options{UsePromptDataSources: true, EmitProvisioners: true}, &b)
+	assert.NoError(t, err)
+	err = gen.Generate([]*il.Graph{g}, lang)
+	assert.NoError(t, err)
+
+	expectedText := readFile(t, "testdata/test_provisioners/index.ts")
+	assert.Equal(t, expectedText, b.String())
+}
+
+func TestESM(t *testing.T) {
+	info := test.NewProviderInfoSource("../../testdata/providers")
+	conf := loadConfig(t, "testdata/test_esm")
+
+	for _, esm := range []bool{false, true} {
+		g, err := il.BuildGraph(module.NewTree("main", conf), &il.BuildOptions{
+			ProviderInfoSource:    info,
+			AllowMissingProviders: true,
+		})
+		if err != nil {
+			t.Fatalf("could not build graph: %v", err)
+		}
+
+		var b bytes.Buffer
+		lang, err := NewWithOptions("main", "1.0.0", Options{UsePromptDataSources: true, EmitESM: esm}, &b)
+		assert.NoError(t, err)
+		err = gen.Generate([]*il.Graph{g}, lang)
+		assert.NoError(t, err)
+
+		expectedPath := "testdata/test_esm/index.ts"
+		if esm {
+			expectedPath = "testdata/test_esm/index.esm.ts"
+		}
+		assert.Equal(t, readFile(t, expectedPath), b.String())
+	}
+}
+
+func TestCountIndex(t *testing.T) {
+	info := test.NewProviderInfoSource("../../testdata/providers")
+	conf := loadConfig(t, "testdata/test_count_index")
+	g, err := il.BuildGraph(module.NewTree("main", conf), &il.BuildOptions{
+		ProviderInfoSource:    info,
+		AllowMissingProviders: true,
+	})
+	if err != nil {
+		t.Fatalf("could not build graph: %v", err)
+	}
+
+	var b bytes.Buffer
+	lang, err := New("main", "1.0.0", true /*prompt*/, &b)
+	assert.NoError(t, err)
+	err = gen.Generate([]*il.Graph{g}, lang)
+	assert.NoError(t, err)
+
+	expectedText := readFile(t, "testdata/test_count_index/index.ts")
+	assert.Equal(t, expectedText, b.String())
+}
+
+func TestRemoteState(t *testing.T) {
+	info := test.NewProviderInfoSource("../../testdata/providers")
+	conf := loadConfig(t, "testdata/test_remote_state")
+	g, err := il.BuildGraph(module.NewTree("main", conf), &il.BuildOptions{
+		ProviderInfoSource:    info,
+		AllowMissingProviders: true,
+	})
+	if err != nil {
+		t.Fatalf("could not build graph: %v", err)
+	}
+
+	var b bytes.Buffer
+	lang, err := New("main", "1.0.0", true /*prompt*/, &b)
+	assert.NoError(t, err)
+	err = gen.Generate([]*il.Graph{g}, lang)
+	assert.NoError(t, err)
+
+	expectedText := readFile(t, "testdata/test_remote_state/index.ts")
+	assert.Equal(t, expectedText, b.String())
+}
+
+func TestSensitiveVariables(t *testing.T) {
+	info := test.NewProviderInfoSource("../../testdata/providers")
+	conf := loadConfig(t, "testdata/test_sensitive_variables")
+	g, err := il.BuildGraph(module.NewTree("main", conf), &il.BuildOptions{
+		ProviderInfoSource:    info,
+		AllowMissingProviders: true,
+	})
+	if err != nil {
+		t.Fatalf("could not build graph: %v", err)
+	}
+
+	var b bytes.Buffer
+	lang, err := New("main", "1.0.0", true /*prompt*/, &b)
+	assert.NoError(t, err)
+	err = gen.Generate([]*il.Graph{g}, lang)
+	assert.NoError(t, err)
+
+	expectedText := readFile(t, "testdata/test_sensitive_variables/index.ts")
+	assert.Equal(t, expectedText, b.String())
+}
+
+func TestRequireComments(t *testing.T) {
+	info := test.NewProviderInfoSource("../../testdata/providers")
+	conf := loadConfig(t, "testdata/test_require_comments")
+	g, err := il.BuildGraph(module.NewTree("main", conf), &il.BuildOptions{
+		ProviderInfoSource:    info,
+		AllowMissingProviders: true,
+		RequireComments:       true,
+	})
+	if err != nil {
+		t.Fatalf("could not build graph: %v", err)
+	}
+
+	var b bytes.Buffer
+	lang, err := New("main", "1.0.0", true /*prompt*/, &b)
+	assert.NoError(t, err)
+	err = gen.Generate([]*il.Graph{g}, lang)
+	assert.NoError(t, err)
+
+	expectedText := readFile(t, "testdata/test_require_comments/index.ts")
+	assert.Equal(t, expectedText, b.String())
+}
+
+func TestNullResources(t *testing.T) {
+	info := test.NewProviderInfoSource("../../testdata/providers")
+	conf := loadConfig(t, "testdata/test_null_resources")
+	g, err := il.BuildGraph(module.NewTree("main", conf), &il.BuildOptions{
+		ProviderInfoSource:    info,
+		AllowMissingProviders: true,
+	})
+	if err != nil {
+		t.Fatalf("could not build graph: %v", err)
+	}
+
+	var b bytes.Buffer
+	lang, err := NewWithOptions("main", "1.0.0", Options{UsePromptDataSources: true, EmitProvisioners: true}, &b)
+	assert.NoError(t, err)
+	err = gen.Generate([]*il.Graph{g}, lang)
+	assert.NoError(t, err)
+
+	expectedText := readFile(t, "testdata/test_null_resources/index.ts")
+	assert.Equal(t, expectedText, b.String())
+}
+
+func TestSplitFiles(t *testing.T) {
+	info := test.NewProviderInfoSource("../../testdata/providers")
+	conf := loadConfig(t, "testdata/test_split_files")
+	g, err := il.BuildGraph(module.NewTree("main", conf), &il.BuildOptions{
+		ProviderInfoSource:    info,
+		AllowMissingProviders: true,
+	})
+	if err != nil {
+		t.Fatalf("could not build graph: %v", err)
+	}
+
+	fs := afero.NewMemMapFs()
+	lang, err := NewSplit("main", "1.0.0", Options{UsePromptDataSources: true}, fs, "out")
+	assert.NoError(t, err)
+	err = gen.Generate([]*il.Graph{g}, lang)
+	assert.NoError(t, err)
+
+	// Each source file is generated into its own file, and the entry point exports the outputs.
+	files, err := afero.ReadDir(fs, "out")
+	assert.NoError(t, err)
+	names := make([]string, 0, len(files))
+	for _, f := range files {
+		names = append(names, f.Name())
+	}
+	assert.Equal(t, []string{"index.ts", "main.ts", "network.ts"}, names)
+
+	for _, name := range names {
+		actual, err := afero.ReadFile(fs, filepath.Join("out", name))
+		assert.NoError(t, err)
+		assert.Equal(t, readFile(t, filepath.Join("testdata/test_split_files", name)), string(actual))
+	}
+
+	// Files that reference each other cannot be split.
+	conf = loadConfig(t, "testdata/test_split_files_cycle")
+	g, err = il.BuildGraph(module.NewTree("main", conf), &il.BuildOptions{
+		ProviderInfoSource:    info,
+		AllowMissingProviders: true,
+	})
+	if err != nil {
+		t.Fatalf("could not build graph: %v", err)
+	}
+
+	lang, err = NewSplit("main", "1.0.0", Options{UsePromptDataSources: true}, afero.NewMemMapFs(), "out")
+	assert.NoError(t, err)
+	err = gen.Generate([]*il.Graph{g}, lang)
+	assert.ErrorContains(t, err, "import cycle main.ts -> network.ts -> main.ts")
+}
+
+func TestMoved(t *testing.T) {
+	info := test.NewProviderInfoSource("../../testdata/providers")
+	conf := loadConfig(t, "testdata/test_moved")
+	g, err := il.BuildGraph(module.NewTree("main", conf), &il.BuildOptions{
+		ProviderInfoSource:    info,
+		AllowMissingProviders: true,
+	})
+	if err != nil {
+		t.Fatalf("could not build graph: %v", err)
+	}
+
+	var b bytes.Buffer
+	lang, err := New("main", "1.0.0", true /*prompt*/, &b)
+	assert.NoError(t, err)
+	err = gen.Generate([]*il.Graph{g}, lang)
+	assert.NoError(t, err)
+
+	expectedText := readFile(t, "testdata/test_moved/index.ts")
+	assert.Equal(t, expectedText, b.String())
+}
+
+func TestResourceNames(t *testing.T) {
+	info := test.NewProviderInfoSource("../../testdata/providers")
+	conf := loadConfig(t, "testdata/test_resource_names")
+	g, err := il.BuildGraph(module.NewTree("main", conf), &il.BuildOptions{
+		ProviderInfoSource:    info,
+		AllowMissingProviders: true,
+	})
+	if err != nil {
+		t.Fatalf("could not build graph: %v", err)
+	}
+
+	var b bytes.Buffer
+	lang, err := NewWithOptions("main", "1.0.0", Options{
+		UsePromptDataSources:  true,
+		ResourceNamePrefix:    "prod-",
+		MaxResourceNameLength: 32,
+	}, &b)
+	assert.NoError(t, err)
+	err = gen.Generate([]*il.Graph{g}, lang)
+	assert.NoError(t, err)
+
+	expectedText := readFile(t, "testdata/test_resource_names/index.ts")
+	assert.Equal(t, expectedText, b.String())
+
+	_, err = NewWithOptions("main", "1.0.0", Options{MaxResourceNameLength: 8}, &b)
+	assert.EqualError(t, err, "the maximum resource name length must be at least 16")
+}
+
+func TestInterpolation(t *testing.T) {
+	info := test.NewProviderInfoSource("../../testdata/providers")
+	conf := loadConfig(t, "testdata/test_interpolation")
+	g, err := il.BuildGraph(module.NewTree("main", conf), &il.BuildOptions{
+		ProviderInfoSource:    info,
+		AllowMissingProviders: true,
+	})
+	if err != nil {
+		t.Fatalf("could not build graph: %v", err)
+	}
+
+	var b bytes.Buffer
+	lang, err := New("main", "1.0.0", true /*prompt*/, &b)
+	assert.NoError(t, err)
+	err = gen.Generate([]*il.Graph{g}, lang)
+	assert.NoError(t, err)
+
+	expectedText := readFile(t, "testdata/test_interpolation/index.ts")
+	assert.Equal(t, expectedText, b.String())
+}
+
+// staticProviderInfoSource serves provider info that is built in memory, e.g. to test schema descriptions, which
+// serialized provider info does not carry.
+type staticProviderInfoSource map[string]*tfbridge.ProviderInfo
+
+func (s staticProviderInfoSource) GetProviderInfo(
+	registry, namespace, name, version string) (*tfbridge.ProviderInfo, error) {
+
+	info, ok := s[name]
+	if !ok {
+		return nil, errors.New("unknown provider " + name)
+	}
+	return info, nil
+}
+
+func TestPropertyDocs(t *testing.T) {
+	settings := (&schema.Resource{Schema: schema.SchemaMap{
+		"shade": (&schema.Schema{
+			Type:        shim.TypeString,
+			Optional:    true,
+			Description: "The shade of the widget. Must not contain `*/`.",
+		}).Shim(),
+	}}).Shim()
+	widget := (&schema.Resource{Schema: schema.SchemaMap{
+		"name": (&schema.Schema{
+			Type:        shim.TypeString,
+			Required:    true,
+			Description: "The name of the widget.",
+		}).Shim(),
+		"description": (&schema.Schema{
+			Type:     shim.TypeString,
+			Optional: true,
+			Description: "A description of the widget.\n\n" +
+				"Descriptions longer than 256 characters are truncated.\n",
+		}).Shim(),
+		"size": (&schema.Schema{Type: shim.TypeInt, Optional: true}).Shim(),
+		"settings": (&schema.Schema{
+			Type:        shim.TypeList,
+			Optional:    true,
+			MaxItems:    1,
+			Elem:        settings,
+			Description: "The settings of the widget.",
+		}).Shim(),
+	}}).Shim()
+	info := staticProviderInfoSource{
+		"widgets": {
+			Name: "widgets",
+			P: (&schema.Provider{
+				ResourcesMap: schema.ResourceMap{"widgets_widget": widget},
+			}).Shim(),
+			Resources: map[string]*tfbridge.ResourceInfo{
+				"widgets_widget": {Tok: "widgets:index/widget:Widget"},
+			},
+		},
+	}
+
+	conf := loadConfig(t, "testdata/test_property_docs")
+	g, err := il.BuildGraph(module.NewTree("main", conf), &il.BuildOptions{
+		ProviderInfoSource:    info,
+		AllowMissingProviders: true,
+	})
+	if err != nil {
+		t.Fatalf("could not build graph: %v", err)
+	}
+
+	var b bytes.Buffer
+	lang, err := NewWithOptions("main", "1.0.0", Options{EmitPropertyDocs: true}, &b)
+	assert.NoError(t, err)
+	err = gen.Generate([]*il.Graph{g}, lang)
+	assert.NoError(t, err)
+
+	expectedText := readFile(t, "testdata/test_property_docs/index.ts")
+	assert.Equal(t, expectedText, b.String())
+}
+
+func TestBackend(t *testing.T) {
+	info := test.NewProviderInfoSource("../../testdata/providers")
+	conf := loadConfig(t, "testdata/test_backend")
+	g, err := il.BuildGraph(module.NewTree("main", conf), &il.BuildOptions{
+		ProviderInfoSource:    info,
+		AllowMissingProviders: true,
+	})
+	if err != nil {
+		t.Fatalf("could not build graph: %v", err)
+	}
+	assert.Equal(t, "s3", g.BackendType)
+
+	var b bytes.Buffer
+	lang, err := New("main", "1.0.0", true /*prompt*/, &b)
+	assert.NoError(t, err)
+	err = gen.Generate([]*il.Graph{g}, lang)
+	assert.NoError(t, err)
+
+	expectedText := readFile(t, "testdata/test_backend/index.ts")
+	assert.Equal(t, expectedText, b.String())
+}
+
+func TestRewriteProperties(t *testing.T) {
+	info := test.NewProviderInfoSource("../../testdata/providers")
+	conf := loadConfig(t, "testdata/test_rewrite")
+	g, err := il.BuildGraph(module.NewTree("main", conf), &il.BuildOptions{
+		ProviderInfoSource:    info,
+		AllowMissingProviders: true,
+	})
+	if err != nil {
+		t.Fatalf("could not build graph: %v", err)
+	}
+
+	// Replace every string literal that names a t2 instance type with the equivalent t3 instance type.
+	t2 := regexp.MustCompile(`^t2\.`)
+	err = il.RewriteAllProperties(g, il.IdentityVisitor, func(n il.BoundNode) (il.BoundNode, error) {
+		if lit, ok := n.(*il.BoundLiteral); ok && lit.ExprType == il.TypeString {
+			if v := lit.Value.(string); t2.MatchString(v) {
+				return &il.BoundLiteral{ExprType: il.TypeString, Value: t2.ReplaceAllString(v, "t3."),
+					NodeComments: lit.NodeComments}, nil
+			}
+		}
+		return n, nil
+	})
+	assert.NoError(t, err)
+
+	var b bytes.Buffer
+	lang, err := New("main", "1.0.0", true /*prompt*/, &b)
+	assert.NoError(t, err)
+	err = gen.Generate([]*il.Graph{g}, lang)
+	assert.NoError(t, err)
+
+	expectedText := readFile(t, "testdata/test_rewrite/index.ts")
+	assert.Equal(t, expectedText, b.String())
+
+	// The properties of a resource may not be replaced with anything but another map.
+	err = il.RewriteAllProperties(g, il.IdentityVisitor, func(n il.BoundNode) (il.BoundNode, error) {
+		if _, ok := n.(*il.BoundMapProperty); ok {
+			return &il.BoundLiteral{ExprType: il.TypeString, Value: "oops"}, nil
+		}
+		return n, nil
+	})
+	assert.Error(t, err)
+}
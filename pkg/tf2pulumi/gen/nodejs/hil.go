@@ -15,6 +15,7 @@
 package nodejs
 
 import (
+	"bytes"
 	"fmt"
 	"io"
 	"strings"
@@ -122,7 +123,7 @@ func (g *generator) genApply(w io.Writer, n *il.BoundCall) {
 // examine the type and name of each property accessed by the expression.
 func (g *generator) getNestedPropertyAccessElementInfo(v *il.BoundVariableAccess) (il.Schemas, []string) {
 	sch, elements := v.Schemas, v.Elements
-	if !g.isDataSourceAccess(v) {
+	if !g.isDataSourceAccess(v) || isRemoteStateAccess(v) {
 		return sch.PropertySchemas(elements[0]), elements[1:]
 	} else if r, ok := v.ILNode.(*il.ResourceNode); ok && r.Provider.Name == "http" {
 		return sch, nil
@@ -130,6 +131,12 @@ func (g *generator) getNestedPropertyAccessElementInfo(v *il.BoundVariableAccess
 	return sch, elements
 }
 
+// isRemoteStateAccess returns true if the given access refers to a remote state data source.
+func isRemoteStateAccess(v *il.BoundVariableAccess) bool {
+	r, ok := v.ILNode.(*il.ResourceNode)
+	return ok && r.IsRemoteState()
+}
+
 // genNestedPropertyAccess generates a property access expression for a nested property of a resource or data source.
 func (g *generator) genNestedPropertyAccess(w io.Writer, v *il.BoundVariableAccess) {
 	_, ok := v.TFVar.(*config.ResourceVariable)
@@ -167,16 +174,15 @@ func (g *generator) genApplyArg(w io.Writer, index int) {
 
 	// Generate any nested path.
 	if rv, ok := v.TFVar.(*config.ResourceVariable); ok {
-		// Handle splats
-		isSplat := rv.Multi && rv.Index == -1
-		if isSplat {
-			g.Fgen(w, ".map(v => v")
-		}
-
-		g.genNestedPropertyAccess(w, v)
-
-		if isSplat {
-			g.Fgen(w, ")")
+		// Handle splats. If there is no nested path, the elements of the splat are already the values we want.
+		if rv.Multi && rv.Index == -1 {
+			var nested bytes.Buffer
+			g.genNestedPropertyAccess(&nested, v)
+			if nested.Len() != 0 {
+				g.Fgenf(w, ".map(v => v%s)", nested.String())
+			}
+		} else {
+			g.genNestedPropertyAccess(w, v)
 		}
 	}
 }
@@ -232,6 +238,8 @@ func (g *generator) GenCall(w io.Writer, n *il.BoundCall) {
 	case il.IntrinsicCoerce:
 		value, toType := il.ParseCoerceCall(n)
 		g.genCoercion(w, value, toType)
+	case il.IntrinsicDynamic:
+		g.genDynamic(w, n)
 	case il.IntrinsicGetStack:
 		g.Fgenf(w, "pulumi.getStack()")
 	case intrinsicDataSource:
@@ -244,11 +252,13 @@ func (g *generator) GenCall(w io.Writer, n *il.BoundCall) {
 			}
 			g.Fgenf(w, "%s(%s%s)", function, inputs, optionsBag)
 		}
+	case intrinsicSecret:
+		g.Fgenf(w, "pulumi.secret(%v)", n.Args[0])
 	case intrinsicInterpolate:
 		fmt.Fprint(w, "pulumi.interpolate`")
 		for _, s := range n.Args {
 			if lit, ok := s.(*il.BoundLiteral); ok && lit.ExprType == il.TypeString {
-				fmt.Fprint(w, lit.Value.(string))
+				fmt.Fprint(w, escapeTemplateText(lit.Value.(string)))
 			} else {
 				g.Fgenf(w, "${%v}", s)
 			}
@@ -290,7 +300,9 @@ func (g *generator) GenCall(w io.Writer, n *il.BoundCall) {
 		}
 		g.Fgen(w, ")")
 	case "element":
-		g.Fgenf(w, "%v[%v]", n.Args[0], n.Args[1])
+		// element wraps around if the index is greater than the length of the list. Terraform rejects negative
+		// indices, so they need no handling here. The list is bound to a parameter so that it is only evaluated once.
+		g.Fgenf(w, "(list => list[%v %% list.length])(%v)", n.Args[1], n.Args[0])
 	case "file":
 		g.Fgenf(w, "fs.readFileSync(%v, \"utf-8\")", n.Args[0])
 	case "format":
@@ -308,6 +320,8 @@ func (g *generator) GenCall(w io.Writer, n *il.BoundCall) {
 			n.Args[1], n.Args[0])
 	case "join":
 		g.Fgenf(w, "%v.join(%v)", n.Args[1], n.Args[0])
+	case "keys":
+		g.Fgenf(w, "Object.keys(%v)", n.Args[0])
 	case "length":
 		g.Fgenf(w, "%v.length", n.Args[0])
 	case "list":
@@ -326,10 +340,14 @@ func (g *generator) GenCall(w io.Writer, n *il.BoundCall) {
 		}
 		g.Fgenf(w, "(<any>%v)[%v]", n.Args[0], n.Args[1])
 		if hasDefault {
-			g.Fgenf(w, " || %v)", n.Args[2])
+			g.Fgenf(w, " ?? %v)", n.Args[2])
 		}
 	case "lower":
 		g.Fgenf(w, "%v.toLowerCase()", n.Args[0])
+	case "jsondecode":
+		g.Fgenf(w, "JSON.parse(%v)", n.Args[0])
+	case "jsonencode":
+		g.Fgenf(w, "JSON.stringify(%v)", n.Args[0])
 	case "map":
 		contract.Assert(len(n.Args)%2 == 0)
 		g.Fgen(w, "{")
@@ -346,12 +364,10 @@ func (g *generator) GenCall(w io.Writer, n *il.BoundCall) {
 		}
 		g.Fgen(w, "}")
 	case "merge":
-		g.Fgenf(w, "Object.assign(%v", n.Args[0])
-		for i, arg := range n.Args[1:] {
-			if i > 0 {
-				g.Fgen(w, ", ")
-			}
-			g.Fgenf(w, "%v", arg)
+		// Merge into a fresh object so that none of the arguments are mutated.
+		g.Fgen(w, "Object.assign({}")
+		for _, arg := range n.Args {
+			g.Fgenf(w, ", %v", arg)
 		}
 		g.Fgen(w, ")")
 	case "min":
@@ -371,12 +387,68 @@ func (g *generator) GenCall(w io.Writer, n *il.BoundCall) {
 		g.Fgenf(w, "%v.split(%v)", n.Args[1], n.Args[0])
 	case "substr":
 		g.Fgenf(w, "((str, s, l) => str.slice(s, l === -1 ? s.length : s + l))(%v, %v, %v)", n.Args[0], n.Args[1], n.Args[2])
+	case "templatefile":
+		// Terraform's template syntax is richer than what we can faithfully reproduce, so only substitute simple
+		// references to the template's variables and flag the call for review.
+		g.Fgenf(w, "/* TODO: tf2pulumi only substitutes simple ${name} references in templatefile() */ "+
+			"((template: string, vars: Record<string, any>) => "+
+			"template.replace(/\\$\\{\\s*(\\w+)\\s*\\}/g, (_, name) => String(vars[name])))"+
+			"(fs.readFileSync(%v, \"utf-8\"), %v)", n.Args[0], n.Args[1])
+	case "try":
+		g.genTry(w, n)
+	case "values":
+		g.Fgenf(w, "Object.values(%v)", n.Args[0])
 	case "zipmap":
-		g.Fgenf(w, "((keys, values) => Object.assign.apply({}, keys.map((k: any, i: number) => ({[k]: values[i]}))))(%v, %v)",
-			n.Args[0], n.Args[1])
+		// Build the map with a reduction so that empty lists, whose lengths may only be known at runtime, produce an
+		// empty map.
+		g.Fgenf(w, "((keys: any[], values: any[]) => keys.reduce((m: Record<string, any>, k: any, i: number) => "+
+			"({ ...m, [k]: values[i] }), {}))(%v, %v)", n.Args[0], n.Args[1])
 	default:
-		g.Fgenf(w, "(() => { throw \"NYI: call to %v\"; })()", n.Func)
+		// Emit a clearly-marked TODO that fails at runtime rather than failing the conversion.
+		g.Fgenf(w, "(() => { throw \"TODO: tf2pulumi does not support the %v function\"; })()", n.Func)
+	}
+}
+
+// genTry generates code for a call to `try`, which evaluates to the first of its alternatives that can be evaluated
+// without error. If every alternative is a variable access (or apply argument) or a literal, the call is generated as a
+// chain of `??` operators. Otherwise, the alternatives are passed as thunks to an inline helper that returns the result
+// of the first call that neither throws nor returns undefined. The last alternative is evaluated unguarded so that its
+// error (if any) is not swallowed.
+func (g *generator) genTry(w io.Writer, n *il.BoundCall) {
+	simple := true
+	for _, arg := range n.Args {
+		switch arg := arg.(type) {
+		case *il.BoundVariableAccess, *il.BoundLiteral:
+		case *il.BoundCall:
+			// Inside an apply, variable accesses are replaced by references to the apply's arguments.
+			simple = simple && arg.Func == il.IntrinsicApplyArg
+		default:
+			simple = false
+		}
+	}
+
+	if simple {
+		g.Fgen(w, "(")
+		for i, arg := range n.Args {
+			if i > 0 {
+				g.Fgen(w, " ?? ")
+			}
+			g.Fgen(w, arg)
+		}
+		g.Fgen(w, ")")
+		return
 	}
+
+	g.Fgen(w, "((...alternatives: (() => any)[]) => { for (const f of alternatives.slice(0, -1)) { "+
+		"try { const v = f(); if (v !== undefined) { return v; } } catch { } } "+
+		"return alternatives[alternatives.length - 1](); })(")
+	for i, arg := range n.Args {
+		if i > 0 {
+			g.Fgen(w, ", ")
+		}
+		g.Fgenf(w, "() => %v", arg)
+	}
+	g.Fgen(w, ")")
 }
 
 // GenConditional generates code for a single conditional expression.
@@ -411,37 +483,40 @@ func (g *generator) genStringLiteral(w io.Writer, v string) {
 	} else {
 		// This string does contain newlines, so we'll generate a template string literal. "${", backquotes, and
 		// backslashes will be escaped in conformance with ECMA-262 11.8.6 ("Template Literal Lexical Components").
-		runes := []rune(v)
 		builder.WriteRune('`')
-		for i, c := range runes {
-			switch c {
-			case '$':
-				if i < len(runes)-1 && runes[i+1] == '{' {
-					builder.WriteRune('\\')
-				}
-			case '`', '\\':
-				builder.WriteRune('\\')
-			}
-			builder.WriteRune(c)
-		}
+		builder.WriteString(escapeTemplateText(v))
 		builder.WriteRune('`')
 	}
 
 	g.Fgenf(w, "%s", builder.String())
 }
 
+// escapeTemplateText escapes "${", backquotes, and backslashes in the given text so that it can be used as the literal
+// portion of a template string literal.
+func escapeTemplateText(v string) string {
+	builder := strings.Builder{}
+	runes := []rune(v)
+	for i, c := range runes {
+		switch c {
+		case '$':
+			if i < len(runes)-1 && runes[i+1] == '{' {
+				builder.WriteRune('\\')
+			}
+		case '`', '\\':
+			builder.WriteRune('\\')
+		}
+		builder.WriteRune(c)
+	}
+	return builder.String()
+}
+
 // GenLiteral generates code for a single literal expression
 func (g *generator) GenLiteral(w io.Writer, n *il.BoundLiteral) {
 	switch n.ExprType {
 	case il.TypeBool:
 		g.Fgenf(w, "%v", n.Value)
 	case il.TypeNumber:
-		f := n.Value.(float64)
-		if float64(int64(f)) == f {
-			g.Fgenf(w, "%d", int64(f))
-		} else {
-			g.Fgenf(w, "%g", n.Value)
-		}
+		g.Fgen(w, il.FormatNumber(n.Value))
 	case il.TypeString:
 		g.genStringLiteral(w, n.Value.(string))
 	default:
@@ -454,7 +529,7 @@ func (g *generator) GenOutput(w io.Writer, n *il.BoundOutput) {
 	g.Fgen(w, "`")
 	for _, s := range n.Exprs {
 		if lit, ok := s.(*il.BoundLiteral); ok && lit.ExprType == il.TypeString {
-			g.Fgen(w, lit.Value.(string))
+			g.Fgen(w, escapeTemplateText(lit.Value.(string)))
 		} else {
 			g.Fgenf(w, "${%v}", s)
 		}
@@ -467,11 +542,56 @@ func (g *generator) GenPropertyValue(w io.Writer, n *il.BoundPropertyValue) {
 	g.Gen(w, n.Value)
 }
 
+// genDynamic generates code for a dynamic block as a map over its collection. Lists are mapped directly; the entries of
+// any other collection are mapped as [key, value] pairs.
+func (g *generator) genDynamic(w io.Writer, n *il.BoundCall) {
+	forEach, iterator, content := il.ParseDynamicCall(n)
+
+	value := iteratorName(iterator)
+	key := value + "Key"
+	if !forEach.Type().IsList() {
+		g.Fgenf(w, "Object.entries(%v).map(([%s, %s]) => (%v))", forEach, key, value, content)
+		return
+	}
+
+	// Only declare the index parameter if the content refers to the iterator's key.
+	usesKey := false
+	_, err := il.VisitBoundNode(content, il.IdentityVisitor, func(n il.BoundNode) (il.BoundNode, error) {
+		if v, ok := n.(*il.BoundVariableAccess); ok {
+			if iv, ok := v.TFVar.(*config.IteratorVariable); ok && iv.Name == iterator && iv.Field == "key" {
+				usesKey = true
+			}
+		}
+		return n, nil
+	})
+	contract.Assert(err == nil)
+
+	if usesKey {
+		g.Fgenf(w, "%v.map((%s, %s) => (%v))", forEach, value, key, content)
+	} else {
+		g.Fgenf(w, "%v.map(%s => (%v))", forEach, value, content)
+	}
+}
+
+// iteratorName returns the name of the lambda parameter that holds the value of the given dynamic block iterator.
+func iteratorName(iterator string) string {
+	return tsName(iterator, nil, nil, false)
+}
+
 // GenVariableAccess generates code for a single variable access expression.
 func (g *generator) GenVariableAccess(w io.Writer, n *il.BoundVariableAccess) {
 	switch v := n.TFVar.(type) {
 	case *config.CountVariable, *config.LocalVariable, *config.UserVariable:
 		g.Fgen(w, g.variableName(n))
+	case *config.IteratorVariable:
+		g.Fgen(w, g.variableName(n))
+		for _, e := range n.Elements {
+			if isLegalIdentifier(e) {
+				g.Fgenf(w, ".%s", e)
+			} else {
+				g.Fgenf(w, "[%q]", e)
+			}
+		}
 
 	case *config.ModuleVariable:
 		g.Fgen(w, g.variableName(n))
@@ -508,8 +628,14 @@ func (g *generator) GenVariableAccess(w io.Writer, n *il.BoundVariableAccess) {
 		}
 
 		// Otherwise, we will generate different code depending on whether or not we have a managed resource or a data
-		// source. The former are bags of outputs while the latter are outputs.
-		if !g.isDataSourceAccess(n) {
+		// source. The former are bags of outputs while the latter are outputs. Remote state data sources are generated
+		// as stack references, whose outputs are fetched by name.
+		if isRemoteStateAccess(n) {
+			g.Fgenf(w, ".getOutput(%q)", n.Elements[0])
+			if !g.inApplyCall {
+				g.genNestedPropertyAccess(w, n)
+			}
+		} else if !g.isDataSourceAccess(n) {
 			// Because a managed resource is a bag of outputs, we must generate the first portion of this access. If we
 			// are _not_ within an apply, we generate the entire access.
 			element := n.Elements[0]
@@ -25,6 +25,8 @@ const (
 	intrinsicDataSource = "__dataSource"
 	// inttrinsicInterpolate is the name of the interpolate intrinsic.
 	intrinsicInterpolate = "__interpolate"
+	// intrinsicSecret is the name of the secret intrinsic.
+	intrinsicSecret = "__secret"
 )
 
 // newDataSourceCall creates a new call to the data source intrinsic that represents an invocation of the specified
@@ -69,3 +71,13 @@ func newInterpolateCall(args []il.BoundExpr) *il.BoundCall {
 		Args:     args,
 	}
 }
+
+// newSecretCall creates a new call to the secret intrinsic that represents a call to the pulumi.secret function with
+// the given value.
+func newSecretCall(value il.BoundExpr) *il.BoundCall {
+	return &il.BoundCall{
+		Func:     intrinsicSecret,
+		ExprType: value.Type().OutputOf(),
+		Args:     []il.BoundExpr{value},
+	}
+}
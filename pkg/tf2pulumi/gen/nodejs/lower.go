@@ -24,33 +24,31 @@ import (
 )
 
 // lowerToLiterals lowers certain elements--namely Module and Root path references--to bound literals. This allows the
-// code generator to fold these expressions into template literals as necessary.
+// code generator to fold these expressions into template literals as necessary. Relative literal paths passed to `file`
+// and `templatefile` are also resolved relative to the module's path.
 func (g *generator) lowerToLiterals(prop il.BoundNode) (il.BoundNode, error) {
 	rewriter := func(n il.BoundNode) (il.BoundNode, error) {
-		v, ok := n.(*il.BoundVariableAccess)
-		if !ok {
-			return n, nil
-		}
-
-		pv, ok := v.TFVar.(*config.PathVariable)
-		if !ok {
+		switch n := n.(type) {
+		case *il.BoundCall:
+			if n.Func == "file" || n.Func == "templatefile" {
+				g.resolveFilePath(n)
+			}
 			return n, nil
-		}
-
-		switch pv.Type {
-		case config.PathValueModule:
-			path := g.module.Path
-
-			// Attempt to make this path relative to that of the root module.
-			rel, err := filepath.Rel(g.rootPath, path)
-			if err == nil {
-				path = rel
+		case *il.BoundVariableAccess:
+			pv, ok := n.TFVar.(*config.PathVariable)
+			if !ok {
+				return n, nil
 			}
 
-			return &il.BoundLiteral{ExprType: il.TypeString, Value: path}, nil
-		case config.PathValueRoot:
-			// NOTE: this might not be the most useful or correct value. Might want Node's __directory or similar.
-			return &il.BoundLiteral{ExprType: il.TypeString, Value: "."}, nil
+			switch pv.Type {
+			case config.PathValueModule:
+				return &il.BoundLiteral{ExprType: il.TypeString, Value: g.modulePath()}, nil
+			case config.PathValueRoot:
+				// NOTE: this might not be the most useful or correct value. Might want Node's __directory or similar.
+				return &il.BoundLiteral{ExprType: il.TypeString, Value: "."}, nil
+			default:
+				return n, nil
+			}
 		default:
 			return n, nil
 		}
@@ -59,9 +57,88 @@ func (g *generator) lowerToLiterals(prop il.BoundNode) (il.BoundNode, error) {
 	return il.VisitBoundNode(prop, il.IdentityVisitor, rewriter)
 }
 
+// sensitiveRewriter lowers calls to the `sensitive` function. See lowerSensitiveCalls.
+type sensitiveRewriter struct {
+	root      il.BoundExpr
+	sensitive bool
+}
+
+// enterNode finds the roots of bound expression trees.
+func (r *sensitiveRewriter) enterNode(n il.BoundNode) (il.BoundNode, error) {
+	if e, ok := n.(il.BoundExpr); ok && r.root == nil {
+		r.root, r.sensitive = e, false
+	}
+	return n, nil
+}
+
+// rewriteNode replaces each call to `sensitive` with its argument and wraps the roots of the expression trees that
+// contained such calls in calls to the secret intrinsic.
+func (r *sensitiveRewriter) rewriteNode(n il.BoundNode) (il.BoundNode, error) {
+	e, ok := n.(il.BoundExpr)
+	if !ok {
+		return n, nil
+	}
+
+	isRoot := e == r.root
+	if call, ok := e.(*il.BoundCall); ok && call.Func == "sensitive" {
+		e, r.sensitive = call.Args[0], true
+	}
+	if !isRoot {
+		return e, nil
+	}
+
+	r.root = nil
+	if r.sensitive {
+		return newSecretCall(e), nil
+	}
+	return e, nil
+}
+
+// lowerSensitiveCalls lowers calls to the `sensitive` function to calls to `pulumi.secret`. Because the value of a
+// sensitive expression may be combined with other values, e.g. by string interpolation, the entire expression that
+// contains the call is made secret rather than just the argument to the call.
+func (g *generator) lowerSensitiveCalls(prop il.BoundNode) (il.BoundNode, error) {
+	rewriter := &sensitiveRewriter{}
+	return il.VisitBoundNode(prop, rewriter.enterNode, rewriter.rewriteNode)
+}
+
+// modulePath returns the path of the current module, relative to that of the root module if possible.
+func (g *generator) modulePath() string {
+	path := g.module.Path
+	if rel, err := filepath.Rel(g.rootPath, path); err == nil {
+		path = rel
+	}
+	return path
+}
+
+// resolveFilePath rewrites a relative literal path passed to a file function s.t. it is relative to the module's path
+// rather than to the working directory. Paths that are computed (e.g. from `path.module`) are left as-is.
+func (g *generator) resolveFilePath(n *il.BoundCall) {
+	if len(n.Args) == 0 {
+		return
+	}
+	lit, ok := n.Args[0].(*il.BoundLiteral)
+	if !ok || lit.ExprType != il.TypeString {
+		return
+	}
+	path, ok := lit.Value.(string)
+	if !ok || path == "" || filepath.IsAbs(path) {
+		return
+	}
+	if modulePath := g.modulePath(); modulePath != "." {
+		n.Args[0] = &il.BoundLiteral{ExprType: il.TypeString, Value: filepath.Join(modulePath, path)}
+	}
+}
+
 // canLiftVariableAccess returns true if this variable access expression can be lifted. Any variable access expression
 // that does not contain references to potentially-undefined values (e.g. optional fields of a resource) can be lifted.
 func (g *generator) canLiftVariableAccess(v *il.BoundVariableAccess) bool {
+	// Only resource variable accesses carry nested property accesses. Other accesses (e.g. of module inputs or
+	// outputs) are always liftable.
+	if _, ok := v.TFVar.(*config.ResourceVariable); !ok {
+		return true
+	}
+
 	sch, elements := g.getNestedPropertyAccessElementInfo(v)
 
 	for _, e := range elements {
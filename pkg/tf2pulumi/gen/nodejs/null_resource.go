@@ -0,0 +1,58 @@
+// Copyright 2016-2022, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nodejs
+
+import (
+	"github.com/pulumi/pulumi/sdk/v3/go/common/util/contract"
+
+	"github.com/pulumi/pulumi-terraform-bridge/v3/pkg/tf2pulumi/il"
+)
+
+// foldedProvisioner returns the provisioner of the given null_resource that is folded into the `local.Command` that
+// replaces it, if any. Only a leading `local-exec` provisioner is folded, so that resources that depend on the
+// null_resource also depend on its first command, as they would in Terraform.
+func (g *generator) foldedProvisioner(r *il.ResourceNode) (*il.Provisioner, bool) {
+	if !g.emitProvisioners || !r.IsNullResource() || len(r.Provisioners) == 0 {
+		return nil, false
+	}
+	if p := r.Provisioners[0]; p.Type == "local-exec" {
+		return p, true
+	}
+	return nil, false
+}
+
+// nullResourceArgs computes the arguments of the `local.Command` that replaces the given null_resource. The resource's
+// triggers become the command's triggers, which replace the command when they change, and the arguments of its folded
+// provisioner, if any, become the command's arguments.
+func (g *generator) nullResourceArgs(r *il.ResourceNode) *il.BoundMapProperty {
+	contract.Require(r.IsNullResource(), "r")
+
+	args := map[string]il.BoundNode{}
+	if p, ok := g.foldedProvisioner(r); ok {
+		args = localCommandArgs(p)
+	}
+
+	// `triggers` is bound as a list if it is written as a block, which is already the shape expected by the command.
+	switch triggers := r.Properties.Elements["triggers"].(type) {
+	case *il.BoundListProperty:
+		args["triggers"] = triggers
+	case nil:
+		// No triggers: the command is only replaced if its arguments change.
+	default:
+		args["triggers"] = &il.BoundListProperty{Elements: []il.BoundNode{triggers}}
+	}
+
+	return &il.BoundMapProperty{Elements: args}
+}
@@ -82,6 +82,9 @@ func (g *generator) GenMapProperty(w io.Writer, n *il.BoundMapProperty) {
 
 				propSch, key := n.Schemas.PropertySchemas(k), k
 				if !useExactKeys {
+					if g.emitPropertyDocs && propSch.TF != nil {
+						g.genPropertyDoc(w, propSch.TF.Description())
+					}
 					key = tsName(k, propSch.TF, propSch.Pulumi, true)
 				} else if !isLegalIdentifier(key) {
 					key = fmt.Sprintf("%q", key)
@@ -0,0 +1,124 @@
+// Copyright 2016-2022, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nodejs
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/common/util/contract"
+
+	"github.com/pulumi/pulumi-terraform-bridge/v3/pkg/tf2pulumi/il"
+)
+
+// singleElement returns the sole element of the given block, which may be bound as either a map or a list of maps.
+func singleElement(block il.BoundNode) (*il.BoundMapProperty, bool) {
+	if list, ok := block.(*il.BoundListProperty); ok {
+		if len(list.Elements) != 1 {
+			return nil, false
+		}
+		block = list.Elements[0]
+	}
+	m, ok := block.(*il.BoundMapProperty)
+	return m, ok
+}
+
+// remoteStateWorkspaceName returns the name of the workspace read by the given remote state data source, if it uses
+// the "remote" backend and names a single workspace.
+func remoteStateWorkspaceName(r *il.ResourceNode) (il.BoundNode, bool) {
+	backend, ok := r.Properties.Elements["backend"].(*il.BoundLiteral)
+	if !ok || backend.Value != "remote" {
+		return nil, false
+	}
+	config, ok := singleElement(r.Properties.Elements["config"])
+	if !ok {
+		return nil, false
+	}
+	workspaces, ok := singleElement(config.Elements["workspaces"])
+	if !ok {
+		return nil, false
+	}
+	name, ok := workspaces.Elements["name"]
+	return name, ok
+}
+
+// computeStackReferenceArgs computes the arguments for a call to the pulumi.StackReference constructor for the given
+// remote state data source. The name of the referenced stack is the name of the workspace it reads, if known;
+// otherwise the stack is assumed to share the resource's name and no stack name is passed.
+func (g *generator) computeStackReferenceArgs(r *il.ResourceNode, indent bool, count string) (string, error) {
+	args := []string{g.makeResourceName(r.Name, count)}
+
+	stackArgs := ""
+	if workspace, ok := remoteStateWorkspaceName(r); ok {
+		stackName, _, err := g.computeProperty(workspace, indent, count)
+		if err != nil {
+			return "", err
+		}
+		stackArgs = fmt.Sprintf("{ name: %s }", stackName)
+	}
+
+	switch {
+	case g.isComponent():
+		if stackArgs == "" {
+			stackArgs = "undefined"
+		}
+		args = append(args, stackArgs, "{ parent: this }")
+	case stackArgs != "":
+		args = append(args, stackArgs)
+	}
+	return strings.Join(args, ", "), nil
+}
+
+// generateRemoteState generates the given remote state data source as a pulumi.StackReference. References to the
+// data source's outputs are generated as calls to the stack reference's getOutput method.
+func (g *generator) generateRemoteState(r *il.ResourceNode) error {
+	contract.Require(r.IsRemoteState(), "r")
+
+	name := g.nodeName(r)
+
+	if _, ok := remoteStateWorkspaceName(r); !ok {
+		backend := "unknown"
+		if lit, ok := r.Properties.Elements["backend"].(*il.BoundLiteral); ok {
+			backend = fmt.Sprintf("%v", lit.Value)
+		}
+		g.Printf("%s// TODO: this stack reference replaces state read from the %q backend; check its stack name\n",
+			g.Indent, backend)
+	}
+
+	if r.Count == nil {
+		args, err := g.computeStackReferenceArgs(r, false, "")
+		if err != nil {
+			return err
+		}
+
+		g.Printf("%sconst %s = new pulumi.StackReference(%s);", g.Indent, name, args)
+	} else {
+		count, _, err := g.computeProperty(r.Count, false, "")
+		if err != nil {
+			return err
+		}
+		args, err := g.computeStackReferenceArgs(r, true, "i")
+		if err != nil {
+			return err
+		}
+
+		g.Printf("%sconst %s: pulumi.StackReference[] = [];\n", g.Indent, name)
+		g.Printf("%sfor (let i = 0; i < %s; i++) {\n", g.Indent, count)
+		g.Printf("%s    %s.push(new pulumi.StackReference(%s));\n", g.Indent, name, args)
+		g.Printf("%s}", g.Indent)
+	}
+
+	return nil
+}
@@ -0,0 +1,387 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nodejs
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/afero"
+
+	"github.com/pulumi/pulumi-terraform-bridge/v3/pkg/tf2pulumi/gen"
+	"github.com/pulumi/pulumi-terraform-bridge/v3/pkg/tf2pulumi/il"
+)
+
+// indexFile is the path of the entry point of a split program.
+const indexFile = "index.ts"
+
+// NewSplit creates a new NodeJS code generator that splits the generated program across multiple files, which are
+// written to the given directory once the last module has been generated. The nodes of the root module are split by
+// the Terraform source file that defines them, e.g. the nodes defined by `network.tf` are generated into `network.ts`,
+// and each child module is generated into its own file under `modules/`. The entry point of the program, `index.ts`,
+// imports each of the root module's files and exports the root module's outputs. References across files are resolved
+// by exporting the referenced names from the file that defines them and importing them into the file that uses them.
+// Generation fails if two files would import each other. If opts.PostProcess is non-nil, it is applied to each file.
+func NewSplit(projectName, targetSDKVersion string, opts Options, fs afero.Fs, dir string) (gen.Generator, error) {
+	// Each file is post-processed separately, so the generator must not buffer the program as a whole.
+	langOpts := opts
+	langOpts.PostProcess = nil
+	lang, err := NewWithOptions(projectName, targetSDKVersion, langOpts, io.Discard)
+	if err != nil {
+		return nil, err
+	}
+
+	g := lang.(*generator)
+	g.postProcess, g.fs, g.dir = opts.PostProcess, fs, dir
+	g.files, g.nodeFiles, g.moduleFiles = map[string]*splitFile{}, map[il.Node]*splitFile{}, map[string]*splitFile{}
+	g.out.w = io.Discard
+	g.Emitter = gen.NewEmitter(&g.out, g)
+	return g, nil
+}
+
+// switchWriter is an io.Writer that forwards writes to a writer that may change over the course of generation.
+type switchWriter struct {
+	w io.Writer
+}
+
+// Write writes the given bytes to the current writer.
+func (s *switchWriter) Write(b []byte) (int, error) {
+	return s.w.Write(b)
+}
+
+// splitFile is a single file of a split program.
+type splitFile struct {
+	// path is the path of the file relative to the output directory, e.g. `network.ts`.
+	path string
+	// isRoot is true if the file contains nodes of the root module other than outputs.
+	isRoot bool
+	// view is a graph that contains the nodes defined by the file. It is used to compute the file's imports.
+	view *il.Graph
+	// body holds the code generated for the file's nodes.
+	body bytes.Buffer
+	// imports maps each file from which this file imports names to the set of imported names.
+	imports map[*splitFile]map[string]bool
+	// exports is the set of names that are imported from this file by other files.
+	exports map[string]bool
+}
+
+// addNode adds the given node to the file's view.
+func (f *splitFile) addNode(n il.Node) {
+	switch n := n.(type) {
+	case *il.LocalNode:
+		f.view.Locals[n.ID()] = n
+	case *il.ModuleNode:
+		f.view.Modules[n.ID()] = n
+	case *il.OutputNode:
+		f.view.Outputs[n.ID()] = n
+	case *il.ProviderNode:
+		f.view.Providers[n.ID()] = n
+	case *il.ResourceNode:
+		// The file needs the import for the resource's provider even if the provider is configured elsewhere.
+		f.view.Resources[n.ID()] = n
+		f.view.Providers[n.Provider.ID()] = n.Provider
+	case *il.VariableNode:
+		f.view.Variables[n.ID()] = n
+	}
+}
+
+// addImport records that the file imports the given name from the given file.
+func (f *splitFile) addImport(from *splitFile, name string) {
+	if f.imports[from] == nil {
+		f.imports[from] = make(map[string]bool)
+	}
+	f.imports[from][name] = true
+	from.exports[name] = true
+}
+
+// sortedFiles returns the given set of files ordered by path.
+func sortedFiles(files map[*splitFile]map[string]bool) []*splitFile {
+	sorted := make([]*splitFile, 0, len(files))
+	for f := range files {
+		sorted = append(sorted, f)
+	}
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].path < sorted[j].path })
+	return sorted
+}
+
+// sortedNames returns the given set of names in lexical order.
+func sortedNames(names map[string]bool) []string {
+	sorted := make([]string, 0, len(names))
+	for name := range names {
+		sorted = append(sorted, name)
+	}
+	sort.Strings(sorted)
+	return sorted
+}
+
+// file returns the file of the split program with the given path, creating it if necessary.
+func (g *generator) file(path string) *splitFile {
+	f, ok := g.files[path]
+	if !ok {
+		f = &splitFile{
+			path: path,
+			view: &il.Graph{
+				Modules:   map[string]*il.ModuleNode{},
+				Providers: map[string]*il.ProviderNode{},
+				Resources: map[string]*il.ResourceNode{},
+				Outputs:   map[string]*il.OutputNode{},
+				Locals:    map[string]*il.LocalNode{},
+				Variables: map[string]*il.VariableNode{},
+			},
+			imports: map[*splitFile]map[string]bool{},
+			exports: map[string]bool{},
+		}
+		g.files[path] = f
+	}
+	return f
+}
+
+// rootFilePath returns the path of the file that holds the root module nodes defined by the given Terraform source
+// file. Nodes with no source file are placed in `main.ts`.
+func rootFilePath(filename string) string {
+	base := strings.TrimSuffix(filepath.Base(filename), filepath.Ext(filename))
+	switch base {
+	case "", ".":
+		base = "main"
+	case "index":
+		// Avoid clobbering the program's entry point.
+		base = "index_tf"
+	}
+	return base + ".ts"
+}
+
+// assignModuleFiles creates the file for each of the given child modules.
+func (g *generator) assignModuleFiles(modules []*il.Graph) {
+	for _, m := range modules {
+		if !m.IsRoot {
+			f := g.file(path.Join("modules", cleanName(m.Name)+".ts"))
+			f.view = m
+			g.moduleFiles[m.Name] = f
+		}
+	}
+}
+
+// moduleNodes returns the top-level nodes of the given module.
+func moduleNodes(m *il.Graph) []il.Node {
+	var nodes []il.Node
+	for _, n := range m.Variables {
+		nodes = append(nodes, n)
+	}
+	for _, n := range m.Locals {
+		nodes = append(nodes, n)
+	}
+	for _, n := range m.Modules {
+		nodes = append(nodes, n)
+	}
+	for _, n := range m.Providers {
+		nodes = append(nodes, n)
+	}
+	for _, n := range m.Resources {
+		nodes = append(nodes, n)
+	}
+	for _, n := range m.Outputs {
+		nodes = append(nodes, n)
+	}
+	return nodes
+}
+
+// assignNodeFiles assigns each top-level node of the given module to the file that defines it. The nodes of a child
+// module are all defined by the module's file. The outputs of the root module are defined by the entry point; its
+// other nodes are defined by the file that corresponds to their Terraform source file.
+func (g *generator) assignNodeFiles(m *il.Graph) {
+	for _, n := range moduleNodes(m) {
+		if !m.IsRoot {
+			g.nodeFiles[n] = g.moduleFiles[m.Name]
+			continue
+		}
+
+		var f *splitFile
+		if _, ok := n.(*il.OutputNode); ok {
+			f = g.file(indexFile)
+		} else {
+			f = g.file(rootFilePath(n.GetLocation().Filename))
+			f.isRoot = true
+		}
+		f.addNode(n)
+		g.nodeFiles[n] = f
+	}
+}
+
+// selectFile directs the generator's output to the file that defines the given node if the program is split.
+func (g *generator) selectFile(n il.Node) {
+	if g.fs != nil {
+		g.out.w = &g.nodeFiles[n].body
+	}
+}
+
+// linkFiles records the names that the files of the given module import from other files: the nodes that are
+// referenced by nodes in other files, and the factory function or component class of each instantiated child module.
+func (g *generator) linkFiles(m *il.Graph) {
+	for _, n := range moduleNodes(m) {
+		f := g.nodeFiles[n]
+		for _, d := range n.Dependencies() {
+			from, ok := g.nodeFiles[d]
+			if !ok || from == f {
+				continue
+			}
+			// A provider without an alias configures the default provider and so is not assigned a name.
+			if p, ok := d.(*il.ProviderNode); ok && p.Alias == "" {
+				continue
+			}
+			f.addImport(from, g.nodeName(d))
+		}
+
+		if mod, ok := n.(*il.ModuleNode); ok {
+			if from, ok := g.moduleFiles[mod.Name]; ok {
+				name := "new_mod_" + cleanName(mod.Name)
+				if g.emitComponents {
+					name = componentClassName(mod.Name)
+				}
+				f.addImport(from, name)
+			}
+		}
+	}
+}
+
+// importSpecifier returns the module specifier with which the file at the path from imports the file at the path to.
+func (g *generator) importSpecifier(from, to string) string {
+	spec := strings.TrimSuffix(to, ".ts")
+	if rel, err := filepath.Rel(filepath.Dir(filepath.FromSlash(from)), filepath.FromSlash(spec)); err == nil {
+		spec = filepath.ToSlash(rel)
+	}
+	if !strings.HasPrefix(spec, "../") {
+		spec = "./" + spec
+	}
+	if g.emitESM {
+		// ECMAScript modules must import relative files by their full names.
+		spec += ".js"
+	}
+	return spec
+}
+
+// checkImportCycles returns an error if any of the files of the split program import each other, as the names that
+// they import might not be initialized when they are used.
+func (g *generator) checkImportCycles() error {
+	const visiting, visited = 1, 2
+
+	state := map[*splitFile]int{}
+	var stack []string
+	var visit func(f *splitFile) error
+	visit = func(f *splitFile) error {
+		switch state[f] {
+		case visiting:
+			return errors.Errorf("cannot split the program into files: import cycle %s",
+				strings.Join(append(stack, f.path), " -> "))
+		case visited:
+			return nil
+		}
+
+		state[f] = visiting
+		stack = append(stack, f.path)
+		for _, dep := range sortedFiles(f.imports) {
+			if err := visit(dep); err != nil {
+				return err
+			}
+		}
+		stack = stack[:len(stack)-1]
+		state[f] = visited
+		return nil
+	}
+
+	paths := make([]string, 0, len(g.files))
+	for p := range g.files {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+	for _, p := range paths {
+		if err := visit(g.files[p]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeFiles assembles the files of the split program and writes them to the output directory. Each file is prefixed
+// with the imports that it requires, and the names that other files import from a root module file are exported at its
+// end. The entry point imports every root module file that contains code. Files that contain no code are not written.
+func (g *generator) writeFiles() error {
+	if err := g.checkImportCycles(); err != nil {
+		return err
+	}
+
+	// The entry point is always written. It imports each root module file that contains code, if only for the file's
+	// side effects.
+	index := g.file(indexFile)
+
+	paths := make([]string, 0, len(g.files))
+	for p, f := range g.files {
+		if _, ok := index.imports[f]; !ok && f.isRoot && strings.TrimSpace(f.body.String()) != "" {
+			index.imports[f] = nil
+		}
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+
+	for _, p := range paths {
+		f := g.files[p]
+		body := strings.Trim(f.body.String(), "\n")
+		if body == "" && f != index {
+			continue
+		}
+
+		var b bytes.Buffer
+		fmt.Fprintln(&b, `import * as pulumi from "@pulumi/pulumi";`)
+		for _, line := range g.collectImports([]*il.Graph{f.view}) {
+			fmt.Fprintln(&b, line)
+		}
+		for _, dep := range sortedFiles(f.imports) {
+			if len(f.imports[dep]) == 0 {
+				fmt.Fprintf(&b, "import \"%s\";\n", g.importSpecifier(f.path, dep.path))
+			} else {
+				fmt.Fprintf(&b, "import { %s } from \"%s\";\n", strings.Join(sortedNames(f.imports[dep]), ", "),
+					g.importSpecifier(f.path, dep.path))
+			}
+		}
+		if body != "" {
+			fmt.Fprintf(&b, "\n%s\n", body)
+		}
+		if f.isRoot && len(f.exports) != 0 {
+			fmt.Fprintf(&b, "\nexport { %s };\n", strings.Join(sortedNames(f.exports), ", "))
+		}
+
+		program := b.String()
+		if g.postProcess != nil {
+			var err error
+			if program, err = g.postProcess(program); err != nil {
+				return errors.Wrapf(err, "post-processing %s", f.path)
+			}
+		}
+
+		filename := filepath.Join(g.dir, filepath.FromSlash(f.path))
+		if err := g.fs.MkdirAll(filepath.Dir(filename), 0700); err != nil {
+			return err
+		}
+		if err := afero.WriteFile(g.fs, filename, []byte(program), 0600); err != nil {
+			return err
+		}
+	}
+	return nil
+}
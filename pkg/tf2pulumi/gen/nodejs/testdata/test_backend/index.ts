@@ -0,0 +1,11 @@
+import * as pulumi from "@pulumi/pulumi";
+import * as aws from "@pulumi/aws";
+
+// The Terraform configuration stores its state in the "s3" backend. Pulumi does not configure state in
+// the program: state is kept by the backend that the Pulumi CLI is logged in to.
+// To keep state in the same kind of store, run `pulumi login s3://<bucket-name>`.
+// See https://www.pulumi.com/docs/concepts/state/ for more information.
+
+const main = new aws.ec2.Vpc("main", {
+    cidrBlock: "10.0.0.0/16",
+});
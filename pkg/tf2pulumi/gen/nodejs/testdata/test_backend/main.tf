@@ -0,0 +1,11 @@
+terraform {
+  backend "s3" {
+    bucket = "my-terraform-state"
+    key    = "network/terraform.tfstate"
+    region = "us-east-1"
+  }
+}
+
+resource "aws_vpc" "main" {
+  cidr_block = "10.0.0.0/16"
+}
@@ -0,0 +1,62 @@
+import * as pulumi from "@pulumi/pulumi";
+import * as aws from "@pulumi/aws";
+
+class AssetsModule extends pulumi.ComponentResource {
+    public readonly arn: pulumi.Output<any>;
+    public readonly bucketName: pulumi.Output<any>;
+
+    constructor(mod_name: string, mod_args: pulumi.Inputs, opts?: pulumi.ComponentResourceOptions) {
+        super("tf2pulumi:module:AssetsModule", mod_name, {}, opts);
+
+        const name = pulumi.output(mod_args["name"]);
+        const versioning = pulumi.output(mod_args["versioning"] || false);
+
+        const bucket = new aws.s3.Bucket(`${mod_name}_bucket`, {
+            bucket: pulumi.interpolate`${name}-bucket`,
+            versioning: {
+                enabled: versioning,
+            },
+        }, { parent: this });
+
+        this.arn = pulumi.output(bucket.arn);
+        this.bucketName = pulumi.output(bucket.bucket);
+        this.registerOutputs({
+            arn: this.arn,
+            bucketName: this.bucketName,
+        });
+    }
+}
+class LogsModule extends pulumi.ComponentResource {
+    public readonly arn: pulumi.Output<any>;
+    public readonly bucketName: pulumi.Output<any>;
+
+    constructor(mod_name: string, mod_args: pulumi.Inputs, opts?: pulumi.ComponentResourceOptions) {
+        super("tf2pulumi:module:LogsModule", mod_name, {}, opts);
+
+        const name = pulumi.output(mod_args["name"]);
+        const versioning = pulumi.output(mod_args["versioning"] || false);
+
+        const bucket = new aws.s3.Bucket(`${mod_name}_bucket`, {
+            bucket: pulumi.interpolate`${name}-bucket`,
+            versioning: {
+                enabled: versioning,
+            },
+        }, { parent: this });
+
+        this.arn = pulumi.output(bucket.arn);
+        this.bucketName = pulumi.output(bucket.bucket);
+        this.registerOutputs({
+            arn: this.arn,
+            bucketName: this.bucketName,
+        });
+    }
+}
+const logs = new LogsModule("logs", {
+    name: "logs",
+});
+const assets = new AssetsModule("assets", {
+    name: "assets",
+    versioning: true,
+});
+
+export const logsArn = logs.arn;
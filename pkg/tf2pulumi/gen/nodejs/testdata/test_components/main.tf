@@ -0,0 +1,16 @@
+module "logs" {
+  source = "./modules/bucket"
+
+  name = "logs"
+}
+
+module "assets" {
+  source = "./modules/bucket"
+
+  name       = "assets"
+  versioning = true
+}
+
+output "logs_arn" {
+  value = "${module.logs.arn}"
+}
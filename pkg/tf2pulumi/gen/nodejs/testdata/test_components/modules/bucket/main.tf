@@ -0,0 +1,21 @@
+variable "name" {}
+
+variable "versioning" {
+  default = false
+}
+
+resource "aws_s3_bucket" "bucket" {
+  bucket = "${var.name}-bucket"
+
+  versioning {
+    enabled = "${var.versioning}"
+  }
+}
+
+output "arn" {
+  value = "${aws_s3_bucket.bucket.arn}"
+}
+
+output "bucket_name" {
+  value = "${aws_s3_bucket.bucket.bucket}"
+}
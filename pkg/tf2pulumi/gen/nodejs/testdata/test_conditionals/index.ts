@@ -50,14 +50,13 @@ if (inUsEast1) {
     });
 }
 // If we are in us-east-2, create a different ec2 instance
-const createWeb2 = ((awsRegion === "us-east-2") ? 1 : 0);
 let web2: aws.ec2.Instance | undefined;
-if (!!(createWeb2)) {
+if ((awsRegion === "us-east-2")) {
     web2 = new aws.ec2.Instance("web2", {
         ami: "some-other-ami",
         instanceType: "t2.micro",
         tags: {
-            Name: `instance-${(createWeb2 % 2)}`,
+            Name: "instance-0",
         },
     });
 }
@@ -0,0 +1,44 @@
+import * as pulumi from "@pulumi/pulumi";
+import * as aws from "@pulumi/aws";
+
+const config = new pulumi.Config();
+const names = config.get("names") || [
+    "alpha",
+    "beta",
+];
+const enabled = config.getBoolean("enabled") || true;
+
+const web: aws.ec2.Instance[] = [];
+for (let i = 0; i < 3; i++) {
+    web.push(new aws.ec2.Instance(`web-${i}`, {
+        ami: "ami-12345678",
+        ebsBlockDevices: [{
+            deviceName: `/dev/sd${(list => list[i % list.length])(["f", "g", "h"])}`,
+        }],
+        instanceType: "t2.micro",
+        tags: {
+            Index: `${i}`,
+            Name: `web-${(i + 1)}`,
+        },
+    }));
+}
+const named: aws.ec2.Instance[] = [];
+for (let i = 0; i < names.length; i++) {
+    named.push(new aws.ec2.Instance(`named-${i}`, {
+        ami: "ami-12345678",
+        instanceType: "t2.micro",
+        tags: {
+            Name: `${names[i]}-${i}`,
+        },
+    }));
+}
+let optional: aws.ec2.Instance | undefined;
+if (enabled) {
+    optional = new aws.ec2.Instance("optional", {
+        ami: "ami-12345678",
+        instanceType: "t2.micro",
+        tags: {
+            Name: "optional-0",
+        },
+    });
+}
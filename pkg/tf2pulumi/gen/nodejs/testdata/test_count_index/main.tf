@@ -0,0 +1,42 @@
+variable "names" {
+  default = ["alpha", "beta"]
+}
+
+variable "enabled" {
+  default = true
+}
+
+resource "aws_instance" "web" {
+  count         = 3
+  ami           = "ami-12345678"
+  instance_type = "t2.micro"
+
+  tags {
+    Name  = "web-${count.index + 1}"
+    Index = "${count.index}"
+  }
+
+  ebs_block_device {
+    device_name = "/dev/sd${element(list("f", "g", "h"), count.index)}"
+  }
+}
+
+resource "aws_instance" "named" {
+  count         = "${length(var.names)}"
+  ami           = "ami-12345678"
+  instance_type = "t2.micro"
+
+  tags {
+    Name = "${var.names[count.index]}-${count.index}"
+  }
+}
+
+resource "aws_instance" "optional" {
+  count         = "${var.enabled ? 1 : 0}"
+  ami           = "ami-12345678"
+  instance_type = "t2.micro"
+
+  tags {
+    Name = "optional-${count.index}"
+  }
+}
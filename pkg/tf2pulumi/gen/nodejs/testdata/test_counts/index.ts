@@ -0,0 +1,55 @@
+import * as pulumi from "@pulumi/pulumi";
+import * as aws from "@pulumi/aws";
+
+const config = new pulumi.Config();
+const bucketNames = config.get("bucketNames") || [
+    "alpha",
+    "beta",
+    "gamma",
+];
+const replicas = config.require("replicas");
+
+const fixed: aws.s3.Bucket[] = [];
+for (let i = 0; i < 2; i++) {
+    fixed.push(new aws.s3.Bucket(`fixed-${i}`, {
+        bucket: `fixed-${i}`,
+    }));
+}
+const dynamic: aws.s3.Bucket[] = [];
+for (let i = 0; i < bucketNames.length; i++) {
+    dynamic.push(new aws.s3.Bucket(`dynamic-${i}`, {
+        bucket: (list => list[i % list.length])(bucketNames),
+    }));
+}
+const first = new aws.s3.BucketPolicy("first", {
+    bucket: fixed[0].id,
+    policy: "{}",
+});
+const last = new aws.s3.BucketPolicy("last", {
+    bucket: pulumi.all(dynamic.map(v => v.id)).apply(id => id[(bucketNames.length - 1)]),
+    policy: "{}",
+});
+const indexed: aws.s3.BucketPolicy[] = [];
+for (let i = 0; i < bucketNames.length; i++) {
+    indexed.push(new aws.s3.BucketPolicy(`indexed-${i}`, {
+        bucket: pulumi.all(dynamic.map(v => v.id)).apply(id => id[i]),
+        policy: "{}",
+    }));
+}
+const zones: aws.GetAvailabilityZonesResult[] = [];
+for (let i = 0; i < 2; i++) {
+    zones.push(aws.getAvailabilityZones());
+}
+const replica: aws.s3.Bucket[] = [];
+for (let i = 0; i < Number.parseFloat(replicas); i++) {
+    replica.push(new aws.s3.Bucket(`replica-${i}`, {
+        bucket: `replica-${i}`,
+    }));
+}
+
+export const firstArn = fixed[0].arn;
+export const dynamicIds = dynamic.map(v => v.id);
+export const secondDynamicBucket = dynamic[1].bucket;
+export const firstZoneNames = zones[0].names;
+export const zoneIds = zones.map(v => v.id);
+export const firstReplica = replica[0].id;
@@ -0,0 +1,64 @@
+variable "bucket_names" {
+  default = ["alpha", "beta", "gamma"]
+}
+
+resource "aws_s3_bucket" "fixed" {
+  count  = 2
+  bucket = "fixed-${count.index}"
+}
+
+resource "aws_s3_bucket" "dynamic" {
+  count  = "${length(var.bucket_names)}"
+  bucket = "${element(var.bucket_names, count.index)}"
+}
+
+resource "aws_s3_bucket_policy" "first" {
+  bucket = "${aws_s3_bucket.fixed.0.id}"
+  policy = "{}"
+}
+
+resource "aws_s3_bucket_policy" "last" {
+  bucket = "${aws_s3_bucket.dynamic.*.id[length(var.bucket_names) - 1]}"
+  policy = "{}"
+}
+
+resource "aws_s3_bucket_policy" "indexed" {
+  count  = "${length(var.bucket_names)}"
+  bucket = "${aws_s3_bucket.dynamic.*.id[count.index]}"
+  policy = "{}"
+}
+
+output "first_arn" {
+  value = "${aws_s3_bucket.fixed.0.arn}"
+}
+
+output "dynamic_ids" {
+  value = "${aws_s3_bucket.dynamic.*.id}"
+}
+
+output "second_dynamic_bucket" {
+  value = "${aws_s3_bucket.dynamic.1.bucket}"
+}
+
+data "aws_availability_zones" "zones" {
+  count = 2
+}
+
+output "first_zone_names" {
+  value = "${data.aws_availability_zones.zones.0.names}"
+}
+
+output "zone_ids" {
+  value = "${data.aws_availability_zones.zones.*.id}"
+}
+
+variable "replicas" {}
+
+resource "aws_s3_bucket" "replica" {
+  count  = "${var.replicas}"
+  bucket = "replica-${count.index}"
+}
+
+output "first_replica" {
+  value = "${aws_s3_bucket.replica.0.id}"
+}
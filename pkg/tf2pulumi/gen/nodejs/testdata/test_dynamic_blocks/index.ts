@@ -0,0 +1,25 @@
+import * as pulumi from "@pulumi/pulumi";
+import * as aws from "@pulumi/aws";
+
+const config = new pulumi.Config();
+const ingressPorts = config.get("ingressPorts") || [
+    80,
+    443,
+];
+
+const web = new aws.ec2.SecurityGroup("web", {
+    egress: ingressPorts.map((port, portKey) => ({
+        cidrBlocks: ["0.0.0.0/0"],
+        description: `rule ${portKey}`,
+        fromPort: port,
+        protocol: "tcp",
+        toPort: port,
+    })),
+    ingress: ingressPorts.map(ingress => ({
+        cidrBlocks: ["0.0.0.0/0"],
+        fromPort: ingress,
+        protocol: "tcp",
+        toPort: ingress,
+    })),
+    name: "web",
+});
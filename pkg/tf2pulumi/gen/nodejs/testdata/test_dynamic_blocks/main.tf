@@ -0,0 +1,31 @@
+variable "ingress_ports" {
+  default = [80, 443]
+}
+
+resource "aws_security_group" "web" {
+  name = "web"
+
+  dynamic "ingress" {
+    for_each = "${var.ingress_ports}"
+
+    content {
+      from_port   = "${ingress.value}"
+      to_port     = "${ingress.value}"
+      protocol    = "tcp"
+      cidr_blocks = ["0.0.0.0/0"]
+    }
+  }
+
+  dynamic "egress" {
+    for_each = "${var.ingress_ports}"
+    iterator = "port"
+
+    content {
+      description = "rule ${port.key}"
+      from_port   = "${port.value}"
+      to_port     = "${port.value}"
+      protocol    = "tcp"
+      cidr_blocks = ["0.0.0.0/0"]
+    }
+  }
+}
@@ -0,0 +1,24 @@
+import * as pulumi from "@pulumi/pulumi";
+import * as aws from "@pulumi/aws";
+
+const config = new pulumi.Config();
+const instanceTypes = config.get("instanceTypes") || [
+    "t2.micro",
+    "t2.small",
+];
+
+// element wraps the index around the length of the list.
+const counted: aws.ec2.Instance[] = [];
+for (let i = 0; i < 3; i++) {
+    counted.push(new aws.ec2.Instance(`counted-${i}`, {
+        ami: "ami-1234",
+        instanceType: (list => list[i % list.length])(instanceTypes),
+    }));
+}
+// The list is only evaluated once, even if it is not a simple reference.
+const computed = new aws.ec2.Instance("computed", {
+    ami: "ami-1234",
+    instanceType: (list => list[5 % list.length])("t2.micro,t2.small".split(",")),
+});
+
+export const firstId = pulumi.all(counted.map(v => v.id)).apply(id => (list => list[0 % list.length])(id));
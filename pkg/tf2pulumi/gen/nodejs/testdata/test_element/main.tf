@@ -0,0 +1,21 @@
+variable "instance_types" {
+  default = ["t2.micro", "t2.small"]
+}
+
+# element wraps the index around the length of the list.
+resource "aws_instance" "counted" {
+  count = 3
+
+  ami           = "ami-1234"
+  instance_type = "${element(var.instance_types, count.index)}"
+}
+
+# The list is only evaluated once, even if it is not a simple reference.
+resource "aws_instance" "computed" {
+  ami           = "ami-1234"
+  instance_type = "${element(split(",", "t2.micro,t2.small"), 5)}"
+}
+
+output "first_id" {
+  value = "${element(aws_instance.counted.*.id, 0)}"
+}
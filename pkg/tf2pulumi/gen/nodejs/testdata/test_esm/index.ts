@@ -0,0 +1,13 @@
+import * as pulumi from "@pulumi/pulumi";
+import * as aws from "@pulumi/aws";
+import * as random from "@pulumi/random";
+import sprintf = require("sprintf-js");
+
+const config = new pulumi.Config();
+const bucketPrefix = config.get("bucketPrefix") || "logs";
+
+const logs = new aws.s3.Bucket("logs", {
+    bucket: sprintf.sprintf("%s-%s", bucketPrefix, "bucket"),
+});
+
+export const bucketArn = logs.arn;
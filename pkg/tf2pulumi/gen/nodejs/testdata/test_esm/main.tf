@@ -0,0 +1,18 @@
+provider "aws" {
+  region = "us-west-2"
+}
+
+# This provider only configures the default provider instance, and nothing uses it.
+provider "random" {}
+
+variable "bucket_prefix" {
+  default = "logs"
+}
+
+resource "aws_s3_bucket" "logs" {
+  bucket = "${format("%s-%s", var.bucket_prefix, "bucket")}"
+}
+
+output "bucket_arn" {
+  value = "${aws_s3_bucket.logs.arn}"
+}
@@ -0,0 +1,18 @@
+import * as pulumi from "@pulumi/pulumi";
+import * as aws from "@pulumi/aws";
+import * as fs from "fs";
+
+const config = new pulumi.Config();
+const bucketName = config.get("bucketName") || "my-bucket";
+
+const bucket = new aws.s3.Bucket("bucket", {
+    bucket: bucketName,
+    policy: fs.readFileSync("policy.json", "utf-8"),
+});
+const index = new aws.s3.BucketObject("index", {
+    bucket: bucket.bucket,
+    content: /* TODO: tf2pulumi only substitutes simple ${name} references in templatefile() */ ((template: string, vars: Record<string, any>) => template.replace(/\$\{\s*(\w+)\s*\}/g, (_, name) => String(vars[name])))(fs.readFileSync(`./index.html.tpl`, "utf-8"), {"name": bucketName}),
+    key: "index.html",
+});
+
+export const readme = fs.readFileSync(`./README.md`, "utf-8");
@@ -0,0 +1,18 @@
+variable "bucket_name" {
+  default = "my-bucket"
+}
+
+resource "aws_s3_bucket" "bucket" {
+  bucket = "${var.bucket_name}"
+  policy = "${file("policy.json")}"
+}
+
+resource "aws_s3_bucket_object" "index" {
+  bucket  = "${aws_s3_bucket.bucket.bucket}"
+  key     = "index.html"
+  content = "${templatefile("${path.module}/index.html.tpl", map("name", var.bucket_name))}"
+}
+
+output "readme" {
+  value = "${file("${path.module}/README.md")}"
+}
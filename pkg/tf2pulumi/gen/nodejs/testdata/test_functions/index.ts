@@ -0,0 +1,66 @@
+import * as pulumi from "@pulumi/pulumi";
+import * as aws from "@pulumi/aws";
+
+const config = new pulumi.Config();
+const amis = config.get("amis") || {
+    "us-east-1": "ami-1234",
+    "us-west-2": "ami-5678",
+};
+const region = config.get("region") || "us-west-2";
+const instanceTypes = config.get("instanceTypes") || [
+    "t2.micro",
+    "t2.small",
+];
+const name = config.get("name") || "";
+const extraTags = config.get("extraTags") || {
+    Owner: "ops",
+};
+
+// lookup with a default lowers to nullish coalescing.
+const lookup = new aws.ec2.Instance("lookup", {
+    ami: ((<any>amis)[region] ?? "ami-default"),
+    instanceType: "t2.micro",
+});
+// element wraps the index around the length of the list.
+const element: aws.ec2.Instance[] = [];
+for (let i = 0; i < 3; i++) {
+    element.push(new aws.ec2.Instance(`element-${i}`, {
+        ami: amis["us-east-1"],
+        instanceType: (list => list[i % list.length])(instanceTypes),
+    }));
+}
+// coalesce picks the first non-empty argument.
+const coalesce = new aws.ec2.Instance("coalesce", {
+    ami: amis["us-east-1"],
+    instanceType: "t2.micro",
+    tags: {
+        Name: [name, "default"].find((v: any) => v !== undefined && v !== ""),
+    },
+});
+// merge combines maps without mutating its arguments.
+const merge = new aws.ec2.Instance("merge", {
+    ami: amis["us-east-1"],
+    instanceType: "t2.micro",
+    tags: Object.assign({}, extraTags, {"Name": "merged"}),
+});
+// keys and values list the keys and values of a map.
+const keysValues = new aws.ec2.Instance("keys_values", {
+    ami: amis["us-east-1"],
+    instanceType: "t2.micro",
+    tags: {
+        Amis: Object.values(amis).join(","),
+        Regions: Object.keys(amis).join(","),
+    },
+});
+// zipmap builds a map from lists of keys and values, whose lengths may only be known at runtime.
+const zipmap = new aws.ec2.Instance("zipmap", {
+    ami: amis["us-east-1"],
+    instanceType: "t2.micro",
+    tags: pulumi.all([pulumi.all(element.map(v => v.id)), pulumi.all(element.map(v => v.privateIp))]).apply(([id, privateIp]) => ((keys: any[], values: any[]) => keys.reduce((m: Record<string, any>, k: any, i: number) => ({ ...m, [k]: values[i] }), {}))(id, privateIp)),
+});
+// Unsupported functions are emitted as TODOs.
+const unsupported = new aws.ec2.Instance("unsupported", {
+    ami: amis["us-east-1"],
+    instanceType: "t2.micro",
+    privateIp: (() => { throw "TODO: tf2pulumi does not support the cidrhost function"; })(),
+});
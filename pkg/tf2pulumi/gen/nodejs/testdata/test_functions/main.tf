@@ -0,0 +1,80 @@
+variable "amis" {
+  default = {
+    us-east-1 = "ami-1234"
+    us-west-2 = "ami-5678"
+  }
+}
+
+variable "region" {
+  default = "us-west-2"
+}
+
+variable "instance_types" {
+  default = ["t2.micro", "t2.small"]
+}
+
+variable "name" {
+  default = ""
+}
+
+variable "extra_tags" {
+  default = {
+    Owner = "ops"
+  }
+}
+
+# lookup with a default lowers to nullish coalescing.
+resource "aws_instance" "lookup" {
+  ami           = "${lookup(var.amis, var.region, "ami-default")}"
+  instance_type = "t2.micro"
+}
+
+# element wraps the index around the length of the list.
+resource "aws_instance" "element" {
+  count = 3
+
+  ami           = "${var.amis["us-east-1"]}"
+  instance_type = "${element(var.instance_types, count.index)}"
+}
+
+# coalesce picks the first non-empty argument.
+resource "aws_instance" "coalesce" {
+  ami           = "${var.amis["us-east-1"]}"
+  instance_type = "t2.micro"
+
+  tags = {
+    Name = "${coalesce(var.name, "default")}"
+  }
+}
+
+# merge combines maps without mutating its arguments.
+resource "aws_instance" "merge" {
+  ami           = "${var.amis["us-east-1"]}"
+  instance_type = "t2.micro"
+  tags          = "${merge(var.extra_tags, map("Name", "merged"))}"
+}
+
+# keys and values list the keys and values of a map.
+resource "aws_instance" "keys_values" {
+  ami           = "${var.amis["us-east-1"]}"
+  instance_type = "t2.micro"
+
+  tags = {
+    Regions = "${join(",", keys(var.amis))}"
+    Amis    = "${join(",", values(var.amis))}"
+  }
+}
+
+# zipmap builds a map from lists of keys and values, whose lengths may only be known at runtime.
+resource "aws_instance" "zipmap" {
+  ami           = "${var.amis["us-east-1"]}"
+  instance_type = "t2.micro"
+  tags          = "${zipmap(aws_instance.element.*.id, aws_instance.element.*.private_ip)}"
+}
+
+# Unsupported functions are emitted as TODOs.
+resource "aws_instance" "unsupported" {
+  ami           = "${var.amis["us-east-1"]}"
+  instance_type = "t2.micro"
+  private_ip    = "${cidrhost("10.0.0.0/16", 5)}"
+}
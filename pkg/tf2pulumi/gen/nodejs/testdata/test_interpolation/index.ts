@@ -0,0 +1,25 @@
+import * as pulumi from "@pulumi/pulumi";
+import * as aws from "@pulumi/aws";
+
+const config = new pulumi.Config();
+const name = config.require("name");
+const key = config.require("key");
+const tags = config.requireObject<Record<string, any>>("tags");
+
+const web = new aws.ec2.Instance("web", {
+    ami: name,
+    tags: {
+        Backslash: `a\\b ${name}`,
+        Backtick: `\`cmd\` ${name}`,
+        Escaped: "${var.name}",
+        EscapedMixed: `\${x} ${name}`,
+        Nested: `ab${name}cd`,
+        NestedLookup: (<any>tags)[`name-${key}`],
+        NestedWhole: name,
+        Quote: `say "hi" ${name}`,
+        Surrounded: `prefix-${name}-suffix`,
+        Whole: name,
+    },
+});
+
+export const address = pulumi.interpolate`${web.id}-\${raw}-${web.arn}`;
@@ -0,0 +1,28 @@
+variable "name" {}
+
+variable "key" {}
+
+variable "tags" {
+  type = "map"
+}
+
+resource "aws_instance" "web" {
+  ami = "${var.name}"
+
+  tags = {
+    Whole        = "${var.name}"
+    Surrounded   = "prefix-${var.name}-suffix"
+    Nested       = "a${"b${var.name}c"}d"
+    NestedWhole  = "${"${var.name}"}"
+    NestedLookup = "${lookup(var.tags, "name-${var.key}")}"
+    Escaped      = "$${var.name}"
+    EscapedMixed = "$${x} ${var.name}"
+    Backtick     = "`cmd` ${var.name}"
+    Backslash    = "a\\b ${var.name}"
+    Quote        = "say \"hi\" ${var.name}"
+  }
+}
+
+output "address" {
+  value = "${aws_instance.web.id}-$${raw}-${aws_instance.web.arn}"
+}
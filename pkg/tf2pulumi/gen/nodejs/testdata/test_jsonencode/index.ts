@@ -0,0 +1,12 @@
+import * as pulumi from "@pulumi/pulumi";
+import * as aws from "@pulumi/aws";
+
+const bucket = new aws.s3.Bucket("bucket", {
+    bucket: "my-bucket",
+});
+const policy = new aws.iam.Policy("policy", {
+    name: "bucket-read",
+    policy: bucket.arn.apply(arn => JSON.stringify({"Version": "2012-10-17", "Statement": [{"Effect": "Allow", "Action": ["s3:GetObject"], "Resource": `${arn}/*`}]})),
+});
+
+export const statements = policy.policy.apply(policy => (<any>JSON.parse(policy))["Statement"]);
@@ -0,0 +1,12 @@
+resource "aws_s3_bucket" "bucket" {
+  bucket = "my-bucket"
+}
+
+resource "aws_iam_policy" "policy" {
+  name   = "bucket-read"
+  policy = "${jsonencode(map("Version", "2012-10-17", "Statement", list(map("Effect", "Allow", "Action", list("s3:GetObject"), "Resource", "${aws_s3_bucket.bucket.arn}/*"))))}"
+}
+
+output "statements" {
+  value = "${lookup(jsondecode(aws_iam_policy.policy.policy), "Statement")}"
+}
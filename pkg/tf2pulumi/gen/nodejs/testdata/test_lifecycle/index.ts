@@ -0,0 +1,12 @@
+import * as pulumi from "@pulumi/pulumi";
+import * as aws from "@pulumi/aws";
+
+const protectedBucket = new aws.s3.Bucket("protected", {
+    bucket: "protected-bucket",
+}, { protect: true });
+const someChanges = new aws.s3.Bucket("some_changes", {
+    bucket: "some-changes-bucket",
+}, { ignoreChanges: ["acl", "tags"], protect: true });
+const allChanges = new aws.s3.Bucket("all_changes", {
+    bucket: "all-changes-bucket",
+}, { ignoreChanges: ["accelerationStatus", "acl", "arn", "bucket", "bucketDomainName", "bucketPrefix", "bucketRegionalDomainName", "corsRules", "forceDestroy", "grants", "hostedZoneId", "lifecycleRules", "loggings", "objectLockConfiguration", "policy", "region", "replicationConfiguration", "requestPayer", "serverSideEncryptionConfiguration", "tags", "versioning", "website", "websiteDomain", "websiteEndpoint"] });
@@ -0,0 +1,24 @@
+resource "aws_s3_bucket" "protected" {
+  bucket = "protected-bucket"
+
+  lifecycle {
+    prevent_destroy = true
+  }
+}
+
+resource "aws_s3_bucket" "some_changes" {
+  bucket = "some-changes-bucket"
+
+  lifecycle {
+    prevent_destroy = true
+    ignore_changes  = ["tags", "acl"]
+  }
+}
+
+resource "aws_s3_bucket" "all_changes" {
+  bucket = "all-changes-bucket"
+
+  lifecycle {
+    ignore_changes = ["all"]
+  }
+}
@@ -0,0 +1,21 @@
+import * as pulumi from "@pulumi/pulumi";
+import * as aws from "@pulumi/aws";
+
+const config = new pulumi.Config();
+const environment = config.get("environment") || "dev";
+
+const region = "us-west-2";
+// The prefix depends on the environment and the region.
+const prefix = `${environment}-${region}`;
+const fullName = `${prefix}-web`;
+const web = new aws.ec2.Instance("web", {
+    ami: "some-ami",
+    instanceType: "t2.micro",
+    tags: {
+        Name: fullName,
+        Region: region,
+    },
+});
+const myInstanceId = web.id;
+
+export const instanceId = myInstanceId;
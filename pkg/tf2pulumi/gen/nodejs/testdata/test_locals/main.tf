@@ -0,0 +1,32 @@
+variable "environment" {
+  default = "dev"
+}
+
+# The full name depends on the prefix, which is declared after it.
+locals {
+  full_name = "${local.prefix}-web"
+}
+
+locals {
+  # The prefix depends on the environment and the region.
+  prefix = "${var.environment}-${local.region}"
+  region = "us-west-2"
+}
+
+resource "aws_instance" "web" {
+  ami           = "some-ami"
+  instance_type = "t2.micro"
+
+  tags = {
+    Name   = "${local.full_name}"
+    Region = "${local.region}"
+  }
+}
+
+locals {
+  instance_id = "${aws_instance.web.id}"
+}
+
+output "instance_id" {
+  value = "${local.instance_id}"
+}
@@ -0,0 +1,20 @@
+import * as pulumi from "@pulumi/pulumi";
+import * as aws from "@pulumi/aws";
+
+const config = new pulumi.Config();
+const amis = config.get("amis") || {
+    "us-east-1": "ami-1234",
+    "us-west-2": "ami-5678",
+};
+const region = config.get("region") || "us-west-2";
+
+// lookup without a default indexes the map.
+const required = new aws.ec2.Instance("required", {
+    ami: (<any>amis)[region],
+    instanceType: "t2.micro",
+});
+// lookup with a default falls back to it if the key is missing.
+const optional = new aws.ec2.Instance("optional", {
+    ami: ((<any>amis)["eu-west-1"] ?? "ami-default"),
+    instanceType: "t2.micro",
+});
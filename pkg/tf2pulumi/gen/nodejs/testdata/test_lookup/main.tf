@@ -0,0 +1,22 @@
+variable "amis" {
+  default = {
+    us-east-1 = "ami-1234"
+    us-west-2 = "ami-5678"
+  }
+}
+
+variable "region" {
+  default = "us-west-2"
+}
+
+# lookup without a default indexes the map.
+resource "aws_instance" "required" {
+  ami           = "${lookup(var.amis, var.region)}"
+  instance_type = "t2.micro"
+}
+
+# lookup with a default falls back to it if the key is missing.
+resource "aws_instance" "optional" {
+  ami           = "${lookup(var.amis, "eu-west-1", "ami-default")}"
+  instance_type = "t2.micro"
+}
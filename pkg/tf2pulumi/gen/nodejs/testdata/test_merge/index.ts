@@ -0,0 +1,17 @@
+import * as pulumi from "@pulumi/pulumi";
+import * as aws from "@pulumi/aws";
+
+const config = new pulumi.Config();
+const defaultTags = config.get("defaultTags") || {
+    Owner: "ops",
+};
+const extraTags = config.get("extraTags") || {
+    Team: "infra",
+};
+
+// merge combines maps without mutating its arguments; later arguments take precedence.
+const merged = new aws.ec2.Instance("merged", {
+    ami: "ami-1234",
+    instanceType: "t2.micro",
+    tags: Object.assign({}, defaultTags, extraTags, {"Name": "merged"}),
+});
@@ -0,0 +1,18 @@
+variable "default_tags" {
+  default = {
+    Owner = "ops"
+  }
+}
+
+variable "extra_tags" {
+  default = {
+    Team = "infra"
+  }
+}
+
+# merge combines maps without mutating its arguments; later arguments take precedence.
+resource "aws_instance" "merged" {
+  ami           = "ami-1234"
+  instance_type = "t2.micro"
+  tags          = "${merge(var.default_tags, var.extra_tags, map("Name", "merged"))}"
+}
@@ -0,0 +1,13 @@
+import * as pulumi from "@pulumi/pulumi";
+import * as aws from "@pulumi/aws";
+
+const web = new aws.ec2.Instance("web", {
+    ami: "some-ami",
+    instanceType: "t2.micro",
+}, { aliases: [{ name: "server" }, { name: "old_server" }] });
+const ips: aws.ec2.Eip[] = [];
+for (let i = 0; i < 2; i++) {
+    ips.push(new aws.ec2.Eip(`ips-${i}`, {
+        instance: web.id,
+    }, { aliases: [{ name: `addresses-${i}` }] }));
+}
@@ -0,0 +1,31 @@
+resource "aws_instance" "web" {
+  ami           = "some-ami"
+  instance_type = "t2.micro"
+}
+
+# The server was renamed twice.
+moved {
+  from = "aws_instance.server"
+  to   = "aws_instance.web"
+}
+
+moved {
+  from = "aws_instance.old_server"
+  to   = "aws_instance.server"
+}
+
+resource "aws_eip" "ips" {
+  count    = 2
+  instance = "${aws_instance.web.id}"
+}
+
+moved {
+  from = "aws_eip.addresses"
+  to   = "aws_eip.ips"
+}
+
+# Moves between modules are not converted.
+moved {
+  from = "module.old"
+  to   = "module.new"
+}
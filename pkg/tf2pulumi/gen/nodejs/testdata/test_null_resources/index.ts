@@ -0,0 +1,32 @@
+import * as pulumi from "@pulumi/pulumi";
+import * as aws from "@pulumi/aws";
+import * as command from "@pulumi/command";
+
+const config = new pulumi.Config();
+const instanceIds = config.requireObject<any[]>("instanceIds");
+
+// Re-run the bootstrap script whenever the set of instances changes.
+const bootstrap = new command.local.Command("bootstrap", {
+    create: `bootstrap.sh ${instanceIds.join(" ")}`,
+    triggers: [{
+        instanceIds: instanceIds.join(","),
+    }],
+});
+const bootstrapProvisioner1 = new command.local.Command("bootstrap-provisioner-1", {
+    delete: "teardown.sh",
+}, { dependsOn: [bootstrap] });
+// A bare null_resource that only orders its provisioner.
+const notify = new command.local.Command("notify", {
+    create: "notify.sh",
+}, { dependsOn: [bootstrap] });
+const perInstance: command.local.Command[] = [];
+for (let i = 0; i < instanceIds.length; i++) {
+    perInstance.push(new command.local.Command(`per_instance-${i}`, {
+        triggers: [{
+            instanceId: instanceIds[i],
+        }],
+    }));
+}
+const logs = new aws.s3.Bucket("logs", {
+    bucket: pulumi.interpolate`${bootstrap.id}-logs`,
+}, { dependsOn: [notify] });
@@ -0,0 +1,42 @@
+variable "instance_ids" {
+  type = "list"
+}
+
+# Re-run the bootstrap script whenever the set of instances changes.
+resource "null_resource" "bootstrap" {
+  triggers {
+    instance_ids = "${join(",", var.instance_ids)}"
+  }
+
+  provisioner "local-exec" {
+    command = "bootstrap.sh ${join(" ", var.instance_ids)}"
+  }
+
+  provisioner "local-exec" {
+    when    = "destroy"
+    command = "teardown.sh"
+  }
+}
+
+# A bare null_resource that only orders its provisioner.
+resource "null_resource" "notify" {
+  depends_on = ["null_resource.bootstrap"]
+
+  provisioner "local-exec" {
+    command = "notify.sh"
+  }
+}
+
+resource "null_resource" "per_instance" {
+  count = "${length(var.instance_ids)}"
+
+  triggers {
+    instance_id = "${var.instance_ids[count.index]}"
+  }
+}
+
+resource "aws_s3_bucket" "logs" {
+  depends_on = ["null_resource.notify"]
+
+  bucket = "${null_resource.bootstrap.id}-logs"
+}
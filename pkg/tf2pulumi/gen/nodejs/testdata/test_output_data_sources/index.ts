@@ -0,0 +1,23 @@
+import * as pulumi from "@pulumi/pulumi";
+import * as aws from "@pulumi/aws";
+
+const west = new aws.Provider("west", {
+    region: "us-west-2",
+});
+const mainVpc = new aws.ec2.Vpc("main", {
+    cidrBlock: "10.0.0.0/16",
+});
+const available = aws.getAvailabilityZonesOutput();
+const mainSubnetIds = aws.ec2.getSubnetIdsOutput({
+    vpcId: mainVpc.id,
+});
+const westCallerIdentity = aws.getCallerIdentityOutput({}, { provider: west });
+const zone: pulumi.Output<aws.GetAvailabilityZoneResult>[] = [];
+for (let i = 0; i < 2; i++) {
+    zone.push(aws.getAvailabilityZoneOutput({
+        name: available.apply(available => available.names[i]),
+    }));
+}
+
+export const subnetIds = mainSubnetIds.ids;
+export const accountId = westCallerIdentity.accountId;
@@ -0,0 +1,31 @@
+provider "aws" {
+  alias  = "west"
+  region = "us-west-2"
+}
+
+resource "aws_vpc" "main" {
+  cidr_block = "10.0.0.0/16"
+}
+
+data "aws_availability_zones" "available" {}
+
+data "aws_subnet_ids" "main" {
+  vpc_id = "${aws_vpc.main.id}"
+}
+
+data "aws_caller_identity" "west" {
+  provider = "aws.west"
+}
+
+data "aws_availability_zone" "zone" {
+  count = 2
+  name  = "${data.aws_availability_zones.available.names[count.index]}"
+}
+
+output "subnet_ids" {
+  value = "${data.aws_subnet_ids.main.ids}"
+}
+
+output "account_id" {
+  value = "${data.aws_caller_identity.west.account_id}"
+}
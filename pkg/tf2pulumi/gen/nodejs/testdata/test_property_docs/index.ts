@@ -0,0 +1,19 @@
+import * as pulumi from "@pulumi/pulumi";
+import * as widgets from "@pulumi/widgets";
+
+const example = new widgets.Widget("example", {
+    /**
+     * A description of the widget.
+     *
+     * Descriptions longer than 256 characters are truncated.
+     */
+    description: "An example widget",
+    /** The name of the widget. */
+    name: "example",
+    /** The settings of the widget. */
+    settings: {
+        /** The shade of the widget. Must not contain `*\/`. */
+        shade: "blue",
+    },
+    size: 3,
+});
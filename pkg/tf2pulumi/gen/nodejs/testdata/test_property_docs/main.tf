@@ -0,0 +1,9 @@
+resource "widgets_widget" "example" {
+  name        = "example"
+  description = "An example widget"
+  size        = 3
+
+  settings {
+    shade = "blue"
+  }
+}
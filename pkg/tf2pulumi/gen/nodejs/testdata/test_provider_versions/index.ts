@@ -0,0 +1,10 @@
+import * as pulumi from "@pulumi/pulumi";
+import * as aws from "@pulumi/aws"; // Terraform provider aws version >= 4.0
+import * as random from "@pulumi/random"; // Terraform provider random version ~> 2.1
+
+const bucket = new aws.s3.Bucket("bucket", {
+    bucket: "my-bucket",
+});
+const suffix = new random.Id("suffix", {
+    byteLength: 8,
+});
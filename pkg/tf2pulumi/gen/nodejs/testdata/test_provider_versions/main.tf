@@ -0,0 +1,26 @@
+terraform {
+  required_version = ">= 0.11.0"
+
+  required_providers {
+    aws = {
+      version = ">= 4.0"
+    }
+  }
+}
+
+provider "aws" {
+  region  = "us-west-2"
+  version = "~> 3.0"
+}
+
+provider "random" {
+  version = "~> 2.1"
+}
+
+resource "aws_s3_bucket" "bucket" {
+  bucket = "my-bucket"
+}
+
+resource "random_id" "suffix" {
+  byte_length = 8
+}
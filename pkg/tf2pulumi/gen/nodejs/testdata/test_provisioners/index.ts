@@ -0,0 +1,26 @@
+import * as pulumi from "@pulumi/pulumi";
+import * as aws from "@pulumi/aws";
+import * as command from "@pulumi/command";
+import * as fs from "fs";
+
+const web = new aws.ec2.Instance("web", {
+    ami: "ami-12345678",
+    instanceType: "t2.micro",
+});
+const webProvisioner = new command.local.Command("web-provisioner", {
+    create: pulumi.interpolate`echo ${web.privateIp} >> private_ips.txt`,
+    dir: "/tmp",
+}, { dependsOn: [web] });
+const webProvisioner1 = new command.local.Command("web-provisioner-1", {
+    delete: "echo destroying",
+}, { dependsOn: [web] });
+const webProvisioner2 = new command.remote.Command("web-provisioner-2", {
+    connection: {
+        host: web.publicIp,
+        privateKey: fs.readFileSync("id_rsa", "utf-8"),
+        user: "ubuntu",
+    },
+    create: pulumi.interpolate`sudo apt-get update
+echo ${web.id}`,
+}, { dependsOn: [web] });
+// TODO: file provisioners are not converted
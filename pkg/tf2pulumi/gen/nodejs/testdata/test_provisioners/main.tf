@@ -0,0 +1,33 @@
+resource "aws_instance" "web" {
+  ami           = "ami-12345678"
+  instance_type = "t2.micro"
+
+  provisioner "local-exec" {
+    command     = "echo ${self.private_ip} >> private_ips.txt"
+    working_dir = "/tmp"
+  }
+
+  provisioner "local-exec" {
+    when    = "destroy"
+    command = "echo destroying"
+  }
+
+  provisioner "remote-exec" {
+    inline = [
+      "sudo apt-get update",
+      "echo ${self.id}",
+    ]
+
+    connection {
+      type        = "ssh"
+      host        = "${self.public_ip}"
+      user        = "ubuntu"
+      private_key = "${file("id_rsa")}"
+    }
+  }
+
+  provisioner "file" {
+    source      = "conf/app.conf"
+    destination = "/etc/app.conf"
+  }
+}
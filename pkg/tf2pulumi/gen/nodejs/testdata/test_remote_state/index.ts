@@ -0,0 +1,20 @@
+import * as pulumi from "@pulumi/pulumi";
+import * as aws from "@pulumi/aws";
+
+const network = new pulumi.StackReference("network", { name: "network-prod" });
+// TODO: this stack reference replaces state read from the "s3" backend; check its stack name
+const dns = new pulumi.StackReference("dns");
+const webSecurityGroup = new aws.ec2.SecurityGroup("web", {
+    name: pulumi.interpolate`web-${network.getOutput("vpc_id")}`,
+    vpcId: network.getOutput("vpc_id"),
+});
+const webInstance = new aws.ec2.Instance("web", {
+    ami: "ami-7172b611",
+    instanceType: "t2.micro",
+    subnetId: network.getOutput("subnet_id"),
+    tags: {
+        Zone: dns.getOutput("zone_id"),
+    },
+});
+
+export const vpcId = network.getOutput("vpc_id");
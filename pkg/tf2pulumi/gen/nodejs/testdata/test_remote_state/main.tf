@@ -0,0 +1,39 @@
+data "terraform_remote_state" "network" {
+  backend = "remote"
+
+  config {
+    organization = "acme"
+
+    workspaces {
+      name = "network-prod"
+    }
+  }
+}
+
+data "terraform_remote_state" "dns" {
+  backend = "s3"
+
+  config {
+    bucket = "acme-state"
+    key    = "dns/terraform.tfstate"
+  }
+}
+
+resource "aws_security_group" "web" {
+  name   = "web-${data.terraform_remote_state.network.vpc_id}"
+  vpc_id = "${data.terraform_remote_state.network.vpc_id}"
+}
+
+resource "aws_instance" "web" {
+  ami           = "ami-7172b611"
+  instance_type = "t2.micro"
+  subnet_id     = "${data.terraform_remote_state.network.subnet_id}"
+
+  tags {
+    Zone = "${data.terraform_remote_state.dns.zone_id}"
+  }
+}
+
+output "vpc_id" {
+  value = "${data.terraform_remote_state.network.vpc_id}"
+}
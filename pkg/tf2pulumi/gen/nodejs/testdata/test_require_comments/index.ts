@@ -0,0 +1,31 @@
+import * as pulumi from "@pulumi/pulumi";
+import * as aws from "@pulumi/aws";
+
+const config = new pulumi.Config();
+// The region in which to create resources.
+//
+// This must be a region in which the AWS provider
+// is available.
+const awsRegion = config.get("awsRegion") || "us-west-2";
+// The CIDR block for the VPC.
+// Keep this in sync with the subnet below.
+const cidrBlock = config.get("cidrBlock") || "10.0.0.0/16";
+
+// The primary VPC.
+//
+// All other resources live in this VPC.
+const mainVpc = new aws.ec2.Vpc("main", {
+    // Taken from the variable above.
+    cidrBlock: cidrBlock,
+    tags: {
+        // The name of the VPC.
+        // This is shown in the console.
+        Name: "main",
+    },
+});
+// A single subnet in the VPC.
+// Multi-line comments must survive conversion.
+const mainSubnet = new aws.ec2.Subnet("main", {
+    cidrBlock: "10.0.1.0/24", // The first /24
+    vpcId: mainVpc.id,
+});
@@ -0,0 +1,36 @@
+# The region in which to create resources.
+#
+# This must be a region in which the AWS provider
+# is available.
+variable "aws_region" {
+	default = "us-west-2"
+}
+
+// The CIDR block for the VPC.
+// Keep this in sync with the subnet below.
+variable "cidr_block" {
+	default = "10.0.0.0/16"
+}
+
+/*
+ * The primary VPC.
+ *
+ * All other resources live in this VPC.
+ */
+resource "aws_vpc" "main" {
+	# Taken from the variable above.
+	cidr_block = "${var.cidr_block}"
+
+	tags {
+		// The name of the VPC.
+		// This is shown in the console.
+		Name = "main"
+	}
+}
+
+# A single subnet in the VPC.
+# Multi-line comments must survive conversion.
+resource "aws_subnet" "main" {
+	vpc_id     = "${aws_vpc.main.id}"
+	cidr_block = "10.0.1.0/24" # The first /24
+}
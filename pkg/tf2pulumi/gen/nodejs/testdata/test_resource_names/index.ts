@@ -0,0 +1,23 @@
+import * as pulumi from "@pulumi/pulumi";
+import * as aws from "@pulumi/aws";
+
+const west = new aws.Provider("prod-west", {
+    region: "us-west-2",
+});
+const web = new aws.ec2.Instance("prod-web", {
+    ami: "some-ami",
+    instanceType: "t2.micro",
+}, { provider: west, aliases: [{ name: "prod-server" }] });
+const ips: aws.ec2.Eip[] = [];
+for (let i = 0; i < 2; i++) {
+    ips.push(new aws.ec2.Eip(`prod-ips-${i}`, {
+        instance: web.id,
+    }));
+}
+// These names are truncated, but remain distinct.
+const aBucketWithARatherLongNameOne = new aws.s3.Bucket("prod-a_bucket_with_a_ra-0259d343", {
+    bucket: "one",
+});
+const aBucketWithARatherLongNameTwo = new aws.s3.Bucket("prod-a_bucket_with_a_ra-8245d8f5", {
+    bucket: "two",
+});
@@ -0,0 +1,29 @@
+provider "aws" {
+  alias  = "west"
+  region = "us-west-2"
+}
+
+resource "aws_instance" "web" {
+  provider      = "aws.west"
+  ami           = "some-ami"
+  instance_type = "t2.micro"
+}
+
+moved {
+  from = "aws_instance.server"
+  to   = "aws_instance.web"
+}
+
+resource "aws_eip" "ips" {
+  count    = 2
+  instance = "${aws_instance.web.id}"
+}
+
+# These names are truncated, but remain distinct.
+resource "aws_s3_bucket" "a_bucket_with_a_rather_long_name_one" {
+  bucket = "one"
+}
+
+resource "aws_s3_bucket" "a_bucket_with_a_rather_long_name_two" {
+  bucket = "two"
+}
@@ -0,0 +1,16 @@
+import * as pulumi from "@pulumi/pulumi";
+import * as aws from "@pulumi/aws";
+
+const config = new pulumi.Config();
+const bastionType = config.get("bastionType") || "t3.nano";
+
+const web = new aws.ec2.Instance("web", {
+    ami: "ami-123456",
+    instanceType: "t3.micro",
+    tags: {
+        Name: `web-${bastionType}`,
+        Type: "t3.micro",
+    },
+});
+
+export const recommendedType = "t3.large";
@@ -0,0 +1,17 @@
+variable "bastion_type" {
+  default = "t2.nano"
+}
+
+resource "aws_instance" "web" {
+  ami           = "ami-123456"
+  instance_type = "t2.micro"
+
+  tags = {
+    Name = "web-${var.bastion_type}"
+    Type = "t2.micro"
+  }
+}
+
+output "recommended_type" {
+  value = "t2.large"
+}
@@ -0,0 +1,13 @@
+import * as pulumi from "@pulumi/pulumi";
+import * as aws from "@pulumi/aws";
+
+const config = new pulumi.Config();
+const passwordInput = config.require("password");
+
+const db = new aws.rds.Instance("db", {
+    instanceClass: "db.t2.micro",
+    password: passwordInput,
+});
+
+export const address = db.address;
+export const password = pulumi.secret(db.password);
@@ -0,0 +1,15 @@
+variable "password" {}
+
+resource "aws_db_instance" "db" {
+  instance_class = "db.t2.micro"
+  password       = "${var.password}"
+}
+
+output "address" {
+  value = "${aws_db_instance.db.address}"
+}
+
+output "password" {
+  value     = "${aws_db_instance.db.password}"
+  sensitive = true
+}
@@ -0,0 +1,30 @@
+import * as pulumi from "@pulumi/pulumi";
+import * as aws from "@pulumi/aws";
+
+const config = new pulumi.Config();
+const dbPassword = pulumi.secret(config.require("dbPassword"));
+const dbUser = config.get("dbUser") || "admin";
+const apiToken = pulumi.secret(config.get("apiToken") || "changeme");
+
+const db = new aws.rds.Instance("db", {
+    instanceClass: "db.t2.micro",
+    password: dbPassword,
+    username: dbUser,
+});
+const connection = new aws.ssm.Parameter("connection", {
+    name: "connection",
+    type: "SecureString",
+    value: pulumi.interpolate`postgres://${dbUser}:${dbPassword}@${db.address}`,
+});
+const token = new aws.ssm.Parameter("token", {
+    name: "token",
+    type: "SecureString",
+    value: pulumi.interpolate`Bearer ${apiToken}`,
+});
+const user = new aws.ssm.Parameter("user", {
+    name: "user",
+    type: "String",
+    value: pulumi.secret(`user-${dbUser}`),
+});
+
+export const greeting = pulumi.secret(`hello ${dbUser}`);
@@ -0,0 +1,40 @@
+variable "db_password" {
+  sensitive = true
+}
+
+variable "db_user" {
+  default = "admin"
+}
+
+variable "api_token" {
+  default   = "changeme"
+  sensitive = true
+}
+
+resource "aws_db_instance" "db" {
+  instance_class = "db.t2.micro"
+  username       = "${var.db_user}"
+  password       = "${var.db_password}"
+}
+
+resource "aws_ssm_parameter" "connection" {
+  name  = "connection"
+  type  = "SecureString"
+  value = "postgres://${var.db_user}:${var.db_password}@${aws_db_instance.db.address}"
+}
+
+resource "aws_ssm_parameter" "token" {
+  name  = "token"
+  type  = "SecureString"
+  value = "Bearer ${var.api_token}"
+}
+
+resource "aws_ssm_parameter" "user" {
+  name  = "user"
+  type  = "String"
+  value = "${sensitive("user-${var.db_user}")}"
+}
+
+output "greeting" {
+  value = "hello ${sensitive(var.db_user)}"
+}
@@ -0,0 +1,21 @@
+import * as pulumi from "@pulumi/pulumi";
+import * as aws from "@pulumi/aws";
+
+const webInstance: aws.ec2.Instance[] = [];
+for (let i = 0; i < 3; i++) {
+    webInstance.push(new aws.ec2.Instance(`web-${i}`, {
+        ami: "some-ami",
+        instanceType: "t2.micro",
+    }));
+}
+const webEip: aws.ec2.Eip[] = [];
+for (let i = 0; i < 3; i++) {
+    webEip.push(new aws.ec2.Eip(`web-${i}`, {
+        instance: pulumi.all(webInstance.map(v => v.id)).apply(id => (list => list[i % list.length])(id)),
+    }));
+}
+
+export const instanceIds = webInstance.map(v => v.id);
+export const publicIps = webInstance.map(v => v.publicIp);
+export const firstId = pulumi.all(webInstance.map(v => v.id)).apply(id => (list => list[0 % list.length])(id));
+export const joinedIds = pulumi.all(webInstance.map(v => v.id)).apply(id => id.join(","));
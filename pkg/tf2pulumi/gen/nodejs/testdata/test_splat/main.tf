@@ -0,0 +1,26 @@
+resource "aws_instance" "web" {
+  count         = 3
+  ami           = "some-ami"
+  instance_type = "t2.micro"
+}
+
+resource "aws_eip" "web" {
+  count    = 3
+  instance = "${element(aws_instance.web.*.id, count.index)}"
+}
+
+output "instance_ids" {
+  value = "${aws_instance.web.*.id}"
+}
+
+output "public_ips" {
+  value = "${aws_instance.web[*].public_ip}"
+}
+
+output "first_id" {
+  value = "${element(aws_instance.web.*.id, 0)}"
+}
+
+output "joined_ids" {
+  value = "${join(",", aws_instance.web.*.id)}"
+}
@@ -0,0 +1,6 @@
+import * as pulumi from "@pulumi/pulumi";
+import { web } from "./main";
+import { mainVpc } from "./network";
+
+export const vpcId = mainVpc.id;
+export const publicIp = web.publicIp;
@@ -0,0 +1,13 @@
+resource "aws_instance" "web" {
+  ami           = "some-ami"
+  instance_type = "t2.micro"
+  subnet_id     = "${aws_subnet.main.id}"
+}
+
+output "vpc_id" {
+  value = "${aws_vpc.main.id}"
+}
+
+output "public_ip" {
+  value = "${aws_instance.web.public_ip}"
+}
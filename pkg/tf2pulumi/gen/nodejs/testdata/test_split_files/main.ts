@@ -0,0 +1,11 @@
+import * as pulumi from "@pulumi/pulumi";
+import * as aws from "@pulumi/aws";
+import { mainSubnet } from "./network";
+
+const web = new aws.ec2.Instance("web", {
+    ami: "some-ami",
+    instanceType: "t2.micro",
+    subnetId: mainSubnet.id,
+});
+
+export { web };
@@ -0,0 +1,15 @@
+import * as pulumi from "@pulumi/pulumi";
+import * as aws from "@pulumi/aws";
+
+const config = new pulumi.Config();
+const cidrBlock = config.get("cidrBlock") || "10.0.0.0/16";
+
+const mainVpc = new aws.ec2.Vpc("main", {
+    cidrBlock: cidrBlock,
+});
+const mainSubnet = new aws.ec2.Subnet("main", {
+    cidrBlock: "10.0.1.0/24",
+    vpcId: mainVpc.id,
+});
+
+export { mainSubnet, mainVpc };
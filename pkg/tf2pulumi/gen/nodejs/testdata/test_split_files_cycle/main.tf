@@ -0,0 +1,9 @@
+resource "aws_vpc" "main" {
+  cidr_block = "10.0.0.0/16"
+}
+
+resource "aws_instance" "web" {
+  ami           = "some-ami"
+  instance_type = "t2.micro"
+  subnet_id     = "${aws_subnet.main.id}"
+}
@@ -0,0 +1,18 @@
+import * as pulumi from "@pulumi/pulumi";
+import * as aws from "@pulumi/aws";
+
+const bucket = new aws.s3.Bucket("bucket", {
+    bucket: `bucket-${(() => {
+        // TODO: NYI: call to uuid
+        // Original Terraform source:
+        //     bucket-${uuid()}
+        throw "tf2pulumi TODO: this expression requires manual conversion";
+    })()}`,
+});
+
+export const created = (() => {
+    // TODO: NYI: call to timestamp
+    // Original Terraform source:
+    //     ${timestamp()}
+    throw "tf2pulumi TODO: this expression requires manual conversion";
+})();
@@ -0,0 +1,7 @@
+resource "aws_s3_bucket" "bucket" {
+  bucket = "bucket-${uuid()}"
+}
+
+output "created" {
+  value = "${timestamp()}"
+}
@@ -0,0 +1,15 @@
+import * as pulumi from "@pulumi/pulumi";
+import * as aws from "@pulumi/aws";
+
+const config = new pulumi.Config();
+const settings = config.getObject<Record<string, any>>("settings") || {};
+const fallbackName = config.get("fallbackName") || "fallback";
+
+const bucket = new aws.s3.Bucket("bucket", {
+    bucket: (fallbackName ?? "my-bucket"),
+});
+const logs = new aws.s3.Bucket("logs", {
+    bucket: ((...alternatives: (() => any)[]) => { for (const f of alternatives.slice(0, -1)) { try { const v = f(); if (v !== undefined) { return v; } } catch { } } return alternatives[alternatives.length - 1](); })(() => settings["logs_bucket"], () => (<any>settings)["bucket"], () => `${fallbackName}-logs`),
+});
+
+export const websiteEndpoint = pulumi.all([bucket.websiteEndpoint, logs.websiteEndpoint]).apply(([bucketWebsiteEndpoint, logsWebsiteEndpoint]) => (bucketWebsiteEndpoint ?? logsWebsiteEndpoint ?? "none"));
@@ -0,0 +1,20 @@
+variable "settings" {
+  type    = "map"
+  default = {}
+}
+
+variable "fallback_name" {
+  default = "fallback"
+}
+
+resource "aws_s3_bucket" "bucket" {
+  bucket = "${try(var.fallback_name, "my-bucket")}"
+}
+
+resource "aws_s3_bucket" "logs" {
+  bucket = "${try(var.settings["logs_bucket"], lookup(var.settings, "bucket"), "${var.fallback_name}-logs")}"
+}
+
+output "website_endpoint" {
+  value = "${try(aws_s3_bucket.bucket.website_endpoint, aws_s3_bucket.logs.website_endpoint, "none")}"
+}
@@ -0,0 +1,17 @@
+import * as pulumi from "@pulumi/pulumi";
+
+const config = new pulumi.Config();
+const name = config.require("name");
+const instanceCount = config.requireNumber("instanceCount");
+const enabled = config.getBoolean("enabled") || true;
+const zones = config.requireObject<string[]>("zones");
+const tags = config.getObject<Record<string, string>>("tags") || {
+    environment: "dev",
+};
+const legacyList = config.requireObject<any[]>("legacyList");
+const server = config.requireObject<{labels: Record<string, string>, name: string, ports: number[]}>("server");
+const servers = config.requireObject<Record<string, {size: number, "zone-name": string}>>("servers");
+const untyped = config.getNumber("untyped") || 42;
+
+
+export const summary = `${name}-${instanceCount}-${enabled}-${zones.length}-${untyped}`;
@@ -0,0 +1,43 @@
+variable "name" {
+  type = "string"
+}
+
+variable "instance_count" {
+  type = "number"
+}
+
+variable "enabled" {
+  type    = "bool"
+  default = true
+}
+
+variable "zones" {
+  type = "list(string)"
+}
+
+variable "tags" {
+  type = "map(string)"
+  default = {
+    environment = "dev"
+  }
+}
+
+variable "legacy_list" {
+  type = "list"
+}
+
+variable "server" {
+  type = "object({ name = string, ports = list(number), labels = map(string) })"
+}
+
+variable "servers" {
+  type = "map(object({ size = number, zone-name = string }))"
+}
+
+variable "untyped" {
+  default = 42
+}
+
+output "summary" {
+  value = "${var.name}-${var.instance_count}-${var.enabled}-${length(var.zones)}-${var.untyped}"
+}
@@ -77,7 +77,21 @@ func (g *generator) GeneratePreamble(modules []*il.Graph) error {
 		}
 	}
 
-	// TODO(swgillespie) walk the graph to find optional imports
+	// Look for additional optional imports.
+	usesJSON := false
+	findOptionals := func(n il.BoundNode) (il.BoundNode, error) {
+		if call, ok := n.(*il.BoundCall); ok && (call.Func == "jsondecode" || call.Func == "jsonencode") {
+			usesJSON = true
+		}
+		return n, nil
+	}
+	for _, m := range modules {
+		err := il.VisitAllProperties(m, findOptionals, il.IdentityVisitor)
+		contract.Assert(err == nil)
+	}
+	if usesJSON {
+		imports = append(imports, "import json")
+	}
 
 	sort.Strings(imports)
 	for _, pkg := range imports {
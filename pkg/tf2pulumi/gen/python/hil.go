@@ -50,6 +50,10 @@ func (g *generator) GenCall(w io.Writer, v *il.BoundCall) {
 		g.genResourceCall(w, v)
 	case il.IntrinsicApply:
 		g.genApply(w, v)
+	case "jsondecode":
+		g.Fgenf(w, "json.loads(%v)", v.Args[0])
+	case "jsonencode":
+		g.Fgenf(w, "json.dumps(%v)", v.Args[0])
 	default:
 		g.genNYI(w, "call")
 	}
@@ -90,8 +94,10 @@ func (g *generator) genApply(w io.Writer, v *il.BoundCall) {
 	g.genNYI(w, "nontrivial apply")
 }
 
+// GenConditional generates code for a single conditional expression. Python's conditional expression evaluates its
+// condition before either of its legs, so the result is equivalent to Terraform's `cond ? a : b`.
 func (g *generator) GenConditional(w io.Writer, v *il.BoundConditional) {
-	g.genNYI(w, "conditionals")
+	g.Fgenf(w, "(%v if %v else %v)", v.TrueExpr, v.CondExpr, v.FalseExpr)
 }
 
 func (g *generator) GenIndex(w io.Writer, v *il.BoundIndex) {
@@ -108,12 +114,7 @@ func (g *generator) GenLiteral(w io.Writer, v *il.BoundLiteral) {
 			g.Fgen(w, "False")
 		}
 	case il.TypeNumber:
-		floatVal := v.Value.(float64)
-		if float64(int64(floatVal)) == floatVal {
-			g.Fgenf(w, "%d", int64(floatVal))
-		} else {
-			g.Fgenf(w, "%g", v.Value)
-		}
+		g.Fgen(w, il.FormatNumber(v.Value))
 	case il.TypeString:
 		g.Fgenf(w, "%q", v.Value.(string))
 	default:
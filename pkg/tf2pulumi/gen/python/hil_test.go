@@ -56,13 +56,16 @@ func TestHilLiteralLowerBool(t *testing.T) {
 
 func TestHilLiteralLowerNumber(t *testing.T) {
 	cases := []struct {
-		Value float64
+		Value interface{}
 		Gen   string
 	}{
 		{Value: 2, Gen: "2"},
 		{Value: 2.1, Gen: "2.1"},
 		{Value: 2.0, Gen: "2"},
 		{Value: 4299.12, Gen: "4299.12"},
+		{Value: 1e10, Gen: "1e10"},
+		{Value: int64(2), Gen: "2"},
+		{Value: int64(9007199254740993), Gen: "9007199254740993"},
 	}
 
 	for _, test := range cases {
@@ -77,3 +80,72 @@ func TestHilLiteralLowerNumber(t *testing.T) {
 		})
 	}
 }
+
+func TestHilConditional(t *testing.T) {
+	cases := []struct {
+		Node il.BoundExpr
+		Gen  string
+	}{
+		{
+			Node: &il.BoundConditional{
+				ExprType:  il.TypeNumber,
+				CondExpr:  &il.BoundLiteral{ExprType: il.TypeBool, Value: true},
+				TrueExpr:  &il.BoundLiteral{ExprType: il.TypeNumber, Value: int64(1)},
+				FalseExpr: &il.BoundLiteral{ExprType: il.TypeNumber, Value: int64(0)},
+			},
+			Gen: "(1 if True else 0)",
+		},
+		{
+			Node: &il.BoundConditional{
+				ExprType: il.TypeString,
+				CondExpr: &il.BoundLiteral{ExprType: il.TypeBool, Value: false},
+				TrueExpr: &il.BoundLiteral{ExprType: il.TypeString, Value: "us-east-1"},
+				FalseExpr: &il.BoundConditional{
+					ExprType:  il.TypeString,
+					CondExpr:  &il.BoundLiteral{ExprType: il.TypeBool, Value: true},
+					TrueExpr:  &il.BoundLiteral{ExprType: il.TypeString, Value: "us-east-2"},
+					FalseExpr: &il.BoundLiteral{ExprType: il.TypeString, Value: "us-west-2"},
+				},
+			},
+			Gen: `("us-east-1" if False else ("us-east-2" if True else "us-west-2"))`,
+		},
+	}
+
+	for _, test := range cases {
+		t.Run(test.Gen, func(t *testing.T) {
+			out := runGen(test.Node)
+			assert.Equal(t, test.Gen, out)
+		})
+	}
+}
+
+func TestHilJSONCalls(t *testing.T) {
+	cases := []struct {
+		Node il.BoundExpr
+		Gen  string
+	}{
+		{
+			Node: &il.BoundCall{
+				Func:     "jsonencode",
+				ExprType: il.TypeString,
+				Args:     []il.BoundExpr{&il.BoundLiteral{ExprType: il.TypeString, Value: "s3:GetObject"}},
+			},
+			Gen: `json.dumps("s3:GetObject")`,
+		},
+		{
+			Node: &il.BoundCall{
+				Func:     "jsondecode",
+				ExprType: il.TypeUnknown,
+				Args:     []il.BoundExpr{&il.BoundLiteral{ExprType: il.TypeString, Value: "{}"}},
+			},
+			Gen: `json.loads("{}")`,
+		},
+	}
+
+	for _, test := range cases {
+		t.Run(test.Gen, func(t *testing.T) {
+			out := runGen(test.Node)
+			assert.Equal(t, test.Gen, out)
+		})
+	}
+}
@@ -87,6 +87,12 @@ func (b *propertyBinder) bindCall(n *ast.Call) (BoundExpr, error) {
 		exprType = TypeString
 	case "join":
 		exprType = TypeString
+	case "jsondecode":
+		// nothing to do
+	case "jsonencode":
+		exprType = TypeString
+	case "keys":
+		exprType = TypeString.ListOf()
 	case "length":
 		exprType = TypeNumber
 	case "list":
@@ -106,12 +112,38 @@ func (b *propertyBinder) bindCall(n *ast.Call) (BoundExpr, error) {
 		exprType = TypeNumber
 	case "replace":
 		exprType = TypeString
+	case "sensitive":
+		if len(args) != 1 {
+			err = errors.Errorf("\"sensitive\" requires exactly one argument")
+			break
+		}
+		exprType = args[0].Type()
 	case "signum":
 		exprType = TypeNumber
 	case "split":
 		exprType = TypeString.ListOf()
 	case "substr":
 		exprType = TypeString
+	case "templatefile":
+		if len(args) != 2 {
+			err = errors.Errorf("\"templatefile\" requires exactly two arguments")
+		}
+		exprType = TypeString
+	case "try":
+		if len(args) == 0 {
+			err = errors.Errorf("\"try\" requires at least one argument")
+			break
+		}
+		// The call has the type of its alternatives if they all agree.
+		exprType = args[0].Type()
+		for _, arg := range args[1:] {
+			if arg.Type() != exprType {
+				exprType = TypeUnknown
+				break
+			}
+		}
+	case "values":
+		exprType = TypeUnknown.ListOf()
 	case "zipmap":
 		exprType = TypeMap
 	default:
@@ -189,7 +221,7 @@ func (b *propertyBinder) bindLiteral(n *ast.LiteralNode) (BoundExpr, error) {
 	case ast.TypeBool:
 		exprType = TypeBool
 	case ast.TypeInt:
-		exprType, value = TypeNumber, float64(value.(int))
+		exprType, value = TypeNumber, int64(value.(int))
 	case ast.TypeFloat:
 		exprType = TypeNumber
 	case ast.TypeString:
@@ -208,6 +240,33 @@ func (b *propertyBinder) bindOutput(n *ast.Output) (BoundExpr, error) {
 		return nil, err
 	}
 
+	// Flatten nested outputs into their parent and merge adjacent string literals so that e.g. "a${"b${var.foo}c"}d"
+	// binds to the same output as "ab${var.foo}cd".
+	flattened := make([]BoundExpr, 0, len(exprs))
+	var appendExpr func(e BoundExpr)
+	appendExpr = func(e BoundExpr) {
+		if o, ok := e.(*BoundOutput); ok {
+			for _, e := range o.Exprs {
+				appendExpr(e)
+			}
+			return
+		}
+		if lit, ok := e.(*BoundLiteral); ok && lit.ExprType == TypeString && len(flattened) > 0 {
+			if prev, ok := flattened[len(flattened)-1].(*BoundLiteral); ok && prev.ExprType == TypeString {
+				flattened[len(flattened)-1] = &BoundLiteral{
+					ExprType: TypeString,
+					Value:    prev.Value.(string) + lit.Value.(string),
+				}
+				return
+			}
+		}
+		flattened = append(flattened, e)
+	}
+	for _, e := range exprs {
+		appendExpr(e)
+	}
+	exprs = flattened
+
 	// Project a single-element output to the element itself.
 	if len(exprs) == 1 {
 		return exprs[0], nil
@@ -226,6 +285,14 @@ func (b *propertyBinder) bindVariableAccess(n *ast.VariableAccess) (BoundExpr, e
 		return nil, err
 	}
 
+	// Within a provisioner, `self.<field>` refers to a field of the resource that owns the provisioner.
+	if v, ok := tfVar.(*config.SelfVariable); ok && b.self != nil {
+		tfVar, err = config.NewResourceVariable(b.self.Type + "." + b.self.Name + "." + v.Field)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	elements, sch, exprType, ilNode := []string(nil), Schemas{}, TypeUnknown, Node(nil)
 	switch v := tfVar.(type) {
 	case *config.CountVariable:
@@ -239,6 +306,17 @@ func (b *propertyBinder) bindVariableAccess(n *ast.VariableAccess) (BoundExpr, e
 		}
 
 		exprType = TypeNumber
+	case *config.IteratorVariable:
+		// "<iterator>.key" or "<iterator>.value"
+		types, ok := b.iterators[v.Name]
+		if !ok {
+			return nil, errors.Errorf("unknown iterator %v", v.Name)
+		}
+		if v.Field == "key" {
+			exprType = types.key
+		} else {
+			exprType = types.value
+		}
 	case *config.LocalVariable:
 		// "local."
 		l, ok := b.builder.locals[v.Name]
@@ -283,6 +361,20 @@ func (b *propertyBinder) bindVariableAccess(n *ast.VariableAccess) (BoundExpr, e
 		// Split the path elements.
 		elements = strings.Split(v.Field, ".")
 
+		// An access of the form "<iterator>.value.<field>" refers to a field of the value of a dynamic block's
+		// iterator.
+		if _, ok := b.iterators[v.Type]; ok && v.Mode == config.ManagedResourceMode && v.Name == "value" {
+			iteratorVar, err := config.NewIteratorVariable(v.Type + ".value")
+			if err != nil {
+				return nil, err
+			}
+			return &BoundVariableAccess{
+				Elements: strings.Split(v.FullKey(), ".")[2:],
+				ExprType: TypeUnknown,
+				TFVar:    iteratorVar,
+			}, nil
+		}
+
 		// Look up the resource.
 		r, ok := b.builder.resources[v.ResourceId()]
 		if !ok {
@@ -297,9 +389,12 @@ func (b *propertyBinder) bindVariableAccess(n *ast.VariableAccess) (BoundExpr, e
 		}
 		ilNode = r
 
-		// Ensure that the resource has a provider.
-		if err := b.builder.ensureBound(r); err != nil {
-			return nil, err
+		// Ensure that the resource has a provider. A resource referenced via `self` is already being bound and has its
+		// provider.
+		if r != b.self {
+			if err := b.builder.ensureBound(r); err != nil {
+				return nil, err
+			}
 		}
 
 		// Fetch the resource's schema info.
@@ -374,6 +469,9 @@ func (b *propertyBinder) bindVariableAccess(n *ast.VariableAccess) (BoundExpr, e
 		if vn.DefaultValue != nil {
 			exprType = vn.DefaultValue.Type()
 		}
+		if vn.Sensitive {
+			exprType = exprType.OutputOf()
+		}
 	default:
 		return nil, errors.Errorf("unexpected variable type %T", v)
 	}
@@ -22,6 +22,7 @@ import (
 	"github.com/pkg/errors"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/util/contract"
 
+	"github.com/pulumi/pulumi-terraform-bridge/v3/pkg/tf2pulumi/internal/config"
 	"github.com/pulumi/pulumi-terraform-bridge/v3/pkg/tfbridge"
 	shim "github.com/pulumi/pulumi-terraform-bridge/v3/pkg/tfshim"
 )
@@ -33,6 +34,17 @@ import (
 type propertyBinder struct {
 	builder       *builder
 	hasCountIndex bool
+
+	// iterators maps the names of the in-scope dynamic block iterators to the types of their keys and values.
+	iterators map[string]iteratorTypes
+
+	// self is the resource that `self` refers to, if any. This is only set when binding provisioners.
+	self *ResourceNode
+}
+
+// iteratorTypes records the types of the key and value of a dynamic block iterator.
+type iteratorTypes struct {
+	key, value Type
 }
 
 // bindListProperty binds a list property according to the given schema information. If the schema information
@@ -102,6 +114,13 @@ func (b *propertyBinder) bindMapProperty(path string, m reflect.Value, sch Schem
 	// Bind each property in turn according to its appropriate schema.
 	elements := make(map[string]BoundNode)
 	for _, k := range m.MapKeys() {
+		if k.String() == "dynamic" {
+			if err := b.bindDynamicBlocks(path, m.MapIndex(k), sch, elements); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
 		bv, err := b.bindProperty(fmt.Sprintf("%v.%v", path, k), m.MapIndex(k), sch.PropertySchemas(k.String()))
 		if err != nil {
 			return nil, err
@@ -115,6 +134,135 @@ func (b *propertyBinder) bindMapProperty(path string, m reflect.Value, sch Schem
 	return &BoundMapProperty{Schemas: sch, Elements: elements}, nil
 }
 
+// bindDynamicBlocks binds the dynamic blocks declared by a property map. Each dynamic block is bound as the list-typed
+// property named by its label.
+func (b *propertyBinder) bindDynamicBlocks(path string, d reflect.Value, sch Schemas,
+	elements map[string]BoundNode) error {
+
+	if d.Kind() == reflect.Interface {
+		d = d.Elem()
+	}
+	if d.Kind() != reflect.Slice {
+		return errors.Errorf("%v.dynamic: expected a list of dynamic blocks, got %v", path, d.Kind())
+	}
+
+	for i := 0; i < d.Len(); i++ {
+		blocks := d.Index(i)
+		if blocks.Kind() == reflect.Interface {
+			blocks = blocks.Elem()
+		}
+		if blocks.Kind() != reflect.Map {
+			return errors.Errorf("%v.dynamic: expected a dynamic block, got %v", path, blocks.Kind())
+		}
+
+		for _, label := range blocks.MapKeys() {
+			name := label.String()
+			if _, ok := elements[name]; ok {
+				return errors.Errorf("%v.%v: NYI: mixing static and dynamic blocks", path, name)
+			}
+
+			block, err := b.bindDynamicBlock(fmt.Sprintf("%v.dynamic.%v", path, name), name, blocks.MapIndex(label),
+				sch.PropertySchemas(name))
+			if err != nil {
+				return err
+			}
+			elements[name] = block
+		}
+	}
+	return nil
+}
+
+// bindDynamicBlock binds a single dynamic block. The block's for_each collection is bound as-is; its content is bound
+// according to the element schema of the property it produces, with the block's iterator in scope.
+func (b *propertyBinder) bindDynamicBlock(path, name string, v reflect.Value, sch Schemas) (BoundNode, error) {
+	body, ok := singleBlock(v)
+	if !ok {
+		return nil, errors.Errorf("%v: expected a single block", path)
+	}
+
+	forEachValue := body.MapIndex(reflect.ValueOf("for_each"))
+	if !forEachValue.IsValid() {
+		return nil, errors.Errorf("%v: missing for_each", path)
+	}
+	forEachNode, err := b.bindProperty(path+".for_each", forEachValue, Schemas{})
+	if err != nil {
+		return nil, err
+	}
+	forEach, ok := forEachNode.(BoundExpr)
+	if !ok {
+		forEach = &BoundPropertyValue{NodeType: forEachNode.Type(), Value: forEachNode}
+	}
+
+	iterator := name
+	if iteratorValue := body.MapIndex(reflect.ValueOf("iterator")); iteratorValue.IsValid() {
+		if iteratorValue.Kind() == reflect.Interface {
+			iteratorValue = iteratorValue.Elem()
+		}
+		if iteratorValue.Kind() != reflect.String {
+			return nil, errors.Errorf("%v.iterator: expected a string, got %v", path, iteratorValue.Kind())
+		}
+		iterator = iteratorValue.String()
+	}
+
+	content, ok := singleBlock(body.MapIndex(reflect.ValueOf("content")))
+	if !ok {
+		return nil, errors.Errorf("%v: expected a single content block", path)
+	}
+
+	// Lists are iterated by index; maps and values of unknown type are iterated by key.
+	types := iteratorTypes{key: TypeString, value: TypeUnknown}
+	if t := forEach.Type(); t.IsList() {
+		types = iteratorTypes{key: TypeNumber, value: t.ElementType()}
+	}
+
+	outer := b.iterators
+	b.iterators = make(map[string]iteratorTypes)
+	for k, v := range outer {
+		b.iterators[k] = v
+	}
+	b.iterators[iterator] = types
+	defer func() { b.iterators = outer }()
+
+	boundContent, err := b.bindProperty(path+".content", content, sch.ElemSchemas())
+	if err != nil {
+		return nil, err
+	}
+	return NewDynamicCall(forEach, iterator, boundContent), nil
+}
+
+// singleBlock returns the sole element of a list of blocks, as decoded from HCL.
+func singleBlock(v reflect.Value) (reflect.Value, bool) {
+	if !v.IsValid() {
+		return reflect.Value{}, false
+	}
+	if v.Kind() == reflect.Interface {
+		v = v.Elem()
+	}
+	if v.Kind() == reflect.Slice {
+		if v.Len() != 1 {
+			return reflect.Value{}, false
+		}
+		v = v.Index(0)
+		if v.Kind() == reflect.Interface {
+			v = v.Elem()
+		}
+	}
+	return v, v.Kind() == reflect.Map
+}
+
+// attachErrorSource records the original Terraform source of a string property on any errors bound from that
+// property so that code generators can surface the unconverted text.
+func attachErrorSource(n BoundNode, source string) BoundNode {
+	n, err := VisitBoundNode(n, IdentityVisitor, func(n BoundNode) (BoundNode, error) {
+		if e, ok := n.(*BoundError); ok && e.Source == "" {
+			e.Source = source
+		}
+		return n, nil
+	})
+	contract.Assert(err == nil)
+	return n
+}
+
 // bindProperty binds a single Terraform property. This property must be of kind bool, int, float64, string, slice, or
 // map. If this property is a map, its keys must be of kind string.
 func (b *propertyBinder) bindProperty(path string, p reflect.Value, sch Schemas) (BoundNode, error) {
@@ -126,12 +274,12 @@ func (b *propertyBinder) bindProperty(path string, p reflect.Value, sch Schemas)
 	case reflect.Bool:
 		return &BoundLiteral{ExprType: TypeBool, Value: p.Bool()}, nil
 	case reflect.Int:
-		return &BoundLiteral{ExprType: TypeNumber, Value: float64(p.Int())}, nil
+		return &BoundLiteral{ExprType: TypeNumber, Value: p.Int()}, nil
 	case reflect.Float64:
 		return &BoundLiteral{ExprType: TypeNumber, Value: p.Float()}, nil
 	case reflect.String:
 		// As in Terraform, parse all strings as HIL, then bind the result.
-		rootNode, err := hil.Parse(p.String())
+		rootNode, err := hil.Parse(config.NormalizeSplats(p.String()))
 		if err != nil {
 			return nil, errors.Errorf("%v: could not parse HIL (%v)", path, err)
 		}
@@ -140,7 +288,7 @@ func (b *propertyBinder) bindProperty(path string, p reflect.Value, sch Schemas)
 		if err != nil {
 			return nil, errors.Errorf("%v: %v", path, err)
 		}
-		return n, nil
+		return attachErrorSource(n, p.String()), nil
 	case reflect.Slice:
 		return b.bindListProperty(path, p, sch)
 	case reflect.Map:
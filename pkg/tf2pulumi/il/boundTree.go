@@ -17,6 +17,8 @@ package il
 import (
 	"fmt"
 	"io"
+	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/hashicorp/hil/ast"
@@ -149,6 +151,10 @@ type BoundNode interface {
 
 	Type() Type
 	Comments() *Comments
+	// Dump writes a readable rendering of the node and its children, including their types, to the given writer.
+	Dump(w io.Writer)
+	// String returns a readable rendering of the node and its children, including their types.
+	String() string
 
 	dump(d *dumper)
 	isNode()
@@ -199,6 +205,16 @@ func (n *BoundArithmetic) dump(d *dumper) {
 	d.dump("\n", d.indent, ")")
 }
 
+// Dump writes a readable rendering of this node to the given writer.
+func (n *BoundArithmetic) Dump(w io.Writer) {
+	DumpBoundNode(w, n)
+}
+
+// String returns a readable rendering of this node.
+func (n *BoundArithmetic) String() string {
+	return dumpString(n)
+}
+
 func (n *BoundArithmetic) isNode() {}
 func (n *BoundArithmetic) isExpr() {}
 
@@ -239,6 +255,16 @@ func (n *BoundCall) dump(d *dumper) {
 	d.dump("\n", d.indent, ")")
 }
 
+// Dump writes a readable rendering of this node to the given writer.
+func (n *BoundCall) Dump(w io.Writer) {
+	DumpBoundNode(w, n)
+}
+
+// String returns a readable rendering of this node.
+func (n *BoundCall) String() string {
+	return dumpString(n)
+}
+
 func (n *BoundCall) isNode() {}
 func (n *BoundCall) isExpr() {}
 
@@ -281,6 +307,16 @@ func (n *BoundConditional) dump(d *dumper) {
 	d.dump("\n", d.indent, ")")
 }
 
+// Dump writes a readable rendering of this node to the given writer.
+func (n *BoundConditional) Dump(w io.Writer) {
+	DumpBoundNode(w, n)
+}
+
+// String returns a readable rendering of this node.
+func (n *BoundConditional) String() string {
+	return dumpString(n)
+}
+
 func (n *BoundConditional) isNode() {}
 func (n *BoundConditional) isExpr() {}
 
@@ -320,6 +356,16 @@ func (n *BoundIndex) dump(d *dumper) {
 	d.dump("\n", d.indent, ")")
 }
 
+// Dump writes a readable rendering of this node to the given writer.
+func (n *BoundIndex) Dump(w io.Writer) {
+	DumpBoundNode(w, n)
+}
+
+// String returns a readable rendering of this node.
+func (n *BoundIndex) String() string {
+	return dumpString(n)
+}
+
 func (n *BoundIndex) isNode() {}
 func (n *BoundIndex) isExpr() {}
 
@@ -329,8 +375,8 @@ type BoundLiteral struct {
 	ExprType Type
 	// Comments is the set of comments associated with this node, if any.
 	NodeComments *Comments
-	// Value is the value of the literal expression. This may be a bool, string, float64, or in the case of the
-	// argument to the __applyArg intrinsic, an int.
+	// Value is the value of the literal expression. This may be a bool, string, int64 (for integral numbers), float64,
+	// or in the case of the argument to the __applyArg intrinsic, an int.
 	Value interface{}
 }
 
@@ -344,6 +390,36 @@ func (n *BoundLiteral) Comments() *Comments {
 	return n.NodeComments
 }
 
+// FormatNumber returns the source text for the value of a numeric literal. Integers are formatted exactly. Floats are
+// formatted using the fewest digits that round-trip exactly; very large or very small floats are formatted in
+// scientific notation if doing so is shorter (e.g. 1e10 rather than 10000000000).
+func FormatNumber(v interface{}) string {
+	switch v := v.(type) {
+	case int:
+		return strconv.Itoa(v)
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case float64:
+		fixed := strconv.FormatFloat(v, 'f', -1, 64)
+
+		// Split the exponent from the mantissa so that we can normalize it, e.g. "1e+10" -> "1e10".
+		sci := strconv.FormatFloat(v, 'e', -1, 64)
+		e := strings.IndexByte(sci, 'e')
+		exp, err := strconv.Atoi(sci[e+1:])
+		contract.AssertNoError(err)
+		if exp > -6 && exp < 6 {
+			return fixed
+		}
+		if sci = fmt.Sprintf("%se%d", sci[:e], exp); len(sci) < len(fixed) {
+			return sci
+		}
+		return fixed
+	default:
+		contract.Failf("unexpected numeric literal value %v (%T)", v, v)
+		return ""
+	}
+}
+
 // setComments attaches the given comments to this node.
 func (n *BoundLiteral) setComments(c *Comments) {
 	n.NodeComments = c
@@ -358,6 +434,16 @@ func (n *BoundLiteral) dump(d *dumper) {
 	}
 }
 
+// Dump writes a readable rendering of this node to the given writer.
+func (n *BoundLiteral) Dump(w io.Writer) {
+	DumpBoundNode(w, n)
+}
+
+// String returns a readable rendering of this node.
+func (n *BoundLiteral) String() string {
+	return dumpString(n)
+}
+
 func (n *BoundLiteral) isNode() {}
 func (n *BoundLiteral) isExpr() {}
 
@@ -394,6 +480,16 @@ func (n *BoundOutput) dump(d *dumper) {
 	d.dump("\n", d.indent, ")")
 }
 
+// Dump writes a readable rendering of this node to the given writer.
+func (n *BoundOutput) Dump(w io.Writer) {
+	DumpBoundNode(w, n)
+}
+
+// String returns a readable rendering of this node.
+func (n *BoundOutput) String() string {
+	return dumpString(n)
+}
+
 func (n *BoundOutput) isNode() {}
 func (n *BoundOutput) isExpr() {}
 
@@ -432,6 +528,16 @@ func (n *BoundVariableAccess) dump(d *dumper) {
 	d.dump(fmt.Sprintf("(%s %s %T)", strings.Join(n.Elements, "."), n.Type(), n.TFVar))
 }
 
+// Dump writes a readable rendering of this node to the given writer.
+func (n *BoundVariableAccess) Dump(w io.Writer) {
+	DumpBoundNode(w, n)
+}
+
+// String returns a readable rendering of this node.
+func (n *BoundVariableAccess) String() string {
+	return dumpString(n)
+}
+
 func (n *BoundVariableAccess) isNode() {}
 func (n *BoundVariableAccess) isExpr() {}
 
@@ -478,6 +584,16 @@ func (n *BoundListProperty) dump(d *dumper) {
 	}
 }
 
+// Dump writes a readable rendering of this node to the given writer.
+func (n *BoundListProperty) Dump(w io.Writer) {
+	DumpBoundNode(w, n)
+}
+
+// String returns a readable rendering of this node.
+func (n *BoundListProperty) String() string {
+	return dumpString(n)
+}
+
 func (n *BoundListProperty) isNode() {}
 
 // BoundMapProperty is the bound form of an HCL map property. (e.g. `{ foo = bar ]`).
@@ -510,15 +626,31 @@ func (n *BoundMapProperty) dump(d *dumper) {
 	if len(n.Elements) == 0 {
 		d.dump(")")
 	} else {
+		keys := make([]string, 0, len(n.Elements))
+		for k := range n.Elements {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
 		d.indented(func() {
-			for k, e := range n.Elements {
-				d.dump("\n", d.indent, k, ": ", e)
+			for _, k := range keys {
+				d.dump("\n", d.indent, k, ": ", n.Elements[k])
 			}
 		})
 		d.dump("\n", d.indent, ")")
 	}
 }
 
+// Dump writes a readable rendering of this node to the given writer.
+func (n *BoundMapProperty) Dump(w io.Writer) {
+	DumpBoundNode(w, n)
+}
+
+// String returns a readable rendering of this node.
+func (n *BoundMapProperty) String() string {
+	return dumpString(n)
+}
+
 func (n *BoundMapProperty) isNode() {}
 
 // BoundError represents a binding error. This is used to preserve bound values in the case
@@ -532,6 +664,8 @@ type BoundError struct {
 	Value BoundNode
 	// The binding error
 	Error error
+	// The original Terraform source of the property that contains this error, if known.
+	Source string
 }
 
 // Type returns the type of the variable access expression.
@@ -560,6 +694,16 @@ func (n *BoundError) dump(d *dumper) {
 	d.dump(d.indent, fmt.Sprintf("%q)", n.Error.Error()))
 }
 
+// Dump writes a readable rendering of this node to the given writer.
+func (n *BoundError) Dump(w io.Writer) {
+	DumpBoundNode(w, n)
+}
+
+// String returns a readable rendering of this node.
+func (n *BoundError) String() string {
+	return dumpString(n)
+}
+
 func (n *BoundError) isNode() {}
 func (n *BoundError) isExpr() {}
 
@@ -589,6 +733,16 @@ func (n *BoundPropertyValue) setComments(c *Comments) {
 	n.NodeComments = c
 }
 
+// Dump writes a readable rendering of this node to the given writer.
+func (n *BoundPropertyValue) Dump(w io.Writer) {
+	DumpBoundNode(w, n)
+}
+
+// String returns a readable rendering of this node.
+func (n *BoundPropertyValue) String() string {
+	return dumpString(n)
+}
+
 func (n *BoundPropertyValue) isNode() {}
 func (n *BoundPropertyValue) isExpr() {}
 
@@ -605,3 +759,10 @@ func DumpBoundNode(w io.Writer, e BoundNode) {
 	e.dump(&dumper{w: w})
 	fmt.Fprint(w, "\n")
 }
+
+// dumpString returns the string representation of the given bound node sans its trailing newline.
+func dumpString(e BoundNode) string {
+	var b strings.Builder
+	e.dump(&dumper{w: &b})
+	return b.String()
+}
@@ -0,0 +1,54 @@
+package il
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi-terraform-bridge/v3/pkg/tf2pulumi/internal/config"
+)
+
+func TestDumpBoundNode(t *testing.T) {
+	// This is the structure used by the NodeJS generator's TestLowerToLiteral.
+	prop := &BoundMapProperty{
+		Elements: map[string]BoundNode{
+			"key": &BoundOutput{
+				Exprs: []BoundExpr{
+					&BoundLiteral{
+						ExprType: TypeString,
+						Value:    "module: ",
+					},
+					&BoundVariableAccess{
+						ExprType: TypeString,
+						TFVar:    &config.PathVariable{Type: config.PathValueModule},
+					},
+					&BoundLiteral{
+						ExprType: TypeString,
+						Value:    " root: ",
+					},
+					&BoundVariableAccess{
+						ExprType: TypeString,
+						TFVar:    &config.PathVariable{Type: config.PathValueRoot},
+					},
+				},
+			},
+			"count": &BoundLiteral{ExprType: TypeNumber, Value: 2},
+		},
+	}
+
+	expected := `(map map
+    count: 2
+    key: (output string
+        "module: "
+        ( string *config.PathVariable)
+        " root: "
+        ( string *config.PathVariable)
+    )
+)`
+	assert.Equal(t, expected, prop.String())
+
+	var b bytes.Buffer
+	prop.Dump(&b)
+	assert.Equal(t, expected+"\n", b.String())
+}
@@ -284,3 +284,84 @@ func VisitAllProperties(m *Graph, pre, post BoundNodeVisitor) error {
 	}
 	return nil
 }
+
+// RewriteAllProperties visits all property nodes in the graph using the given pre- and post-order visitors and stores
+// the result of each walk back into the graph, so that the visitors may replace any node, including the root of a
+// property tree. This allows custom transformations to be applied to a graph before code is generated from it. The
+// properties of modules, providers, resources, and provisioners must remain maps: it is an error for a visitor to
+// replace the root of one of these trees with a node of another type.
+func RewriteAllProperties(m *Graph, pre, post BoundNodeVisitor) error {
+	for _, n := range m.Modules {
+		if err := rewriteMapProperty(&n.Properties, pre, post); err != nil {
+			return err
+		}
+	}
+	for _, n := range m.Providers {
+		if err := rewriteMapProperty(&n.Properties, pre, post); err != nil {
+			return err
+		}
+	}
+	for _, n := range m.Resources {
+		if n.Count != nil {
+			count, err := VisitBoundNode(n.Count, pre, post)
+			if err != nil {
+				return err
+			}
+			n.Count = count
+		}
+		if err := rewriteMapProperty(&n.Properties, pre, post); err != nil {
+			return err
+		}
+		if err := rewriteMapProperty(&n.Timeouts, pre, post); err != nil {
+			return err
+		}
+		for _, p := range n.Provisioners {
+			if err := rewriteMapProperty(&p.Properties, pre, post); err != nil {
+				return err
+			}
+			if err := rewriteMapProperty(&p.Connection, pre, post); err != nil {
+				return err
+			}
+		}
+	}
+	for _, n := range m.Outputs {
+		value, err := VisitBoundNode(n.Value, pre, post)
+		if err != nil {
+			return err
+		}
+		n.Value = value
+	}
+	for _, n := range m.Locals {
+		value, err := VisitBoundNode(n.Value, pre, post)
+		if err != nil {
+			return err
+		}
+		n.Value = value
+	}
+	for _, n := range m.Variables {
+		value, err := VisitBoundNode(n.DefaultValue, pre, post)
+		if err != nil {
+			return err
+		}
+		n.DefaultValue = value
+	}
+	return nil
+}
+
+// rewriteMapProperty visits the given property map, if any, and replaces it with the result of the walk.
+func rewriteMapProperty(p **BoundMapProperty, pre, post BoundNodeVisitor) error {
+	if *p == nil {
+		return nil
+	}
+
+	n, err := VisitBoundNode(*p, pre, post)
+	if err != nil {
+		return err
+	}
+	m, ok := n.(*BoundMapProperty)
+	if !ok {
+		return errors.Errorf("a property map may only be replaced with another property map, not %T", n)
+	}
+	*p = m
+	return nil
+}
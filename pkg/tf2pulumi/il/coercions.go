@@ -25,7 +25,7 @@ func coerceLiteral(lit *BoundLiteral, from, to Type) (*BoundLiteral, bool) {
 	case TypeBool:
 		str = strconv.FormatBool(lit.Value.(bool))
 	case TypeNumber:
-		str = strconv.FormatFloat(lit.Value.(float64), 'g', -1, 64)
+		str = FormatNumber(lit.Value)
 	case TypeString:
 		str = lit.Value.(string)
 	default:
@@ -63,6 +63,9 @@ type Graph struct {
 	// Variables maps from variable name to variable node for this module's variables. This map is used to bind a
 	// variable access in an interpolation to the corresponding variable node.
 	Variables map[string]*VariableNode
+	// BackendType is the type of the Terraform state backend configured by the module's `terraform` block, e.g. "s3",
+	// or the empty string if the module does not configure a backend.
+	BackendType string
 }
 
 // A Node represents a single node in a dependency graph. A node is connected to other nodes by dependency edges.
@@ -123,6 +126,9 @@ type ProviderNode struct {
 	PluginName string
 	// Implicit is true if this provider node was generated by an implicit provider block.
 	Implicit bool
+	// VersionConstraint is the version constraint for the Terraform provider, if any. Constraints declared in the
+	// module's required_providers block take precedence over the provider block's version attribute.
+	VersionConstraint string
 }
 
 // A ResourceNode is the analyzed form of a resource or data source instatiation in a Terraform configuration. In
@@ -157,6 +163,26 @@ type ResourceNode struct {
 	Timeouts *BoundMapProperty
 	// IgnoreChanges is the bound list of properties with ignored changes, if any.
 	IgnoreChanges []string
+	// Protect is true if the resource's lifecycle prevents it from being destroyed.
+	Protect bool
+	// Provisioners is the bound form of the resource's provisioners, if any.
+	Provisioners []*Provisioner
+	// Aliases is the list of names under which the resource was previously known, as recorded by the module's `moved`
+	// blocks.
+	Aliases []string
+}
+
+// A Provisioner is the bound form of a provisioner attached to a resource. References to `self` within the
+// provisioner's configuration are bound as references to the resource itself.
+type Provisioner struct {
+	// Config is the provisioner's raw Terraform configuration.
+	Config *config.Provisioner
+	// Type is the type of the provisioner, e.g. "local-exec".
+	Type string
+	// Properties is the bound form of the provisioner's configuration properties.
+	Properties *BoundMapProperty
+	// Connection is the bound form of the provisioner's connection info, if any.
+	Connection *BoundMapProperty
 }
 
 // An OutputNode is the analyzed form of an output in a Terraform configuration. An OutputNode may never be referenced
@@ -174,6 +200,8 @@ type OutputNode struct {
 	ExplicitDeps []Node
 	// Name is the name of this output.
 	Name string
+	// Sensitive is true if the output is marked as sensitive.
+	Sensitive bool
 	// Value is the bound from of the output's value.
 	Value BoundNode
 }
@@ -206,6 +234,11 @@ type VariableNode struct {
 	Name string
 	// DefaultValue is the bound form of the variable's default value (if any).
 	DefaultValue BoundNode
+	// Type is the parsed form of the variable's type constraint (if any).
+	Type *TypeConstraint
+	// Sensitive is true if the variable is marked as sensitive. Accesses of sensitive variables are output-typed so
+	// that the values derived from them remain secret.
+	Sensitive bool
 }
 
 // nodeSet is a set of Node values.
@@ -279,6 +312,18 @@ func (r *ResourceNode) Dependencies() []Node {
 	return r.Deps
 }
 
+// IsRemoteState returns true if this resource is a `terraform_remote_state` data source, which reads the outputs of
+// another Terraform configuration.
+func (r *ResourceNode) IsRemoteState() bool {
+	return r.IsDataSource && r.Type == "terraform_remote_state"
+}
+
+// IsNullResource returns true if this resource is a `null_resource`, which manages no infrastructure and is typically
+// used to run provisioners when its triggers change.
+func (r *ResourceNode) IsNullResource() bool {
+	return !r.IsDataSource && r.Type == "null_resource"
+}
+
 // Schemas returns the Terraform and Pulumi schemas for this resource. These schemas can are principally used to
 // calculate the types and names of a resource's properties during binding and code generation.
 func (r *ResourceNode) Schemas() Schemas {
@@ -453,6 +498,12 @@ type builder struct {
 	allowMissingProviders bool
 	allowMissingVariables bool
 
+	// requireComments is true if comments that cannot be associated with the graph are errors rather than merely
+	// logged. See BuildOptions.RequireComments.
+	requireComments bool
+	// commentErrors accumulates the comments that could not be associated with the graph.
+	commentErrors error
+
 	providerInfo ProviderInfoSource
 	modules      map[string]*ModuleNode
 	providers    map[string]*ProviderNode
@@ -461,6 +512,12 @@ type builder struct {
 	locals       map[string]*LocalNode
 	variables    map[string]*VariableNode
 
+	// requiredProviders maps provider names to the version constraints declared by the module's required_providers
+	// block.
+	requiredProviders map[string]string
+	// backendType is the type of the state backend declared by the module's terraform block, if any.
+	backendType string
+
 	binding map[Node]bool
 	bound   map[Node]bool
 }
@@ -477,14 +534,16 @@ func newBuilder(opts *BuildOptions) *builder {
 	}
 
 	var logger *log.Logger
+	requireComments := false
 	if opts != nil {
-		logger = opts.Logger
+		logger, requireComments = opts.Logger, opts.RequireComments
 	}
 
 	return &builder{
 		logger:                logger,
 		allowMissingProviders: allowMissingProviders,
 		allowMissingVariables: allowMissingVariables,
+		requireComments:       requireComments,
 
 		providerInfo: providerInfo,
 		modules:      make(map[string]*ModuleNode),
@@ -526,7 +585,12 @@ func (b *builder) bindProperty(
 		builder:       b,
 		hasCountIndex: hasCountIndex,
 	}
-	prop, err := binder.bindProperty(path, reflect.ValueOf(v), sch)
+	return binder.bindPropertyValue(path, v, sch)
+}
+
+// bindPropertyValue binds a single property value with the given binder and collects its dependencies.
+func (b *propertyBinder) bindPropertyValue(path string, v interface{}, sch Schemas) (BoundNode, nodeSet, error) {
+	prop, err := b.bindProperty(path, reflect.ValueOf(v), sch)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -562,6 +626,35 @@ func (b *builder) bindProperties(name string, raw *config.RawConfig, sch Schemas
 	return v.(*BoundMapProperty), deps, nil
 }
 
+// bindProvisioner binds the configuration and connection info of one of the given resource's provisioners and
+// collects their dependencies. References to `self` are bound as references to the resource.
+func (b *builder) bindProvisioner(r *ResourceNode, p *config.Provisioner) (*Provisioner, nodeSet, error) {
+	binder := &propertyBinder{
+		builder:       b,
+		hasCountIndex: r.Count != nil,
+		self:          r,
+	}
+
+	path := fmt.Sprintf("%s.%s.provisioner.%s", r.Type, r.Name, p.Type)
+	props, deps, err := binder.bindPropertyValue(path, p.RawConfig.Raw, Schemas{})
+	if err != nil {
+		return nil, nil, err
+	}
+	provisioner := &Provisioner{Config: p, Type: p.Type, Properties: props.(*BoundMapProperty)}
+
+	if p.ConnInfo != nil && len(p.ConnInfo.Raw) != 0 {
+		conn, connDeps, err := binder.bindPropertyValue(path+".connection", p.ConnInfo.Raw, Schemas{})
+		if err != nil {
+			return nil, nil, err
+		}
+		for k := range connDeps {
+			deps.add(k)
+		}
+		provisioner.Connection = conn.(*BoundMapProperty)
+	}
+	return provisioner, deps, nil
+}
+
 // buildDeps calculates the union of a node's implicit and explicit dependencies. It returns this union as a list of
 // Nodes as well as the list of the node's explicit dependencies. This function will fail if a node referenced in the
 // list of explicit dependencies is not present in the graph.
@@ -638,6 +731,11 @@ func (b *builder) buildProvider(p *ProviderNode) error {
 	}
 	p.Info, p.PluginName = info, pluginName
 
+	p.VersionConstraint = b.requiredProviders[p.Name]
+	if p.VersionConstraint == "" {
+		p.VersionConstraint = p.Config.Version
+	}
+
 	props, deps, err := b.bindProperties(p.Name, p.Config.RawConfig, Schemas{}, false)
 	if err != nil {
 		return err
@@ -695,8 +793,8 @@ func buildIgnoreChanges(tfIgnoreChanges []string, schemas Schemas) []string {
 		// Split the ignore_changes entry on '.'
 		elements := strings.Split(entry, ".")
 
-		// If there is one element and that element is "*", ignore all of the top-level properties.
-		if len(elements) == 1 && elements[0] == "*" {
+		// If there is one element and that element is "*" or "all", ignore all of the top-level properties.
+		if len(elements) == 1 && (elements[0] == "*" || elements[0] == "all") {
 			if schemas.TFRes == nil {
 				return []string{"*"}
 			}
@@ -789,7 +887,7 @@ func (b *builder) buildResource(r *ResourceNode) error {
 			if countInt == 1 {
 				count = nil
 			} else {
-				count = &BoundLiteral{ExprType: TypeNumber, Value: float64(countInt)}
+				count = &BoundLiteral{ExprType: TypeNumber, Value: countInt}
 			}
 		}
 	}
@@ -818,8 +916,24 @@ func (b *builder) buildResource(r *ResourceNode) error {
 		r.Timeouts = timeoutsMap
 	}
 
-	// Process ignore_changes.
+	// Process ignore_changes and prevent_destroy.
 	r.IgnoreChanges = buildIgnoreChanges(r.Config.Lifecycle.IgnoreChanges, r.Schemas())
+	r.Protect = r.Config.Lifecycle.PreventDestroy
+
+	// Bind the resource's provisioners. Whatever they reference is a dependency of the resource itself.
+	r.Provisioners = nil
+	for _, p := range r.Config.Provisioners {
+		provisioner, provisionerDeps, err := b.bindProvisioner(r, p)
+		if err != nil {
+			return err
+		}
+		for k := range provisionerDeps {
+			if k != Node(r) {
+				deps.add(k)
+			}
+		}
+		r.Provisioners = append(r.Provisioners, provisioner)
+	}
 
 	// Merge the count dependencies into the overall dependency set and compute the final dependency lists.
 	for k := range countDeps {
@@ -883,6 +997,12 @@ func (b *builder) buildLocal(l *LocalNode) error {
 
 // buildVariable builds a variable's default value (if any). This value must not depend on any other nodes.
 func (b *builder) buildVariable(v *VariableNode) error {
+	typ, err := ParseTypeConstraint(v.Config.DeclaredType)
+	if err != nil {
+		return errors.Wrapf(err, "variable %v", v.Name)
+	}
+	v.Type = typ
+
 	defaultValue, deps, err := b.bindProperty(v.Name+".default", v.Config.Default, Schemas{}, false)
 	if err != nil {
 		return err
@@ -928,11 +1048,19 @@ func (b *builder) ensureBound(n Node) error {
 
 // buildNodes builds the nodes for the given config.
 func (b *builder) buildNodes(conf *config.Config) error {
+	if conf.Terraform != nil {
+		b.requiredProviders = conf.Terraform.RequiredProviders
+		if conf.Terraform.Backend != nil {
+			b.backendType = conf.Terraform.Backend.Type
+		}
+	}
+
 	// Next create our nodes.
 	for _, v := range conf.Variables {
 		b.variables[v.Name] = &VariableNode{
-			Config: v,
-			Name:   v.Name,
+			Config:    v,
+			Name:      v.Name,
+			Sensitive: v.Sensitive,
 		}
 	}
 	for _, p := range conf.ProviderConfigs {
@@ -964,11 +1092,15 @@ func (b *builder) buildNodes(conf *config.Config) error {
 	}
 	for _, o := range conf.Outputs {
 		b.outputs[o.Name] = &OutputNode{
-			Config: o,
-			Name:   o.Name,
+			Config:    o,
+			Name:      o.Name,
+			Sensitive: o.Sensitive,
 		}
 	}
 
+	// Record the previous names of any resources that have moved.
+	b.buildMoved(conf.Moved)
+
 	// Now bind each node's properties and compute any dependency edges.
 	for _, v := range b.variables {
 		if err := b.ensureBound(v); err != nil {
@@ -1004,6 +1136,57 @@ func (b *builder) buildNodes(conf *config.Config) error {
 	return nil
 }
 
+// movedResourceAddress parses the address of a managed resource in a `moved` block, e.g. "aws_instance.web". Addresses
+// of modules, data sources, and resource instances are not supported.
+func movedResourceAddress(addr string) (string, string, bool) {
+	components := strings.Split(addr, ".")
+	if len(components) != 2 || components[0] == "module" || components[0] == "data" ||
+		strings.ContainsAny(addr, "[]") {
+		return "", "", false
+	}
+	return components[0], components[1], true
+}
+
+// buildMoved records the previous names of each managed resource that is the target of one or more `moved` blocks,
+// including the names that it had before a chain of moves. A moved block whose addresses are unsupported or that does
+// not target a resource in this module is ignored.
+func (b *builder) buildMoved(moved []*config.Moved) {
+	previous := make(map[string][]string)
+	for _, m := range moved {
+		fromType, _, fromOK := movedResourceAddress(m.From)
+		toType, _, toOK := movedResourceAddress(m.To)
+		if !fromOK || !toOK || fromType != toType {
+			b.logf("ignoring moved block from %v to %v: only moves between resources of the same type are supported",
+				m.From, m.To)
+			continue
+		}
+		previous[m.To] = append(previous[m.To], m.From)
+	}
+	if len(previous) == 0 {
+		return
+	}
+
+	for _, r := range b.resources {
+		if r.IsDataSource {
+			continue
+		}
+
+		addr := r.Type + "." + r.Name
+		seen := map[string]bool{addr: true}
+		for worklist := previous[addr]; len(worklist) > 0; worklist = worklist[1:] {
+			from := worklist[0]
+			if seen[from] {
+				continue
+			}
+			seen[from] = true
+
+			_, name, _ := movedResourceAddress(from)
+			r.Aliases = append(r.Aliases, name)
+			worklist = append(worklist, previous[from]...)
+		}
+	}
+}
+
 // BuildOptions defines the set of optional parameters to `BuildGraph`.
 type BuildOptions struct {
 	// ProviderInfoSource allows the caller to override the default source for provider schema information, which
@@ -1017,6 +1200,10 @@ type BuildOptions struct {
 	AllowMissingVariables bool
 	// AllowMissingComments allows binding to succeed even if there are errors extracting comments from the source.
 	AllowMissingComments bool
+	// RequireComments requires that every comment in the source that is attached to a construct is preserved in the
+	// graph. Binding fails if comments cannot be extracted from the source or if any comment cannot be associated
+	// with the node or property that it documents. RequireComments takes precedence over AllowMissingComments.
+	RequireComments bool
 }
 
 // BuildGraph analyzes the various entities present in the given module's configuration and constructs the
@@ -1033,21 +1220,22 @@ func BuildGraph(tree *module.Tree, opts *BuildOptions) (*Graph, error) {
 
 	// Attempt to extract comments from the tree's sources and associate them with the appropriate constructs in the
 	// bound graph.
-	if err := b.extractComments(conf); err != nil && !opts.AllowMissingComments {
+	if err := b.extractComments(conf); err != nil && (!opts.AllowMissingComments || opts.RequireComments) {
 		return nil, err
 	}
 
 	// Put the graph together
 	return &Graph{
-		Tree:      tree,
-		Name:      tree.Name(),
-		IsRoot:    len(tree.Path()) == 0,
-		Path:      conf.Dir,
-		Modules:   b.modules,
-		Providers: b.providers,
-		Resources: b.resources,
-		Outputs:   b.outputs,
-		Locals:    b.locals,
-		Variables: b.variables,
+		Tree:        tree,
+		Name:        tree.Name(),
+		IsRoot:      len(tree.Path()) == 0,
+		Path:        conf.Dir,
+		Modules:     b.modules,
+		Providers:   b.providers,
+		Resources:   b.resources,
+		Outputs:     b.outputs,
+		Locals:      b.locals,
+		Variables:   b.variables,
+		BackendType: b.backendType,
 	}, nil
 }
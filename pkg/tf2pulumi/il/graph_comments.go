@@ -19,9 +19,11 @@ import (
 	"regexp"
 	"strings"
 
+	"github.com/hashicorp/go-multierror"
 	"github.com/hashicorp/hcl"
 	"github.com/hashicorp/hcl/hcl/ast"
 	"github.com/hashicorp/hcl/hcl/token"
+	"github.com/pkg/errors"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/util/contract"
 	"github.com/spf13/afero"
 
@@ -78,7 +80,45 @@ func (b *builder) extractComments(c *config.Config) error {
 			return err
 		}
 	}
-	return nil
+	return b.commentErrors
+}
+
+// lostComments reports comments that could not be associated with the graph. If comments are required, the report is
+// recorded as an error; otherwise it is merely logged.
+func (b *builder) lostComments(format string, args ...interface{}) {
+	if !b.requireComments {
+		b.logf(format, args...)
+		return
+	}
+	b.commentErrors = multierror.Append(b.commentErrors, errors.Errorf(format, args...))
+}
+
+// itemHasComments returns true if the given HCL object item or any of its contents has comments.
+func itemHasComments(item *ast.ObjectItem) bool {
+	return item.LeadComment != nil || item.LineComment != nil || nodeHasComments(item.Val)
+}
+
+// nodeHasComments returns true if the given HCL AST node contains any comments.
+func nodeHasComments(node ast.Node) bool {
+	switch node := node.(type) {
+	case *ast.ListType:
+		for _, item := range node.List {
+			if literal, ok := item.(*ast.LiteralType); ok {
+				if literal.LeadComment != nil || literal.LineComment != nil {
+					return true
+				}
+			} else if nodeHasComments(item) {
+				return true
+			}
+		}
+	case *ast.ObjectType:
+		for _, item := range node.List.Items {
+			if itemHasComments(item) {
+				return true
+			}
+		}
+	}
+	return false
 }
 
 // extractFileComments extracts comments from a particular HCL source file.
@@ -101,7 +141,7 @@ func (b *builder) extractFileComments(fs afero.Fs, filePath string) error {
 func (b *builder) extractHCLComments(f *ast.File, path string) {
 	root, ok := f.Node.(*ast.ObjectList)
 	if !ok {
-		b.logf("unexpected type for HCL root node '%T'; skipping file...", f.Node)
+		b.lostComments("unexpected type for HCL root node '%T'; skipping file...", f.Node)
 		return
 	}
 
@@ -124,7 +164,7 @@ func (b *builder) extractHCLComments(f *ast.File, path string) {
 					b.extractLocalComments(ln, path)
 				}
 			} else {
-				b.logf("unexpected locals type '%T'; skipping node...", n.Val)
+				b.lostComments("unexpected locals type '%T'; skipping node...", n.Val)
 			}
 		case "output":
 			b.extractOutputComments(n, path)
@@ -138,6 +178,9 @@ func (b *builder) extractVariableComments(item *ast.ObjectItem, path string) {
 	name := item.Keys[1].Token.Value().(string)
 	v, ok := b.variables[name]
 	if !ok {
+		if itemHasComments(item) {
+			b.lostComments("no variable named '%s' for the comments at %v", name, item.Pos())
+		}
 		return
 	}
 
@@ -168,6 +211,9 @@ func (b *builder) extractProviderComments(item *ast.ObjectItem, path string) {
 	name := item.Keys[1].Token.Value().(string)
 	p, ok := b.providers[(&config.ProviderConfig{Name: name, Alias: alias}).FullName()]
 	if !ok {
+		if itemHasComments(item) {
+			b.lostComments("no provider named '%s' for the comments at %v", name, item.Pos())
+		}
 		return
 	}
 
@@ -182,6 +228,9 @@ func (b *builder) extractModuleComments(item *ast.ObjectItem, path string) {
 	name := item.Keys[1].Token.Value().(string)
 	m, ok := b.modules[name]
 	if !ok {
+		if itemHasComments(item) {
+			b.lostComments("no module named '%s' for the comments at %v", name, item.Pos())
+		}
 		return
 	}
 
@@ -200,6 +249,9 @@ func (b *builder) extractResourceComments(item *ast.ObjectItem, path string, mod
 	}
 	r, ok := b.resources[cfg.Id()]
 	if !ok {
+		if itemHasComments(item) {
+			b.lostComments("no resource named '%s' for the comments at %v", cfg.Id(), item.Pos())
+		}
 		return
 	}
 
@@ -214,6 +266,9 @@ func (b *builder) extractLocalComments(item *ast.ObjectItem, path string) {
 	name := item.Keys[0].Token.Value().(string)
 	l, ok := b.locals[name]
 	if !ok {
+		if itemHasComments(item) {
+			b.lostComments("no local named '%s' for the comments at %v", name, item.Pos())
+		}
 		return
 	}
 
@@ -228,6 +283,9 @@ func (b *builder) extractOutputComments(item *ast.ObjectItem, path string) {
 	name := item.Keys[1].Token.Value().(string)
 	o, ok := b.outputs[name]
 	if !ok {
+		if itemHasComments(item) {
+			b.lostComments("no output named '%s' for the comments at %v", name, item.Pos())
+		}
 		return
 	}
 
@@ -272,6 +330,11 @@ func (b *builder) extractNodeComments(node ast.Node, context BoundNode) {
 		for key, items := range objectItems {
 			element, ok := prop.Elements[key]
 			if !ok {
+				for _, item := range items {
+					if itemHasComments(item) {
+						b.lostComments("no property named '%s' for the comments at %v", key, item.Pos())
+					}
+				}
 				continue
 			}
 
@@ -290,8 +353,8 @@ func (b *builder) extractNodeComments(node ast.Node, context BoundNode) {
 				}
 			} else {
 				// This is a strange case: we have multiple items with the same key in the object, but the
-				// corresponding property is not a list or differs in length. Log it and carry on.
-				b.logf("list mismatch for key '%v': %v, %T", key, len(items), element)
+				// corresponding property is not a list or differs in length. Report it and carry on.
+				b.lostComments("list mismatch for key '%v': %v, %T", key, len(items), element)
 			}
 		}
 	case *ast.LiteralType:
@@ -207,3 +207,50 @@ output "security_group_name" {
 	assertLeading(t, out.Value.Comments(), " Take the value from the default SG.")
 	assertTrailing(t, out.Value.Comments(), " Neat!")
 }
+
+func TestRequireComments(t *testing.T) {
+	const hclText = `
+# The region in which to create resources.
+#
+# This must be a region in which the AWS provider is available.
+variable "aws_region" {
+	default = "us-west-2"
+}
+`
+
+	dir, err := os.MkdirTemp("", "")
+	if err != nil {
+		t.Fatalf("could not create temporary directory: %v", err)
+	}
+	defer func() {
+		contract.IgnoreError(os.RemoveAll(dir))
+	}()
+
+	err = os.WriteFile(path.Join(dir, "main.tf"), []byte(hclText), 0600)
+	if err != nil {
+		t.Fatalf("could not create main.tf: %v", err)
+	}
+
+	conf, err := config.LoadDir(dir)
+	if err != nil {
+		t.Fatalf("could not load config: %v", err)
+	}
+
+	info := test.NewProviderInfoSource("../testdata/providers")
+	b := newBuilder(&BuildOptions{
+		ProviderInfoSource: info,
+		RequireComments:    true,
+	})
+	err = b.buildNodes(conf)
+	assert.NoError(t, err)
+
+	err = b.extractComments(conf)
+	assert.NoError(t, err)
+	assertLeading(t, b.variables["aws_region"].Comments, " The region in which to create resources.", "",
+		" This must be a region in which the AWS provider is available.")
+
+	// Comments that cannot be associated with a node are an error if comments are required.
+	delete(b.variables, "aws_region")
+	err = b.extractComments(conf)
+	assert.Error(t, err)
+}
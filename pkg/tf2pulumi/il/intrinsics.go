@@ -29,6 +29,8 @@ const (
 	IntrinsicAsset = "__asset"
 	// IntrinsicCoerce is the name of the coerce intrinsic.
 	IntrinsicCoerce = "__coerce"
+	// IntrinsicDynamic is the name of the dynamic block intrinsic.
+	IntrinsicDynamic = "__dynamic"
 	// IntrinsicGetStack is the name of the get stack intrinsic.
 	IntrinsicGetStack = "__getStack"
 )
@@ -123,6 +125,28 @@ func ParseCoerceCall(c *BoundCall) (value BoundExpr, toType Type) {
 	return c.Args[0], c.ExprType
 }
 
+// NewDynamicCall creates a call to IntrinsicDynamic, which represents the list of blocks produced by a Terraform
+// dynamic block: one copy of the block's content for each element of the forEach collection, with the named iterator
+// bound to that element.
+func NewDynamicCall(forEach BoundExpr, iterator string, content BoundNode) *BoundCall {
+	return &BoundCall{
+		Func:     IntrinsicDynamic,
+		ExprType: content.Type().ListOf(),
+		Args: []BoundExpr{
+			forEach,
+			&BoundLiteral{ExprType: TypeString, Value: iterator},
+			&BoundPropertyValue{NodeType: content.Type(), Value: content},
+		},
+	}
+}
+
+// ParseDynamicCall extracts the collection, the name of the iterator, and the content from a call to the dynamic block
+// intrinsic.
+func ParseDynamicCall(c *BoundCall) (forEach BoundExpr, iterator string, content BoundNode) {
+	contract.Assert(c.Func == IntrinsicDynamic)
+	return c.Args[0], c.Args[1].(*BoundLiteral).Value.(string), c.Args[2].(*BoundPropertyValue).Value
+}
+
 // NewGetStackCall creates a call to IntrinsicGetStack.
 func NewGetStackCall() *BoundCall {
 	return &BoundCall{Func: IntrinsicGetStack, ExprType: TypeString}
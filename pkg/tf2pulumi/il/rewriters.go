@@ -17,6 +17,7 @@ package il
 import (
 	"sort"
 
+	"github.com/hashicorp/hil/ast"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/util/contract"
 
 	"github.com/pulumi/pulumi-terraform-bridge/v3/pkg/tf2pulumi/internal/config"
@@ -238,7 +239,8 @@ func FilterProperties(r *ResourceNode, filter func(key string, property BoundNod
 }
 
 // MarkPromptDataSources finds all data sources with no Output-typed inputs, marks these data sources as prompt,
-// and retypes all variable accesses rooted in these data sources appropriately.
+// and retypes all variable accesses rooted in these data sources appropriately. Remote state data sources are never
+// prompt, as the outputs of another stack are only available as Outputs.
 func MarkPromptDataSources(g *Graph) map[*ResourceNode]bool {
 	// Mark any datasources with no output-typed inputs as prompt. Do this until we reach a fixed point.
 	promptDataSources := make(map[*ResourceNode]bool)
@@ -248,7 +250,7 @@ func MarkPromptDataSources(g *Graph) map[*ResourceNode]bool {
 
 		// First, check all data sources for output-typed inputs.
 		for _, r := range g.Resources {
-			if r.IsDataSource {
+			if r.IsDataSource && !r.IsRemoteState() {
 				containsOutputs := false
 				_, err := VisitBoundNode(r.Properties, IdentityVisitor, func(n BoundNode) (BoundNode, error) {
 					containsOutputs = containsOutputs || n.Type().IsOutput()
@@ -393,3 +395,90 @@ func SimplifyBooleanExpressions(expr BoundExpr) BoundExpr {
 		return expr
 	}
 }
+
+// BindCountIndex replaces each reference to `count.index` in the given node with the literal index and folds any
+// integer arithmetic whose operands thereby become literals, e.g. `count.index % 2` becomes `0` for the index 0. Integer
+// literals that are interpolated into strings are folded into the surrounding text. This is used to generate the
+// properties of resources that are known to have a single instance.
+func BindCountIndex(n BoundNode, index int64) (BoundNode, error) {
+	return VisitBoundNode(n, IdentityVisitor, func(n BoundNode) (BoundNode, error) {
+		switch n := n.(type) {
+		case *BoundVariableAccess:
+			if v, ok := n.TFVar.(*config.CountVariable); ok && v.Type == config.CountValueIndex {
+				return &BoundLiteral{ExprType: TypeNumber, Value: index, NodeComments: n.NodeComments}, nil
+			}
+		case *BoundArithmetic:
+			if lit, ok := foldIntegerArithmetic(n); ok {
+				return lit, nil
+			}
+		case *BoundOutput:
+			return foldIntegerInterpolations(n), nil
+		}
+		return n, nil
+	})
+}
+
+// foldIntegerInterpolations folds the integer literals that are interpolated into the given output into the
+// surrounding text. If the output is thereby reduced to a single string literal, the literal is returned.
+func foldIntegerInterpolations(n *BoundOutput) BoundExpr {
+	exprs := make([]BoundExpr, 0, len(n.Exprs))
+	for _, e := range n.Exprs {
+		if lit, ok := e.(*BoundLiteral); ok {
+			if v, ok := lit.Value.(int64); ok {
+				e = &BoundLiteral{ExprType: TypeString, Value: FormatNumber(v)}
+			}
+		}
+
+		if lit, ok := e.(*BoundLiteral); ok && lit.ExprType == TypeString && len(exprs) > 0 {
+			if prev, ok := exprs[len(exprs)-1].(*BoundLiteral); ok && prev.ExprType == TypeString {
+				exprs[len(exprs)-1] = &BoundLiteral{ExprType: TypeString, Value: prev.Value.(string) + lit.Value.(string)}
+				continue
+			}
+		}
+		exprs = append(exprs, e)
+	}
+
+	if len(exprs) == 1 {
+		if lit, ok := exprs[0].(*BoundLiteral); ok && lit.ExprType == TypeString {
+			return lit
+		}
+	}
+	return &BoundOutput{NodeComments: n.NodeComments, Exprs: exprs}
+}
+
+// foldIntegerArithmetic evaluates an addition, subtraction, multiplication, or remainder whose operands are all integer
+// literals. Division is not folded, as its result may not be an integer.
+func foldIntegerArithmetic(n *BoundArithmetic) (*BoundLiteral, bool) {
+	var result int64
+	for i, e := range n.Exprs {
+		lit, ok := e.(*BoundLiteral)
+		if !ok {
+			return nil, false
+		}
+		v, ok := lit.Value.(int64)
+		if !ok {
+			return nil, false
+		}
+
+		if i == 0 {
+			result = v
+			continue
+		}
+		switch n.Op {
+		case ast.ArithmeticOpAdd:
+			result += v
+		case ast.ArithmeticOpSub:
+			result -= v
+		case ast.ArithmeticOpMul:
+			result *= v
+		case ast.ArithmeticOpMod:
+			if v == 0 {
+				return nil, false
+			}
+			result %= v
+		default:
+			return nil, false
+		}
+	}
+	return &BoundLiteral{ExprType: TypeNumber, Value: result, NodeComments: n.NodeComments}, true
+}
@@ -32,8 +32,8 @@ type Schemas struct {
 	Pulumi *tfbridge.SchemaInfo
 }
 
-// PropertySchemas returns the Schemas for the child property with the given name. If the name is an integer, this
-// function returns the value of a call to ElemSchemas.
+// PropertySchemas returns the Schemas for the child property with the given name. If the name is an integer or the
+// property is a map of primitive values, this function returns the value of a call to ElemSchemas.
 func (s Schemas) PropertySchemas(key string) Schemas {
 	var propSch Schemas
 
@@ -41,6 +41,12 @@ func (s Schemas) PropertySchemas(key string) Schemas {
 		return s.ElemSchemas()
 	}
 
+	if s.TFRes == nil && s.TF != nil && s.TF.Type() == shim.TypeMap {
+		if _, ok := s.TF.Elem().(shim.Schema); ok {
+			return s.ElemSchemas()
+		}
+	}
+
 	if s.TFRes != nil && s.TFRes.Schema() != nil {
 		propSch.TF = s.TFRes.Schema().Get(key)
 	}
@@ -0,0 +1,293 @@
+// Copyright 2016-2022, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package il
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/pkg/errors"
+)
+
+// TypeConstraintKind is the kind of a Terraform variable's type constraint.
+type TypeConstraintKind int
+
+const (
+	// TypeConstraintAny accepts values of any type.
+	TypeConstraintAny TypeConstraintKind = iota
+	// TypeConstraintString accepts string values.
+	TypeConstraintString
+	// TypeConstraintNumber accepts number values.
+	TypeConstraintNumber
+	// TypeConstraintBool accepts boolean values.
+	TypeConstraintBool
+	// TypeConstraintList accepts lists of values of the constraint's element type.
+	TypeConstraintList
+	// TypeConstraintSet accepts sets of values of the constraint's element type.
+	TypeConstraintSet
+	// TypeConstraintMap accepts maps from strings to values of the constraint's element type.
+	TypeConstraintMap
+	// TypeConstraintObject accepts objects with the constraint's attributes.
+	TypeConstraintObject
+	// TypeConstraintTuple accepts tuples with the constraint's elements.
+	TypeConstraintTuple
+)
+
+// TypeConstraint is the parsed form of the type constraint of a Terraform variable, e.g. `list(string)` or
+// `object({name = string, ports = list(number)})`.
+type TypeConstraint struct {
+	// Kind is the kind of this constraint.
+	Kind TypeConstraintKind
+	// ElementType is the element type of a list, set, or map constraint. Legacy `list` and `map` constraints have an
+	// element type of `any`.
+	ElementType *TypeConstraint
+	// Attributes holds the attribute types of an object constraint.
+	Attributes map[string]*TypeConstraint
+	// Elements holds the element types of a tuple constraint.
+	Elements []*TypeConstraint
+}
+
+// IsPrimitive returns true if this constraint accepts only strings, numbers, or booleans.
+func (t *TypeConstraint) IsPrimitive() bool {
+	switch t.Kind {
+	case TypeConstraintString, TypeConstraintNumber, TypeConstraintBool:
+		return true
+	default:
+		return false
+	}
+}
+
+// SortedAttributeNames returns the names of an object constraint's attributes in lexical order.
+func (t *TypeConstraint) SortedAttributeNames() []string {
+	names := make([]string, 0, len(t.Attributes))
+	for name := range t.Attributes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// String returns the Terraform syntax for this constraint.
+func (t *TypeConstraint) String() string {
+	switch t.Kind {
+	case TypeConstraintString:
+		return "string"
+	case TypeConstraintNumber:
+		return "number"
+	case TypeConstraintBool:
+		return "bool"
+	case TypeConstraintList:
+		return "list(" + t.ElementType.String() + ")"
+	case TypeConstraintSet:
+		return "set(" + t.ElementType.String() + ")"
+	case TypeConstraintMap:
+		return "map(" + t.ElementType.String() + ")"
+	case TypeConstraintObject:
+		attrs := make([]string, 0, len(t.Attributes))
+		for _, name := range t.SortedAttributeNames() {
+			attrs = append(attrs, name+" = "+t.Attributes[name].String())
+		}
+		return "object({" + strings.Join(attrs, ", ") + "})"
+	case TypeConstraintTuple:
+		elements := make([]string, len(t.Elements))
+		for i, e := range t.Elements {
+			elements[i] = e.String()
+		}
+		return "tuple([" + strings.Join(elements, ", ") + "])"
+	default:
+		return "any"
+	}
+}
+
+// ParseTypeConstraint parses the given Terraform type constraint. Both the legacy constraints (`string`, `list`, and
+// `map`) and the parameterized constraints introduced in Terraform 0.12 are accepted. An empty constraint parses to
+// nil.
+func ParseTypeConstraint(s string) (*TypeConstraint, error) {
+	p := &typeConstraintParser{text: s}
+	p.skipSpace()
+	if p.done() {
+		return nil, nil
+	}
+
+	t, err := p.parseType()
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid type constraint %q", s)
+	}
+	if p.skipSpace(); !p.done() {
+		return nil, errors.Errorf("invalid type constraint %q: unexpected %q", s, p.text[p.offset:])
+	}
+	return t, nil
+}
+
+// typeConstraintParser is a simple recursive-descent parser for type constraints.
+type typeConstraintParser struct {
+	text   string
+	offset int
+}
+
+func (p *typeConstraintParser) done() bool {
+	return p.offset >= len(p.text)
+}
+
+func (p *typeConstraintParser) skipSpace() {
+	for !p.done() && unicode.IsSpace(rune(p.text[p.offset])) {
+		p.offset++
+	}
+}
+
+// accept consumes the given character (after any leading whitespace) if it is next in the input.
+func (p *typeConstraintParser) accept(c byte) bool {
+	p.skipSpace()
+	if !p.done() && p.text[p.offset] == c {
+		p.offset++
+		return true
+	}
+	return false
+}
+
+func (p *typeConstraintParser) expect(c byte) error {
+	if !p.accept(c) {
+		if p.done() {
+			return errors.Errorf("expected %q", c)
+		}
+		return errors.Errorf("expected %q at %q", c, p.text[p.offset:])
+	}
+	return nil
+}
+
+// parseName parses an identifier, i.e. a type keyword or an object attribute name.
+func (p *typeConstraintParser) parseName() (string, error) {
+	p.skipSpace()
+	start := p.offset
+	for !p.done() {
+		c := rune(p.text[p.offset])
+		if !unicode.IsLetter(c) && !unicode.IsDigit(c) && c != '_' && c != '-' {
+			break
+		}
+		p.offset++
+	}
+	if start == p.offset {
+		if p.done() {
+			return "", errors.New("expected a name")
+		}
+		return "", errors.Errorf("expected a name at %q", p.text[p.offset:])
+	}
+	return p.text[start:p.offset], nil
+}
+
+func (p *typeConstraintParser) parseType() (*TypeConstraint, error) {
+	keyword, err := p.parseName()
+	if err != nil {
+		return nil, err
+	}
+
+	switch keyword {
+	case "any":
+		return &TypeConstraint{Kind: TypeConstraintAny}, nil
+	case "string":
+		return &TypeConstraint{Kind: TypeConstraintString}, nil
+	case "number":
+		return &TypeConstraint{Kind: TypeConstraintNumber}, nil
+	case "bool":
+		return &TypeConstraint{Kind: TypeConstraintBool}, nil
+	case "list", "set", "map":
+		kind := map[string]TypeConstraintKind{
+			"list": TypeConstraintList,
+			"set":  TypeConstraintSet,
+			"map":  TypeConstraintMap,
+		}[keyword]
+
+		// The legacy `list` and `map` constraints take no element type.
+		if !p.accept('(') {
+			return &TypeConstraint{Kind: kind, ElementType: &TypeConstraint{Kind: TypeConstraintAny}}, nil
+		}
+		element, err := p.parseType()
+		if err != nil {
+			return nil, err
+		}
+		if err = p.expect(')'); err != nil {
+			return nil, err
+		}
+		return &TypeConstraint{Kind: kind, ElementType: element}, nil
+	case "object":
+		return p.parseObject()
+	case "tuple":
+		return p.parseTuple()
+	default:
+		return nil, errors.Errorf("unknown type %q", keyword)
+	}
+}
+
+// parseObject parses the `({name = type, ...})` portion of an object constraint. Attributes may be separated by commas
+// or newlines.
+func (p *typeConstraintParser) parseObject() (*TypeConstraint, error) {
+	if err := p.expect('('); err != nil {
+		return nil, err
+	}
+	if err := p.expect('{'); err != nil {
+		return nil, err
+	}
+
+	attributes := map[string]*TypeConstraint{}
+	for !p.accept('}') {
+		name, err := p.parseName()
+		if err != nil {
+			return nil, err
+		}
+		if err = p.expect('='); err != nil {
+			return nil, err
+		}
+		if attributes[name], err = p.parseType(); err != nil {
+			return nil, err
+		}
+		p.accept(',')
+	}
+
+	if err := p.expect(')'); err != nil {
+		return nil, err
+	}
+	return &TypeConstraint{Kind: TypeConstraintObject, Attributes: attributes}, nil
+}
+
+// parseTuple parses the `([type, ...])` portion of a tuple constraint.
+func (p *typeConstraintParser) parseTuple() (*TypeConstraint, error) {
+	if err := p.expect('('); err != nil {
+		return nil, err
+	}
+	if err := p.expect('['); err != nil {
+		return nil, err
+	}
+
+	var elements []*TypeConstraint
+	for !p.accept(']') {
+		element, err := p.parseType()
+		if err != nil {
+			return nil, err
+		}
+		elements = append(elements, element)
+		if !p.accept(',') {
+			if err = p.expect(']'); err != nil {
+				return nil, err
+			}
+			break
+		}
+	}
+
+	if err := p.expect(')'); err != nil {
+		return nil, err
+	}
+	return &TypeConstraint{Kind: TypeConstraintTuple, Elements: elements}, nil
+}
@@ -0,0 +1,58 @@
+// Copyright 2016-2022, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package il
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseTypeConstraint(t *testing.T) {
+	cases := []struct {
+		input    string
+		expected string
+	}{
+		{"string", "string"},
+		{" number ", "number"},
+		{"bool", "bool"},
+		{"any", "any"},
+		{"list", "list(any)"},
+		{"map", "map(any)"},
+		{"list(string)", "list(string)"},
+		{"set( number )", "set(number)"},
+		{"map(list(bool))", "map(list(bool))"},
+		{"object({name = string, ports = list(number)})", "object({name = string, ports = list(number)})"},
+		{"object({\n  b = bool\n  a = map(string)\n})", "object({a = map(string), b = bool})"},
+		{"object({})", "object({})"},
+		{"tuple([string, number])", "tuple([string, number])"},
+		{"list(object({zone-name = string}))", "list(object({zone-name = string}))"},
+	}
+	for _, c := range cases {
+		typ, err := ParseTypeConstraint(c.input)
+		if assert.NoError(t, err, c.input) {
+			assert.Equal(t, c.expected, typ.String(), c.input)
+		}
+	}
+
+	typ, err := ParseTypeConstraint("")
+	assert.NoError(t, err)
+	assert.Nil(t, typ)
+
+	for _, input := range []string{"strin", "list(string", "map(string))", "object({name})", "tuple([string,"} {
+		_, err := ParseTypeConstraint(input)
+		assert.Error(t, err, input)
+	}
+}
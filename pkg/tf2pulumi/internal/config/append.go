@@ -88,5 +88,11 @@ func Append(c1, c2 *Config) (*Config, error) {
 		c.Locals = append(c.Locals, c2.Locals...)
 	}
 
+	if len(c1.Moved) > 0 || len(c2.Moved) > 0 {
+		c.Moved = make([]*Moved, 0, len(c1.Moved)+len(c2.Moved))
+		c.Moved = append(c.Moved, c1.Moved...)
+		c.Moved = append(c.Moved, c2.Moved...)
+	}
+
 	return c, nil
 }
@@ -33,6 +33,7 @@ type Config struct {
 	Variables       []*Variable
 	Locals          []*Local
 	Outputs         []*Output
+	Moved           []*Moved
 
 	// The fields below can be filled in by loaders for validation
 	// purposes.
@@ -153,6 +154,7 @@ type Variable struct {
 	DeclaredType string `mapstructure:"type"`
 	Default      interface{}
 	Description  string
+	Sensitive    bool
 }
 
 // Local is a local value defined within the configuration.
@@ -161,6 +163,13 @@ type Local struct {
 	RawConfig *RawConfig
 }
 
+// Moved is a `moved` block, which records that the object at the From address is now at the To address. Because the
+// configuration is parsed as HCL 1, the addresses must be quoted, e.g. `from = "aws_instance.a"`.
+type Moved struct {
+	From string
+	To   string
+}
+
 // Output is an output defined within the configuration. An output is
 // resulting data that is highlighted by Terraform when finished. An
 // output marked Sensitive will be output in a masked form following
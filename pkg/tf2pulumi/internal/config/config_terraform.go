@@ -13,6 +13,10 @@ import (
 type Terraform struct {
 	RequiredVersion string   `hcl:"required_version"` // Required Terraform version (constraint)
 	Backend         *Backend // See Backend struct docs
+
+	// RequiredProviders maps provider names to their version constraints, as
+	// declared by the required_providers block.
+	RequiredProviders map[string]string
 }
 
 // Validate performs the validation for just the Terraform configuration.
@@ -57,6 +61,13 @@ func (t *Terraform) Merge(t2 *Terraform) {
 	if t2.Backend != nil {
 		t.Backend = t2.Backend
 	}
+
+	for name, constraint := range t2.RequiredProviders {
+		if t.RequiredProviders == nil {
+			t.RequiredProviders = make(map[string]string)
+		}
+		t.RequiredProviders[name] = constraint
+	}
 }
 
 // Backend is the configuration for the "backend" to use with Terraform.
@@ -2,6 +2,7 @@ package config
 
 import (
 	"fmt"
+	"regexp"
 	"strconv"
 	"strings"
 
@@ -31,6 +32,15 @@ const (
 	CountValueIndex
 )
 
+// An IteratorVariable is a variable that references the key or value of the current element of a dynamic block's
+// iterator, such as "${ingress.value}".
+type IteratorVariable struct {
+	Name  string // Iterator name, i.e. the label of the dynamic block or the value of its iterator argument
+	Field string // "key" or "value"
+
+	key string
+}
+
 // A ModuleVariable is a variable that is referencing the output
 // of a module, such as "${module.foo.bar}"
 type ModuleVariable struct {
@@ -124,6 +134,8 @@ func NewInterpolatedVariable(v string) (InterpolatedVariable, error) {
 		return NewModuleVariable(v)
 	} else if !strings.ContainsRune(v, '.') {
 		return NewSimpleVariable(v)
+	} else if isIteratorVariable(v) {
+		return NewIteratorVariable(v)
 	} else {
 		return NewResourceVariable(v)
 	}
@@ -147,6 +159,26 @@ func (c *CountVariable) FullKey() string {
 	return c.key
 }
 
+// isIteratorVariable returns true if the given key has the form NAME.key or NAME.value. Such keys are not valid
+// resource variables, which must have at least three parts.
+func isIteratorVariable(key string) bool {
+	parts := strings.Split(key, ".")
+	return len(parts) == 2 && (parts[1] == "key" || parts[1] == "value")
+}
+
+func NewIteratorVariable(key string) (*IteratorVariable, error) {
+	parts := strings.SplitN(key, ".", 2)
+	return &IteratorVariable{
+		Name:  parts[0],
+		Field: parts[1],
+		key:   key,
+	}, nil
+}
+
+func (v *IteratorVariable) FullKey() string {
+	return v.key
+}
+
 func NewModuleVariable(key string) (*ModuleVariable, error) {
 	parts := strings.SplitN(key, ".", 3)
 	if len(parts) < 3 {
@@ -411,3 +443,20 @@ func DetectVariables(root ast.Node) ([]InterpolatedVariable, error) {
 
 	return result, nil
 }
+
+var (
+	interpolationRegexp = regexp.MustCompile(`\$\{[^}]*\}`)
+	fullSplatRegexp     = regexp.MustCompile(`([\w-])\[\*\]`)
+)
+
+// NormalizeSplats rewrites any full splat expressions within the interpolations in the given string, e.g.
+// `${aws_instance.foo[*].id}`, into the equivalent legacy splat expressions, e.g. `${aws_instance.foo.*.id}`, which
+// are the only splat expressions that HIL is able to parse.
+func NormalizeSplats(s string) string {
+	if !strings.Contains(s, "[*]") {
+		return s
+	}
+	return interpolationRegexp.ReplaceAllStringFunc(s, func(interpolation string) string {
+		return fullSplatRegexp.ReplaceAllString(interpolation, "$1.*")
+	})
+}
@@ -43,6 +43,24 @@ func TestNewInterpolatedVariable(t *testing.T) {
 			},
 			false,
 		},
+		{
+			"ingress.value",
+			&IteratorVariable{
+				Name:  "ingress",
+				Field: "value",
+				key:   "ingress.value",
+			},
+			false,
+		},
+		{
+			"ingress.key",
+			&IteratorVariable{
+				Name:  "ingress",
+				Field: "key",
+				key:   "ingress.key",
+			},
+			false,
+		},
 		{
 			"count.index",
 			&CountVariable{
@@ -228,6 +246,26 @@ func TestResourceVariable_MultiIndex(t *testing.T) {
 	}
 }
 
+func TestNormalizeSplats(t *testing.T) {
+	cases := []struct {
+		Input  string
+		Output string
+	}{
+		{"${foo.bar.*.baz}", "${foo.bar.*.baz}"},
+		{"${foo.bar[*].baz}", "${foo.bar.*.baz}"},
+		{"${element(foo.bar[*].baz, count.index)}", "${element(foo.bar.*.baz, count.index)}"},
+		{"${foo.bar[*].baz}-${foo.qux[*].id}", "${foo.bar.*.baz}-${foo.qux.*.id}"},
+		{"foo[*] ${foo.bar[*].baz}", "foo[*] ${foo.bar.*.baz}"},
+		{"foo[*]", "foo[*]"},
+	}
+
+	for _, tc := range cases {
+		if actual := NormalizeSplats(tc.Input); actual != tc.Output {
+			t.Fatalf("bad: %s\n\n%s", actual, tc.Input)
+		}
+	}
+}
+
 func TestUserVariable_impl(t *testing.T) {
 	var _ InterpolatedVariable = new(UserVariable)
 }
@@ -122,7 +122,7 @@ func (w *interpolationWalker) Primitive(v reflect.Value) error {
 		return nil
 	}
 
-	astRoot, err := hil.Parse(v.String())
+	astRoot, err := hil.Parse(NormalizeSplats(v.String()))
 	if err != nil {
 		return err
 	}
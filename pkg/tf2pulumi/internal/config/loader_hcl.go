@@ -47,6 +47,7 @@ func (t *hclConfigurable) Config() (*Config, error) {
 		"data":      {},
 		"locals":    {},
 		"module":    {},
+		"moved":     {},
 		"output":    {},
 		"provider":  {},
 		"resource":  {},
@@ -150,6 +151,15 @@ func (t *hclConfigurable) Config() (*Config, error) {
 		}
 	}
 
+	// Build the moved blocks
+	if moved := list.Filter("moved"); len(moved.Items) > 0 {
+		var err error
+		config.Moved, err = loadMovedHcl(moved)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	// Check for invalid keys
 	for _, item := range list.Items {
 		if len(item.Keys) == 0 {
@@ -294,9 +304,49 @@ func loadTerraformHcl(list *ast.ObjectList) (*Terraform, error) {
 		}
 	}
 
+	if os := listVal.Filter("required_providers"); len(os.Items) > 0 {
+		var err error
+		config.RequiredProviders, err = loadRequiredProvidersHcl(os)
+		if err != nil {
+			return nil, fmt.Errorf(
+				"Error reading required_providers for terraform block: %s",
+				err)
+		}
+	}
+
 	return &config, nil
 }
 
+// Loads the provider version constraints from a required_providers block.
+// Both the shorthand form (`aws = ">= 4.0"`) and the object form
+// (`aws = { version = ">= 4.0" }`) are accepted.
+func loadRequiredProvidersHcl(list *ast.ObjectList) (map[string]string, error) {
+	result := make(map[string]string)
+	for _, item := range list.Items {
+		var providers map[string]interface{}
+		if err := hcl.DecodeObject(&providers, item.Val); err != nil {
+			return nil, err
+		}
+
+		for name, v := range providers {
+			switch v := v.(type) {
+			case string:
+				result[name] = v
+			case []map[string]interface{}:
+				for _, m := range v {
+					if constraint, ok := m["version"].(string); ok {
+						result[name] = constraint
+					}
+				}
+			default:
+				return nil, fmt.Errorf(
+					"provider %q: expected a version constraint or an object", name)
+			}
+		}
+	}
+	return result, nil
+}
+
 // Loads the Backend configuration from an object list.
 func loadTerraformBackendHcl(list *ast.ObjectList) (*Backend, error) {
 	if len(list.Items) > 1 {
@@ -509,6 +559,31 @@ func loadLocalsHcl(list *ast.ObjectList) ([]*Local, error) {
 	return result, nil
 }
 
+// loadMovedHcl recurses into the given HCL object and turns it into a list
+// of moved blocks.
+func loadMovedHcl(list *ast.ObjectList) ([]*Moved, error) {
+	result := make([]*Moved, 0, len(list.Items))
+	for _, block := range list.Items {
+		if len(block.Keys) > 0 {
+			return nil, fmt.Errorf(
+				"moved block at %s should not have label %q",
+				block.Pos(), block.Keys[0].Token.Value(),
+			)
+		}
+
+		var moved Moved
+		if err := hcl.DecodeObject(&moved, block.Val); err != nil {
+			return nil, fmt.Errorf("Error reading moved block at %s: %s", block.Pos(), err)
+		}
+		if moved.From == "" || moved.To == "" {
+			return nil, fmt.Errorf("moved block at %s must have both from and to addresses", block.Pos())
+		}
+		result = append(result, &moved)
+	}
+
+	return result, nil
+}
+
 // LoadOutputsHcl recurses into the given HCL object and turns
 // it into a mapping of outputs.
 func loadOutputsHcl(list *ast.ObjectList) ([]*Output, error) {
@@ -538,6 +613,7 @@ func loadOutputsHcl(list *ast.ObjectList) ([]*Output, error) {
 		// Delete special keys
 		delete(config, "depends_on")
 		delete(config, "description")
+		delete(config, "sensitive")
 
 		rawConfig, err := NewRawConfig(config)
 		if err != nil {
@@ -571,11 +647,24 @@ func loadOutputsHcl(list *ast.ObjectList) ([]*Output, error) {
 			}
 		}
 
+		// If we have a sensitive field, then filter that
+		var sensitive bool
+		if o := listVal.Filter("sensitive"); len(o.Items) > 0 {
+			err := hcl.DecodeObject(&sensitive, o.Items[0].Val)
+			if err != nil {
+				return nil, fmt.Errorf(
+					"Error reading sensitive for output %q: %s",
+					n,
+					err)
+			}
+		}
+
 		result = append(result, &Output{
 			Name:        n,
 			RawConfig:   rawConfig,
 			DependsOn:   dependsOn,
 			Description: description,
+			Sensitive:   sensitive,
 		})
 	}
 
@@ -596,6 +685,7 @@ func loadVariablesHcl(list *ast.ObjectList) ([]*Variable, error) {
 		DeclaredType string `hcl:"type"`
 		Default      interface{}
 		Description  string
+		Sensitive    bool
 		Fields       []string `hcl:",decodedFields"`
 	}
 
@@ -620,7 +710,7 @@ func loadVariablesHcl(list *ast.ObjectList) ([]*Variable, error) {
 		}
 
 		// Check for invalid keys
-		valid := []string{"type", "default", "description"}
+		valid := []string{"type", "default", "description", "sensitive"}
 		if err := checkHCLKeys(item.Val, valid); err != nil {
 			return nil, multierror.Prefix(err, fmt.Sprintf(
 				"variable[%s]:", n))
@@ -652,6 +742,7 @@ func loadVariablesHcl(list *ast.ObjectList) ([]*Variable, error) {
 			DeclaredType: hclVar.DeclaredType,
 			Default:      hclVar.Default,
 			Description:  hclVar.Description,
+			Sensitive:    hclVar.Sensitive,
 		}
 
 		result = append(result, newVar)
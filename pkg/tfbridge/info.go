@@ -91,6 +91,54 @@ type ProviderInfo struct {
 
 	PreConfigureCallback           PreConfigureCallback // a provider-specific callback to invoke prior to TF Configure
 	PreConfigureCallbackWithLogger PreConfigureCallbackWithLogger
+
+	// TokenStrategy optionally derives the tokens of resources and data sources that have no explicit Tok.
+	TokenStrategy TokenStrategy
+}
+
+// TokenStrategy derives the Pulumi token for a TF resource or data source from its TF token, e.g. by following a
+// naming convention. It returns false if no token can be derived for the given TF token.
+type TokenStrategy func(tfToken string) (pulumiToken string, ok bool)
+
+// GetResource returns the information for the given TF resource. If the resource has no explicit Tok, the token is
+// derived using the TokenStrategy, if any. It returns nil if there is neither explicit nor derived information.
+func (info ProviderInfo) GetResource(tfToken string) *ResourceInfo {
+	res := info.Resources[tfToken]
+	if res != nil && res.Tok != "" || info.TokenStrategy == nil {
+		return res
+	}
+	tok, ok := info.TokenStrategy(tfToken)
+	if !ok {
+		return res
+	}
+
+	derived := ResourceInfo{}
+	if res != nil {
+		derived = *res
+	}
+	derived.Tok = tokens.Type(tok)
+	return &derived
+}
+
+// GetDataSource returns the information for the given TF data source. If the data source has no explicit Tok, the
+// token is derived using the TokenStrategy, if any. It returns nil if there is neither explicit nor derived
+// information.
+func (info ProviderInfo) GetDataSource(tfToken string) *DataSourceInfo {
+	ds := info.DataSources[tfToken]
+	if ds != nil && ds.Tok != "" || info.TokenStrategy == nil {
+		return ds
+	}
+	tok, ok := info.TokenStrategy(tfToken)
+	if !ok {
+		return ds
+	}
+
+	derived := DataSourceInfo{}
+	if ds != nil {
+		derived = *ds
+	}
+	derived.Tok = tokens.ModuleMember(tok)
+	return &derived
 }
 
 // TFProviderLicense is a way to be able to pass a license type for the upstream Terraform provider.
@@ -300,6 +348,20 @@ type DocInfo struct {
 	IncludeAttributesFromArguments string // optionally include attributes from another raw resource's arguments.
 	ImportDetails                  string // Overwrite for import instructions
 
+	// DeduplicateAttributeDescriptions, when used with IncludeAttributesFromArguments, avoids duplicating included
+	// descriptions that are identical to the description of this entity's own argument of the same name. Such
+	// attributes refer to the argument's description instead.
+	DeduplicateAttributeDescriptions bool
+
+	// AttributeDescriptions optionally overrides the descriptions of specific attributes, keyed by their TF name.
+	// Overrides take precedence over descriptions parsed from the TF docs and any included attributes.
+	AttributeDescriptions map[string]string
+
+	// ArgumentExamples optionally attaches small HCL usage snippets to specific arguments, keyed by the dotted TF path
+	// of the argument, e.g. "website.index_document". Each snippet is converted like any other example and appended
+	// to the description of its argument.
+	ArgumentExamples map[string]string
+
 	// Replace examples with the contents of a specific document
 	// this document will satisfy the criteria `docs/pulumiToken.md`
 	// The examples need to wrapped in the correct shortcodes
@@ -1,9 +1,11 @@
 package tfbridge
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/tokens"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -57,6 +59,46 @@ func TestMakeResource(t *testing.T) {
 	assert.Equal(t, "package:module/myResource:MyResource", MakeResource("package", "module", "MyResource").String())
 }
 
+func TestTokenStrategy(t *testing.T) {
+	// A strategy that maps `aws_foo_bar` to `aws:foo/bar:Bar`.
+	strategy := func(tfToken string) (string, bool) {
+		parts := strings.Split(tfToken, "_")
+		if len(parts) != 3 {
+			return "", false
+		}
+		name := strings.ToUpper(parts[2][:1]) + parts[2][1:]
+		return MakeResource(parts[0], parts[1], name).String(), true
+	}
+
+	info := ProviderInfo{
+		Resources: map[string]*ResourceInfo{
+			"aws_foo_baz":  {Tok: "aws:index/explicit:Explicit"},
+			"aws_foo_quux": {DeprecationMessage: "deprecated"},
+		},
+		DataSources: map[string]*DataSourceInfo{
+			"aws_foo_baz": {Tok: "aws:index/getExplicit:getExplicit"},
+		},
+		TokenStrategy: strategy,
+	}
+
+	assert.Equal(t, tokens.Type("aws:foo/bar:Bar"), info.GetResource("aws_foo_bar").Tok)
+	assert.Equal(t, tokens.ModuleMember("aws:foo/bar:Bar"), info.GetDataSource("aws_foo_bar").Tok)
+
+	// Explicit tokens always win.
+	assert.Equal(t, tokens.Type("aws:index/explicit:Explicit"), info.GetResource("aws_foo_baz").Tok)
+	assert.Equal(t, tokens.ModuleMember("aws:index/getExplicit:getExplicit"), info.GetDataSource("aws_foo_baz").Tok)
+
+	// The rest of the explicit information is preserved, and the provider info itself is left alone.
+	quux := info.GetResource("aws_foo_quux")
+	assert.Equal(t, tokens.Type("aws:foo/quux:Quux"), quux.Tok)
+	assert.Equal(t, "deprecated", quux.DeprecationMessage)
+	assert.Equal(t, tokens.Type(""), info.Resources["aws_foo_quux"].Tok)
+
+	// Tokens the strategy cannot map are left unmapped.
+	assert.Nil(t, info.GetResource("aws_unmapped"))
+	assert.Nil(t, ProviderInfo{}.GetResource("aws_foo_bar"))
+}
+
 func TestStringValue(t *testing.T) {
 	myMap := map[resource.PropertyKey]resource.PropertyValue{
 		"key1": {V: "value1"},
@@ -205,12 +205,9 @@ func (p *Provider) initResourceMaps() {
 		var tok tokens.Type
 
 		// See if there is override information for this resource.  If yes, use that to decode the token.
-		var schema *ResourceInfo
-		if p.info.Resources != nil {
-			schema = p.info.Resources[name]
-			if schema != nil {
-				tok = schema.Tok
-			}
+		schema := p.info.GetResource(name)
+		if schema != nil {
+			tok = schema.Tok
 		}
 
 		// Otherwise, we default to the standard naming scheme.
@@ -235,12 +232,9 @@ func (p *Provider) initResourceMaps() {
 		var tok tokens.ModuleMember
 
 		// See if there is override information for this resource.  If yes, use that to decode the token.
-		var schema *DataSourceInfo
-		if p.info.DataSources != nil {
-			schema = p.info.DataSources[name]
-			if schema != nil {
-				tok = schema.Tok
-			}
+		schema := p.info.GetDataSource(name)
+		if schema != nil {
+			tok = schema.Tok
 		}
 
 		// Otherwise, we default to the standard naming scheme.
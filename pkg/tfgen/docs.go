@@ -17,6 +17,7 @@ package tfgen
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"golang.org/x/text/cases"
 	"golang.org/x/text/language"
@@ -28,14 +29,18 @@ import (
 	"sort"
 	"strings"
 	"sync"
+	"time"
+	"unicode"
 
 	"github.com/hashicorp/go-multierror"
 	"github.com/pulumi/pulumi-terraform-bridge/v3/pkg/tf2pulumi/gen/python"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/tokens"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/util/contract"
 	"github.com/spf13/afero"
 
 	"github.com/pulumi/pulumi-terraform-bridge/v3/pkg/tf2pulumi/convert"
 	"github.com/pulumi/pulumi-terraform-bridge/v3/pkg/tfbridge"
+	shim "github.com/pulumi/pulumi-terraform-bridge/v3/pkg/tfshim"
 )
 
 // argumentDocs contains the documentation metadata for an argument of the resource.
@@ -49,6 +54,30 @@ type argumentDocs struct {
 	// Whether this argument was derived from a nested object. Used to determine
 	// whether to append descriptions that have continued to the following line
 	isNested bool
+
+	// (Optional) The deprecation message for this argument, if the docs mark it as deprecated.
+	deprecationMessage string
+
+	// (Optional) The values that the description enumerates as valid for this argument, e.g. ["BLOCK", "ALLOW"] for
+	// "Valid values are `BLOCK` or `ALLOW`".
+	validValues []string
+
+	// Whether the docs list this argument under a "Required:" grouping header, as the docs of
+	// terraform-plugin-framework providers do for the arguments of nested schemata, or mark it as required in the
+	// Required column of an argument table.
+	isRequired bool
+
+	// (Optional) The type that the docs annotate this argument with, e.g. "Number" for "(Optional, Number)" or
+	// "List of String" for "(Required, List of String)".
+	docType string
+
+	// (Optional) The sentence that opens the description if it describes the argument's default in prose, e.g.
+	// "Defaults to the region of the provider.". The description itself is left intact.
+	defaultDescription string
+
+	// (Optional) The default value that the Default column of an argument table gives for this argument, e.g.
+	// `"blue"` or `3`.
+	defaultValue string
 }
 
 // Included for testing convenience.
@@ -97,11 +126,30 @@ type entityDocs struct {
 	// the TF markdown are inconsistent. For example, see `cors_rule` in s3_bucket.html.markdown.
 	Arguments map[string]*argumentDocs
 
-	// Attributes includes the names and descriptions for each attribute of the resource
+	// Attributes includes the names and descriptions for each attribute of the resource. A description may be a
+	// reference to the description of the argument of the same name (see argumentDescriptionRef); use
+	// attributeDescription to read descriptions.
 	Attributes map[string]string
 
+	// NestedAttributes maps the name of each nested block of attributes to the names and descriptions of its
+	// attributes. As with arguments, nested attributes are also recorded in Attributes if no top-level attribute of
+	// the same name is documented.
+	NestedAttributes map[string]map[string]string
+
 	// Import is the import details for the resource
 	Import string
+
+	// ImportID is the ID that the first `terraform import` command of the import details imports, e.g.
+	// "<project>/<name>", if any.
+	ImportID string
+
+	// DeprecationMessage is the deprecation message of the entity itself, if its docs lead with an admonition that it is
+	// deprecated. See parseEntityDeprecation.
+	DeprecationMessage string
+
+	// FrontMatter holds the fields of the YAML front matter that registry docs begin with, e.g. "subcategory" and
+	// "page_title". Only fields with scalar values are recorded.
+	FrontMatter map[string]string
 }
 
 func (ed *entityDocs) getOrCreateArgumentDocs(argumentName string) (*argumentDocs, bool) {
@@ -118,6 +166,28 @@ func (ed *entityDocs) getOrCreateArgumentDocs(argumentName string) (*argumentDoc
 	return args, created
 }
 
+// argumentDescriptionRefPrefix marks an attribute description that refers to the description of an argument rather
+// than duplicating it. The name of the argument follows the prefix.
+const argumentDescriptionRefPrefix = "\x00argument:"
+
+// argumentDescriptionRef returns a reference to the description of the given argument for use as an attribute
+// description.
+func argumentDescriptionRef(name string) string {
+	return argumentDescriptionRefPrefix + name
+}
+
+// attributeDescription returns the description of the given attribute, resolving references to argument descriptions.
+func (ed entityDocs) attributeDescription(name string) string {
+	desc := ed.Attributes[name]
+	if !strings.HasPrefix(desc, argumentDescriptionRefPrefix) {
+		return desc
+	}
+	if arg := ed.Arguments[strings.TrimPrefix(desc, argumentDescriptionRefPrefix)]; arg != nil {
+		return arg.description
+	}
+	return ""
+}
+
 // DocKind indicates what kind of entity's documentation is being requested.
 type DocKind string
 
@@ -267,7 +337,29 @@ func getDocsForProvider(g *Generator, org string, provider string, resourcePrefi
 		return entityDocs{}, nil
 	}
 
-	doc, err := parseTFMarkdown(g, info, kind, string(markdownBytes), markdownFileName, resourcePrefix, rawname)
+	// Docs that are read from the provider's doc set may refer to footer links that are defined by their siblings.
+	if markdownFileName != "" {
+		g.loadSharedFooterLinks(org, provider, providerModuleVersion, githost)
+	}
+
+	// If the markdown documents several entities, only parse the part that documents this one.
+	markdown := string(markdownBytes)
+	if entityMarkdown, ok := splitMarkdownEntities(markdown)[rawname]; ok {
+		markdown = entityMarkdown
+	}
+
+	doc, panicValue, err := parseEntityMarkdown(g, info, kind, markdown, markdownFileName, resourcePrefix, rawname)
+	if panicValue != nil {
+		// A bug in the parser should not block the generation of the rest of the provider, so the entity is
+		// generated without docs instead.
+		tok := rawname
+		if info != nil && info.GetTok() != "" {
+			tok = string(info.GetTok())
+		}
+		g.warnFor(rawname, "", "panic parsing the docs for %v %v (%v); it will be generated without docs: %v",
+			kind, formatEntityName(rawname), tok, panicValue)
+		return entityDocs{}, nil
+	}
 	if err != nil {
 		return entityDocs{}, err
 	}
@@ -297,7 +389,7 @@ func getDocsForProvider(g *Generator, org string, provider string, resourcePrefi
 				return doc, err
 			}
 
-			overlayArgsToAttributes(sourceDocs, doc)
+			overlayArgsToAttributes(sourceDocs, doc, docinfo.DeduplicateAttributeDescriptions)
 		}
 
 		if docinfo.IncludeArgumentsFrom != "" {
@@ -308,32 +400,212 @@ func getDocsForProvider(g *Generator, org string, provider string, resourcePrefi
 
 			overlayArgsToArgs(sourceDocs, doc)
 		}
+
+		if len(docinfo.AttributeDescriptions) != 0 {
+			overlayAttributeDescriptions(g, kind, rawname, docinfo.AttributeDescriptions, doc)
+		}
+
+		if len(docinfo.ArgumentExamples) != 0 {
+			g.appendArgumentExamples(docinfo.ArgumentExamples, &doc)
+		}
 	}
 
 	return doc, nil
 }
 
+// parseEntityMarkdown parses the markdown for a single entity like parseTFMarkdown, but returns the value of any panic
+// raised by the parser instead of propagating it.
+func parseEntityMarkdown(g *Generator, info tfbridge.ResourceOrDataSourceInfo, kind DocKind,
+	markdown, markdownFileName, resourcePrefix, rawname string) (doc entityDocs, panicValue interface{}, err error) {
+
+	defer func() {
+		if v := recover(); v != nil {
+			doc, panicValue, err = entityDocs{}, v, nil
+		}
+	}()
+
+	doc, err = parseTFMarkdownFunc(g, info, kind, markdown, markdownFileName, resourcePrefix, rawname)
+	return doc, nil, err
+}
+
+// providerDocs represents the documentation for a provider as extracted from the provider's index markdown.
+type providerDocs struct {
+	// Description is the introductory prose for the provider, e.g. installation and authentication instructions.
+	Description string
+
+	// Arguments maps the name of each configuration argument of the provider to its metadata.
+	Arguments map[string]*argumentDocs
+}
+
+// getProviderDocs extracts documentation for the provider itself from the index markdown in the upstream provider's
+// docs. The result is computed once per generator. If the docs cannot be found or parsed, empty docs are returned; the
+// provider's docs are supplementary, so a bad index only warrants a warning.
+func (g *Generator) getProviderDocs() providerDocs {
+	if g.providerDocs != nil {
+		return *g.providerDocs
+	}
+	if g.skipDocs {
+		return providerDocs{}
+	}
+
+	g.providerDocs = &providerDocs{}
+
+	repoPath, err := getRepoPath(g.info.GetGitHubHost(), g.info.GetGitHubOrg(), g.info.Name,
+		g.info.GetProviderModuleVersion())
+	if err != nil {
+		g.debug("could not find the repository for provider %v: %v", g.info.Name, err)
+		return *g.providerDocs
+	}
+
+	// The index lives alongside the resource and data source docs, e.g. website/docs/index.html.markdown.
+	docsPath := filepath.Dir(getDocsPath(repoPath, ResourceDocs))
+	for _, name := range []string{"index.html.markdown", "index.markdown", "index.html.md", "index.md"} {
+		markdownBytes, err := os.ReadFile(filepath.Join(docsPath, name))
+		if err != nil {
+			continue
+		}
+
+		docs, err := parseProviderMarkdown(g, string(markdownBytes), name)
+		if err != nil {
+			g.warn("could not parse the index docs of provider %v; its configuration will be undocumented: %v",
+				g.info.Name, err)
+			return *g.providerDocs
+		}
+		g.providerDocs = &docs
+		return docs
+	}
+
+	g.debug("could not find index docs for provider %v", g.info.Name)
+	return *g.providerDocs
+}
+
+// parseProviderMarkdown takes the TF website markdown for a provider's index page and extracts its introductory prose
+// and configuration arguments.
+func parseProviderMarkdown(g *Generator, markdown, markdownFileName string) (providerDocs, error) {
+	info := &tfbridge.ResourceInfo{
+		Tok:    tokens.Type(g.pkg),
+		Fields: g.info.Config,
+	}
+	docs, err := parseTFMarkdown(g, info, ResourceDocs, markdown, markdownFileName, g.info.GetResourcePrefix(),
+		g.info.Name)
+	if err != nil {
+		return providerDocs{}, err
+	}
+	return providerDocs{
+		Description: docs.Description,
+		Arguments:   docs.Arguments,
+	}, nil
+}
+
 func overlayAttributesToAttributes(sourceDocs entityDocs, targetDocs entityDocs) {
-	for k, v := range sourceDocs.Attributes {
-		targetDocs.Attributes[k] = v
+	for _, k := range sortedKeys(sourceDocs.Attributes) {
+		targetDocs.Attributes[k] = sourceDocs.Attributes[k]
+	}
+	if targetDocs.NestedAttributes == nil {
+		return
+	}
+	for _, k := range sortedKeys(sourceDocs.NestedAttributes) {
+		v := sourceDocs.NestedAttributes[k]
+		if targetDocs.NestedAttributes[k] == nil {
+			targetDocs.NestedAttributes[k] = make(map[string]string, len(v))
+		}
+		for _, kk := range sortedKeys(v) {
+			targetDocs.NestedAttributes[k][kk] = v[kk]
+		}
+	}
+}
+
+// overlayAttributeDescriptions applies the attribute description overrides supplied through DocInfo to the given
+// docs. Overrides for attributes that the TF schema of the entity does not define are reported as warnings.
+func overlayAttributeDescriptions(g *Generator, kind DocKind, rawname string, overrides map[string]string,
+	doc entityDocs) {
+
+	var res shim.Resource
+	var found bool
+	if p := g.provider(); p != nil {
+		if kind == DataSourceDocs {
+			res, found = p.DataSourcesMap().GetOk(rawname)
+		} else {
+			res, found = p.ResourcesMap().GetOk(rawname)
+		}
+	}
+	if found {
+		for _, name := range sortedKeys(overrides) {
+			if _, ok := res.Schema().GetOk(name); !ok {
+				g.warn("attribute description override for %v %v refers to unknown attribute %q",
+					kind, formatEntityName(rawname), name)
+			}
+		}
+	}
+
+	overlayAttributesToAttributes(entityDocs{Attributes: overrides}, doc)
+}
+
+// appendArgumentExamples appends the given HCL snippets, keyed by the dotted path of the argument they illustrate, to
+// the descriptions of those arguments. The snippets are converted along with the rest of the examples in the schema,
+// so nothing is appended if examples are not going to be converted.
+func (g *Generator) appendArgumentExamples(examples map[string]string, doc *entityDocs) {
+	if g.skipExamples || !g.language.shouldConvertExamples() {
+		return
+	}
+
+	for _, path := range sortedKeys(examples) {
+		snippet := "```terraform\n" + strings.TrimSpace(examples[path]) + "\n```"
+		withSnippet := func(desc string) string {
+			if desc == "" {
+				return snippet
+			}
+			return desc + "\n\n" + snippet
+		}
+
+		i := strings.LastIndex(path, ".")
+		if i == -1 {
+			arg, _ := doc.getOrCreateArgumentDocs(path)
+			arg.description = withSnippet(arg.description)
+			continue
+		}
+
+		block, name := path[:i], path[i+1:]
+		arg, _ := doc.getOrCreateArgumentDocs(block)
+		if arg.arguments == nil {
+			arg.arguments = make(map[string]string)
+		}
+		arg.arguments[name] = withSnippet(arg.arguments[name])
+
+		// Keep the top-level copy of the nested argument in sync, as the parser does.
+		if nested := doc.Arguments[name]; nested != nil && nested.isNested {
+			nested.description = withSnippet(nested.description)
+		}
 	}
 }
 
-func overlayArgsToAttributes(sourceDocs entityDocs, targetDocs entityDocs) {
-	for k, v := range sourceDocs.Arguments {
-		targetDocs.Attributes[k] = v.description
-		for kk, vv := range v.arguments {
-			targetDocs.Attributes[kk] = vv
+// overlayArgsToAttributes copies the descriptions of the arguments in sourceDocs to the attributes of the same name in
+// targetDocs. If dedup is true, a description that is identical to that of the argument of the same name in targetDocs
+// is recorded as a reference to that argument's description rather than as a copy. Arguments are visited in lexical
+// order so that the description recorded for a name shared by several nested blocks does not depend on map order.
+func overlayArgsToAttributes(sourceDocs entityDocs, targetDocs entityDocs, dedup bool) {
+	overlay := func(name, desc string) {
+		if arg := targetDocs.Arguments[name]; dedup && desc != "" && arg != nil && arg.description == desc {
+			desc = argumentDescriptionRef(name)
+		}
+		targetDocs.Attributes[name] = desc
+	}
+
+	for _, k := range sortedKeys(sourceDocs.Arguments) {
+		v := sourceDocs.Arguments[k]
+		overlay(k, v.description)
+		for _, kk := range sortedKeys(v.arguments) {
+			overlay(kk, v.arguments[kk])
 		}
 	}
 }
 
 func overlayArgsToArgs(sourceDocs entityDocs, docs entityDocs) {
-	for k, v := range sourceDocs.Arguments { // string -> argument
-		arguments := sourceDocs.Arguments[k].arguments
+	for _, k := range sortedKeys(sourceDocs.Arguments) { // string -> argument
+		v := sourceDocs.Arguments[k]
 		docArguments := make(map[string]string)
-		for kk, vv := range arguments {
-			docArguments[kk] = vv
+		for _, kk := range sortedKeys(v.arguments) {
+			docArguments[kk] = v.arguments[kk]
 		}
 		docs.Arguments[k] = &argumentDocs{
 			description: v.description,
@@ -342,6 +614,33 @@ func overlayArgsToArgs(sourceDocs entityDocs, docs entityDocs) {
 	}
 }
 
+// overlayArgsFillMissing is the non-destructive counterpart of overlayArgsToArgs: it only fills in the descriptions of
+// arguments and nested arguments whose description in docs is empty, so that descriptions that have been edited
+// locally survive a merge of upstream docs.
+func overlayArgsFillMissing(sourceDocs entityDocs, docs entityDocs) {
+	for _, k := range sortedKeys(sourceDocs.Arguments) {
+		v := sourceDocs.Arguments[k]
+		arg, ok := docs.Arguments[k]
+		if !ok {
+			arg = &argumentDocs{}
+			docs.Arguments[k] = arg
+		}
+		if arg.description == "" {
+			arg.description = v.description
+		}
+
+		for _, kk := range sortedKeys(v.arguments) {
+			vv := v.arguments[kk]
+			if arg.arguments == nil {
+				arg.arguments = make(map[string]string)
+			}
+			if arg.arguments[kk] == "" {
+				arg.arguments[kk] = vv
+			}
+		}
+	}
+}
+
 // checkIfNewDocsExist checks if the new docs root exists
 func checkIfNewDocsExist(repo string) bool {
 	// Check if the new docs path exists
@@ -386,21 +685,38 @@ var (
 	// [1]: https://docs.aws.amazon.com/lambda/latest/dg/welcome.html
 	linkFooterRegexp = regexp.MustCompile(`(?m)^(\[\d+\]):\s(.*)`)
 
+	// The code span may also assign a default value to the argument, e.g. `name = "value"`. Besides `*`, `-`, and
+	// `+` bullets, ordered-list markers such as `1.` are recognized; the backtick-quoted name is still required so
+	// that prose enumerations are not mistaken for arguments.
 	argumentBulletRegexp = regexp.MustCompile(
-		"^\\s*[*+-]\\s+`([a-zA-z0-9_]*)`\\s*(\\([a-zA-Z]*\\)\\s*)?[–-]?\\s+(\\([^\\)]*\\)\\s*)?(.*)")
+		"^\\s*(?:[*+-]|\\d+\\.)\\s+`([a-zA-z0-9_]*)(?:\\s*=\\s*([^`]*?)|\\s+[^`]*?)?\\s*`" +
+			"\\s*(\\([a-zA-Z]*\\)\\s*)?[–-]?\\s+(\\([^\\)]*\\)\\s*)?(.*)")
+
+	// Attributes in the format used by terraform-plugin-framework providers omit the dash and instead follow the name
+	// with a type declaration, e.g. "- `id` (String) The ID of the resource."
+	attributeBulletRegexp = regexp.MustCompile(
+		"^\\s*[*+-]\\s+`([a-zA-z0-9_]*)`(?:\\s+[–-]?\\s+|\\s+(\\([^)]*\\))\\s*)(.*)")
 
-	attributeBulletRegexp = regexp.MustCompile("^\\s*[*+-]\\s+`([a-zA-z0-9_]*)`\\s+[–-]?\\s+(.*)")
+	// For example:
+	// ---
+	// ***
+	horizontalRuleRegexp = regexp.MustCompile(`^\s*(-{3,}|\*{3,})\s*$`)
 
 	attributionFormatString = "This Pulumi package is based on the [`%[1]s` Terraform Provider](https://%[3]s/%[2]s/terraform-provider-%[1]s)."
 )
 
 // groupLines take a slice of strings, lines, and returns a nested slice of strings. When groupLines encounters a line
-// that in the input that starts with the supplied string sep, it will begin a new entry in the outer slice.
+// that in the input that starts with the supplied string sep, it will begin a new entry in the outer slice. Lines
+// inside fenced code blocks never begin a new entry, so code such as HCL comments cannot split an example in two.
 func groupLines(lines []string, sep string) [][]string {
 	var buffer []string
 	var sections [][]string
+	inCode := false
 	for _, line := range lines {
-		if strings.Index(line, sep) == 0 {
+		if strings.HasPrefix(line, "```") {
+			inCode = !inCode
+		}
+		if !inCode && strings.Index(line, sep) == 0 {
 			sections = append(sections, buffer)
 			buffer = []string{}
 		}
@@ -434,6 +750,65 @@ func parseTFMarkdown(g *Generator, info tfbridge.ResourceOrDataSourceInfo, kind
 	return p.parse()
 }
 
+// entityHeaderRegexp matches the H1 that introduces an entity in markdown that documents several resources or data
+// sources, e.g. "# Resource: aws_widget".
+var entityHeaderRegexp = regexp.MustCompile("^# (?:Resource|Data Source):\\s*`?([a-zA-Z0-9_\\\\]+)`?\\s*$")
+
+// splitMarkdownEntities splits markdown that documents several entities, each under its own "# Resource:" or
+// "# Data Source:" H1, into per-entity markdown keyed by entity name. Any prose that precedes the first H1 is attached
+// to the first entity, while the front matter (if any) is shared by every entity. If the markdown documents fewer than
+// two entities, the result is nil.
+func splitMarkdownEntities(markdown string) map[string]string {
+	lines := strings.Split(strings.Replace(markdown, "\r\n", "\n", -1), "\n")
+
+	type entity struct {
+		name  string
+		start int
+	}
+	var entities []entity
+	inCode := false
+	for i, line := range lines {
+		if strings.HasPrefix(line, "```") {
+			inCode = !inCode
+		}
+		if inCode {
+			continue
+		}
+		if matches := entityHeaderRegexp.FindStringSubmatch(line); len(matches) != 0 {
+			entities = append(entities, entity{name: strings.ReplaceAll(matches[1], "\\", ""), start: i})
+		}
+	}
+	if len(entities) < 2 {
+		return nil
+	}
+
+	var frontMatter []string
+	if len(lines) > 0 && lines[0] == "---" {
+		for i := 1; i < entities[0].start; i++ {
+			if lines[i] == "---" {
+				frontMatter = lines[:i+1]
+				break
+			}
+		}
+	}
+
+	result := make(map[string]string, len(entities))
+	for i, e := range entities {
+		var section []string
+		if i == 0 {
+			section = append(section, lines[:e.start]...)
+		} else {
+			section = append(section, frontMatter...)
+		}
+		end := len(lines)
+		if i+1 < len(entities) {
+			end = entities[i+1].start
+		}
+		result[e.name] = strings.Join(append(section, lines[e.start:end]...), "\n")
+	}
+	return result
+}
+
 type tfMarkdownParser struct {
 	g                *Generator
 	info             tfbridge.ResourceOrDataSourceInfo
@@ -443,6 +818,14 @@ type tfMarkdownParser struct {
 	resourcePrefix   string
 	rawname          string
 
+	// nestedSchemaLinks maps the anchors that "(see below for nested schema)" links point to, e.g.
+	// "nestedatt--settings", to the dotted path of the argument or attribute whose description links to them.
+	nestedSchemaLinks map[string]string
+
+	// anchor is the anchor of the last non-blank line if that line is only an HTML anchor, e.g.
+	// `<a id="nestedatt--settings"></a>`, which names the nested schema whose header follows it.
+	anchor string
+
 	ret entityDocs
 }
 
@@ -453,8 +836,49 @@ const (
 	sectionAttributesReference = 3
 	sectionFrontMatter         = 4
 	sectionImports             = 5
+	sectionSchema              = 6
+	sectionIgnored             = 7
 )
 
+// getSectionKind returns the kind of the H2 section with the given header, less its "## " prefix.
+func getSectionKind(header string) int {
+	switch header {
+	case "Timeout", "Timeouts", "User Project Override", "User Project Overrides":
+		return sectionIgnored
+	case "Example Usage":
+		return sectionExampleUsage
+	case "Arguments Reference", "Argument Reference", "Argument reference", "Nested Blocks", "Nested blocks":
+		return sectionArgsReference
+	case "Attributes Reference", "Attribute Reference", "Attribute reference":
+		return sectionAttributesReference
+	case "Import", "Imports":
+		return sectionImports
+	case "---":
+		return sectionFrontMatter
+	case "Schema":
+		return sectionSchema
+	default:
+		return sectionOther
+	}
+}
+
+// hasDescriptionProse returns true if the given leading section of a document holds any prose besides its front matter
+// and headers.
+func hasDescriptionProse(section []string) bool {
+	inFrontMatter := false
+	for i, line := range section {
+		switch {
+		case strings.TrimSpace(line) == "---" && (i == 0 || inFrontMatter):
+			inFrontMatter = i == 0
+		case inFrontMatter || isBlank(line) || strings.HasPrefix(line, "#"):
+			// Front matter, blank lines, and headers are not prose.
+		default:
+			return true
+		}
+	}
+	return false
+}
+
 func (p *tfMarkdownParser) parseSupplementaryExamples() (string, error) {
 	examplesFileName := fmt.Sprintf("docs/%s/%s.examples.md", p.kind, p.rawname)
 	absPath, err := filepath.Abs(examplesFileName)
@@ -471,9 +895,51 @@ func (p *tfMarkdownParser) parseSupplementaryExamples() (string, error) {
 }
 
 func (p *tfMarkdownParser) parse() (entityDocs, error) {
+	raw, err := parseRawMarkdownCached(p)
+	if err != nil {
+		return entityDocs{}, err
+	}
+	p.ret = raw.doc
+
+	if limit := p.g.maxArgumentNestingDepth; limit > 0 {
+		for _, path := range deeplyNestedArguments(p.ret.Arguments, limit) {
+			p.g.warnFor(p.rawname, path, "Argument %q of %v is nested more than %d levels deep, which may "+
+				"indicate that sibling arguments were parsed as nested ones", path, p.rawname, limit)
+		}
+	}
+
+	// Docs from the provider's doc set fall back to the footer links that are defined by their siblings.
+	footerLinks := raw.footerLinks
+	if p.markdownFileName != "" {
+		footerLinks = mergeFooterLinks(footerLinks, p.g.sharedFooterLinks)
+	}
+
+	doc, elided := cleanupDoc(p.rawname, p.g, p.ret, footerLinks, getFieldRenames(p.info), p.g.elidedReplacement)
+	if elided {
+		p.g.warnFor(p.rawname, "", "Resource %v contains an <elided> doc reference that needs updated", p.rawname)
+	}
+
+	return doc, nil
+}
+
+// rawEntityDocs holds the docs that parseRaw extracts from the markdown of an entity, before their text is cleaned
+// up for the target language.
+type rawEntityDocs struct {
+	doc entityDocs
+	// footerLinks maps the references of the link definitions in the markdown's footer to their URLs.
+	footerLinks map[string]string
+	// warnings holds the warnings that were raised while parsing, so that they can be raised again when the docs are
+	// reused from a DocsCache.
+	warnings []GenerationWarning
+}
+
+// parseRaw splits the markdown into its sections and extracts the description, arguments, attributes, and other docs
+// of the entity from them. Its result depends only on the markdown and on the inputs that docsCacheKey covers.
+func (p *tfMarkdownParser) parseRaw() (rawEntityDocs, error) {
 	p.ret = entityDocs{
-		Arguments:  make(map[string]*argumentDocs),
-		Attributes: make(map[string]string),
+		Arguments:        make(map[string]*argumentDocs),
+		Attributes:       make(map[string]string),
+		NestedAttributes: make(map[string]map[string]string),
 	}
 
 	// Replace any Windows-style newlines.
@@ -482,8 +948,16 @@ func (p *tfMarkdownParser) parse() (entityDocs, error) {
 	// Replace redundant comment.
 	markdown = strings.Replace(markdown, "<!-- schema generated by tfplugindocs -->", "", -1)
 
+	// Extract the front matter, if any. Only its opening delimiter is kept, as it marks the section that holds the
+	// description.
+	if fields, rest, ok := extractFrontMatter(markdown); ok {
+		p.ret.FrontMatter, markdown = fields, frontMatterDelimiter+"\n"+rest
+	} else if strings.HasPrefix(markdown, frontMatterDelimiter+"\n") {
+		p.g.warnFor(p.rawname, "", "Expected to pair --- begin/end for resource %v's Markdown header", p.rawname)
+	}
+
 	// Split the sections by H2 topics in the Markdown file.
-	sections := splitGroupLines(markdown, "## ")
+	sections := splitGroupLines(normalizeH1Sections(markdown), "## ")
 
 	// we are explicitly overwriting the Terraform examples here
 	if p.info != nil && p.info.GetDocs() != nil && p.info.ReplaceExamplesSection() {
@@ -499,30 +973,90 @@ func (p *tfMarkdownParser) parse() (entityDocs, error) {
 		// now we are going to inject the new source of examples
 		newExamples, err := p.parseSupplementaryExamples()
 		if err != nil {
-			return entityDocs{}, err
+			return rawEntityDocs{}, err
 		}
 		newSection := strings.Split(newExamples, "\n")
 		sections = append(sections, newSection)
 	} else {
 		// Reparent examples that are peers of the "Example Usage" section (if any) and fixup some example titles.
 		sections = reformatExamples(sections)
+		sections = moveExamplesAfterDescription(sections)
+
+		if p.g.collapseDuplicateExamples {
+			for i, section := range sections {
+				if len(section) == 0 || section[0] != "## Example Usage" {
+					continue
+				}
+
+				var duplicates []string
+				sections[i], duplicates = collapseDuplicateExamples(section)
+				for _, title := range duplicates {
+					p.g.warnFor(p.rawname, "", "Example %q in %v has the same code as an earlier example and was dropped", title,
+						p.rawname)
+				}
+				break
+			}
+		}
 	}
 
 	for _, section := range sections {
 		if err := p.parseSection(section); err != nil {
-			return entityDocs{}, err
+			return rawEntityDocs{}, err
 		}
 	}
 
-	// Get links.
-	footerLinks := getFooterLinks(markdown)
+	// Extract enumerations of valid values and prose defaults now that descriptions that span several lines are complete.
+	for _, arg := range p.ret.Arguments {
+		arg.validValues = parseValidValues(arg.description)
+		arg.defaultDescription = parseLeadingDefault(arg.description)
+	}
 
-	doc, elided := cleanupDoc(p.rawname, p.g, p.ret, footerLinks)
-	if elided {
-		p.g.warn("Resource %v contains an <elided> doc reference that needs updated", p.rawname)
+	p.ret.DeprecationMessage = parseEntityDeprecation(p.ret.Description)
+
+	return rawEntityDocs{doc: p.ret, footerLinks: getFooterLinks(markdown)}, nil
+}
+
+// collapseDuplicateExamples removes the H3 subsections of an example usage section whose code blocks are identical to
+// those of an earlier subsection. Code is compared verbatim (modulo trailing whitespace), so examples that differ only
+// in their comments are kept. Subsections without any code are never considered duplicates. The titles of the removed
+// subsections are returned alongside the collapsed section.
+func collapseDuplicateExamples(section []string) ([]string, []string) {
+	subsections := groupLines(section, "### ")
+
+	var result, duplicates []string
+	seen := map[string]bool{}
+	for _, subsection := range subsections {
+		if len(subsection) == 0 || !strings.HasPrefix(subsection[0], "### ") {
+			result = append(result, subsection...)
+			continue
+		}
+
+		code := exampleCode(subsection)
+		if code != "" && seen[code] {
+			duplicates = append(duplicates, strings.TrimSpace(strings.TrimPrefix(subsection[0], "### ")))
+			continue
+		}
+		seen[code] = true
+		result = append(result, subsection...)
 	}
+	return result, duplicates
+}
 
-	return doc, nil
+// exampleCode returns the contents of the fenced code blocks in the given lines, including their fences.
+func exampleCode(lines []string) string {
+	var code strings.Builder
+	inCode := false
+	for _, line := range lines {
+		isFence := strings.HasPrefix(strings.TrimSpace(line), "```")
+		if inCode || isFence {
+			code.WriteString(strings.TrimRight(line, " \t"))
+			code.WriteString("\n")
+		}
+		if isFence {
+			inCode = !inCode
+		}
+	}
+	return code.String()
 }
 
 // fixExampleTitles transforms H4 sections that contain code snippets into H3 sections.
@@ -538,6 +1072,60 @@ func fixExampleTitles(lines []string) {
 	}
 }
 
+// fenceLanguageAliases maps the lowercased code fence languages that upstream docs use for Terraform examples to the
+// canonical language. Add an entry here to recognize another spelling.
+var fenceLanguageAliases = map[string]string{
+	"hcl":       "hcl",
+	"terraform": "hcl",
+	"tf":        "hcl",
+}
+
+// normalizeFenceLanguages rewrites the language of each opening code fence that names an alias of HCL, e.g. "```tf" or
+// "```HCL", to the canonical "```hcl".
+func normalizeFenceLanguages(lines []string) {
+	inCode := false
+	for i, line := range lines {
+		if !strings.HasPrefix(line, "```") {
+			continue
+		}
+		if !inCode {
+			lang := strings.ToLower(strings.TrimSpace(strings.TrimPrefix(line, "```")))
+			if canonical, ok := fenceLanguageAliases[lang]; ok {
+				lines[i] = "```" + canonical
+			}
+		}
+		inCode = !inCode
+	}
+}
+
+// isAdmonition returns true if the given line opens one of the "->" (note) or "~>" (warning) admonitions that upstream
+// docs interleave with their examples.
+func isAdmonition(line string) bool {
+	return strings.HasPrefix(line, "-> ") || strings.HasPrefix(line, "~> ")
+}
+
+// separateAdmonitions returns the given lines with a blank line inserted between each admonition and any code fence
+// that immediately precedes or follows it, so that the admonition remains prose in its place between the examples
+// rather than running into their code. Lines within code blocks are left alone.
+func separateAdmonitions(lines []string) []string {
+	result := make([]string, 0, len(lines))
+	inCode := false
+	for i, line := range lines {
+		isFence := strings.HasPrefix(line, "```")
+		if !inCode && isAdmonition(line) && i > 0 && strings.HasPrefix(lines[i-1], "```") {
+			result = append(result, "")
+		}
+		result = append(result, line)
+		if isFence {
+			inCode = !inCode
+		}
+		if !inCode && isAdmonition(line) && i+1 < len(lines) && strings.HasPrefix(lines[i+1], "```") {
+			result = append(result, "")
+		}
+	}
+	return result
+}
+
 var exampleHeaderRegexp = regexp.MustCompile(`(?i)^(## Example Usage\s*)(?:(?:(?:for|of|[\pP]+)\s*)?(.*?)\s*)?$`)
 
 // reformatExamples reparents examples that are peers of the "Example Usage" section (if any) and fixup some example
@@ -606,8 +1194,11 @@ func reformatExamples(sections [][]string) [][]string {
 	// Ensure that the output begins with the canonical example usage header.
 	exampleUsageSection[0] = "## Example Usage"
 
-	// Fixup example titles and replace the contents of the canonical example usage section with the output.
+	// Fixup example titles and fence languages and replace the contents of the canonical example usage section with
+	// the output.
 	fixExampleTitles(exampleUsageSection)
+	normalizeFenceLanguages(exampleUsageSection)
+	exampleUsageSection = separateAdmonitions(exampleUsageSection)
 	sections[canonicalExampleUsageSectionIndex] = exampleUsageSection
 
 	// If there is only one example section, we're done. Otherwise, we need to remove all non-canonical example usage
@@ -629,10 +1220,83 @@ func reformatExamples(sections [][]string) [][]string {
 	return result
 }
 
+// normalizeH1Sections rewrites the H1 headings of well-known sections, e.g. "# Example Usage", as H2 headings so that
+// they are grouped like those of any other doc. If the doc has no H2 headings at all, the other H1 headings that follow
+// a well-known section are taken to introduce its subsections, and are rewritten as H3 headings. The title of the doc
+// precedes any section, and is left alone, as are lines within code blocks, where "#" begins a comment.
+func normalizeH1Sections(markdown string) string {
+	lines := strings.Split(markdown, "\n")
+
+	flat, inCode := true, false
+	for _, line := range lines {
+		if strings.HasPrefix(line, "```") {
+			inCode = !inCode
+		} else if !inCode && strings.HasPrefix(line, "## ") {
+			flat = false
+			break
+		}
+	}
+
+	inSection := false
+	inCode = false
+	for i, line := range lines {
+		if strings.HasPrefix(line, "```") {
+			inCode = !inCode
+		}
+		if inCode || !strings.HasPrefix(line, "# ") {
+			continue
+		}
+
+		header := strings.TrimSpace(strings.TrimPrefix(line, "# "))
+		switch {
+		case getSectionKind(header) != sectionOther || exampleHeaderRegexp.MatchString("## "+header):
+			lines[i], inSection = "## "+header, true
+		case flat && inSection:
+			lines[i] = "### " + header
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// moveExamplesAfterDescription moves the canonical "Example Usage" section after the prose sections that follow it if
+// it precedes all of the document's prose, as the docs of a handful of resources place their examples immediately
+// after the H1. This keeps the description first in the rendered docs.
+func moveExamplesAfterDescription(sections [][]string) [][]string {
+	examples := -1
+	for i, s := range sections {
+		if len(s) > 0 && s[0] == "## Example Usage" {
+			examples = i
+			break
+		}
+		if hasDescriptionProse(s) {
+			return sections
+		}
+	}
+	if examples == -1 {
+		return sections
+	}
+
+	// Find the run of prose sections that immediately follows the examples.
+	end := examples + 1
+	for end < len(sections) && len(sections[end]) > 0 &&
+		getSectionKind(strings.TrimPrefix(sections[end][0], "## ")) == sectionOther {
+		end++
+	}
+	if end == examples+1 {
+		return sections
+	}
+
+	result := make([][]string, 0, len(sections))
+	result = append(result, sections[:examples]...)
+	result = append(result, sections[examples+1:end]...)
+	result = append(result, sections[examples])
+	return append(result, sections[end:]...)
+}
+
 func (p *tfMarkdownParser) parseSection(h2Section []string) error {
 	// Extract the header name, since this will drive how we process the content.
 	if len(h2Section) == 0 {
-		p.g.warn("Unparseable H2 doc section for %v; consider overriding doc source location", p.rawname)
+		p.g.warnFor(p.rawname, "", "Unparseable H2 doc section for %v; consider overriding doc source location", p.rawname)
 		return nil
 	}
 
@@ -642,24 +1306,13 @@ func (p *tfMarkdownParser) parseSection(h2Section []string) error {
 		header = header[3:]
 	}
 
-	sectionKind := sectionOther
-
-	switch header {
-	case "Timeout", "Timeouts", "User Project Override", "User Project Overrides":
+	sectionKind := getSectionKind(header)
+	switch sectionKind {
+	case sectionIgnored:
 		p.g.debug("Ignoring doc section [%v] for [%v]", header, p.rawname)
 		ignoredDocHeaders[header]++
 		return nil
-	case "Example Usage":
-		sectionKind = sectionExampleUsage
-	case "Arguments Reference", "Argument Reference", "Argument reference", "Nested Blocks", "Nested blocks":
-		sectionKind = sectionArgsReference
-	case "Attributes Reference", "Attribute Reference", "Attribute reference":
-		sectionKind = sectionAttributesReference
-	case "Import", "Imports":
-		sectionKind = sectionImports
-	case "---":
-		sectionKind = sectionFrontMatter
-	case "Schema":
+	case sectionSchema:
 		p.parseSchemaWithNestedSections(h2Section)
 		return nil
 	}
@@ -684,15 +1337,18 @@ func (p *tfMarkdownParser) parseSection(h2Section []string) error {
 		}
 		if hasExamples && sectionKind != sectionExampleUsage && sectionKind != sectionImports &&
 			!p.info.ReplaceExamplesSection() {
-			p.g.warn("Unexpected code snippets in section '%v' for %v '%v'. The HCL code will be converted if possible, "+
-				"but may not display correctly in the generated docs.", header, p.kind, p.rawname)
+			p.g.warnFor(p.rawname, "", "Unexpected code snippets in section '%v' for %v '%v'. The HCL code "+
+				"will be converted if possible, but may not display correctly in the generated docs.", header, p.kind, p.rawname)
 			unexpectedSnippets++
 		}
 
 		// Now process the content based on the H2 topic. These are mostly standard across TF's docs.
 		switch sectionKind {
 		case sectionArgsReference:
-			p.parseArgReferenceSection(reformattedH3Section)
+			if p.parseArgReferenceSection(reformattedH3Section) {
+				// The section transitioned to documenting attributes, so its remaining subsections do as well.
+				sectionKind = sectionAttributesReference
+			}
 		case sectionAttributesReference:
 			p.parseAttributesReferenceSection(reformattedH3Section)
 		case sectionFrontMatter:
@@ -734,8 +1390,73 @@ func getFooterLinks(markdown string) map[string]string {
 	return links
 }
 
-func (p *tfMarkdownParser) parseSchemaWithNestedSections(subsection []string) {
-	node := parseNode(strings.Join(subsection, "\n"))
+// loadSharedFooterLinks loads the footer links defined across the resource and data source docs of the given provider
+// into the generator's shared footer link table, unless the table has already been loaded.
+func (g *Generator) loadSharedFooterLinks(org, provider, providerModuleVersion, githost string) {
+	if g.sharedFooterLinks != nil {
+		return
+	}
+	g.sharedFooterLinks = map[string]string{}
+
+	repoPath, err := getRepoPath(githost, org, provider, providerModuleVersion)
+	if err != nil {
+		return
+	}
+	var docs []string
+	for _, kind := range []DocKind{ResourceDocs, DataSourceDocs} {
+		dir := getDocsPath(repoPath, kind)
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			continue
+		}
+		for _, entry := range entries {
+			if name := entry.Name(); !entry.IsDir() && (strings.HasSuffix(name, ".md") ||
+				strings.HasSuffix(name, ".markdown")) {
+				if markdown, err := os.ReadFile(filepath.Join(dir, name)); err == nil {
+					docs = append(docs, string(markdown))
+				}
+			}
+		}
+	}
+	g.sharedFooterLinks = getSharedFooterLinks(docs)
+}
+
+// getSharedFooterLinks returns the footer links defined across the given docs. References that the docs define
+// inconsistently are ambiguous and are omitted.
+func getSharedFooterLinks(docs []string) map[string]string {
+	links, conflicts := map[string]string{}, map[string]bool{}
+	for _, markdown := range docs {
+		for ref, link := range getFooterLinks(markdown) {
+			if existing, ok := links[ref]; ok && existing != link {
+				conflicts[ref] = true
+			}
+			links[ref] = link
+		}
+	}
+	for ref := range conflicts {
+		delete(links, ref)
+	}
+	return links
+}
+
+// mergeFooterLinks returns the footer links defined locally by a doc together with the shared footer links that it
+// does not define itself. Local definitions always take precedence.
+func mergeFooterLinks(local, shared map[string]string) map[string]string {
+	if len(shared) == 0 {
+		return local
+	}
+	merged := make(map[string]string, len(local)+len(shared))
+	for ref, link := range shared {
+		merged[ref] = link
+	}
+	for ref, link := range local {
+		merged[ref] = link
+	}
+	return merged
+}
+
+func (p *tfMarkdownParser) parseSchemaWithNestedSections(subsection []string) {
+	node := parseNode(strings.Join(subsection, "\n"))
 	topLevelSchema, err := parseTopLevelSchema(node, nil)
 	if err != nil {
 		p.g.warn(fmt.Sprintf("error: Failure in parsing resource name: %s, subsection: %s", p.rawname, subsection[0]))
@@ -745,110 +1466,811 @@ func (p *tfMarkdownParser) parseSchemaWithNestedSections(subsection []string) {
 		p.g.warn("Failed to parse top-level Schema section")
 		return
 	}
-	parseTopLevelSchemaIntoDocs(&p.ret, topLevelSchema, p.g.warn)
+	parseTopLevelSchemaIntoDocs(&p.ret, topLevelSchema, p.g.warn)
+}
+
+// parseArgFromMarkdownLine takes a line of Markdown and attempts to parse it for a Terraform argument, its
+// description, the type it is annotated with (if any) and, if the argument is marked as deprecated, its deprecation
+// message. If the argument's code span assigns it a value, e.g. "* `name = "value"` - ...", the value is folded into
+// the description as its default.
+func parseArgFromMarkdownLine(line string) (string, string, string, string, bool) {
+	matches := argumentBulletRegexp.FindStringSubmatch(line)
+
+	if len(matches) > 5 {
+		desc, deprecationMessage := parseArgDeprecation(matches[3]+matches[4], matches[5])
+		if def := strings.TrimSpace(matches[2]); def != "" && !strings.Contains(strings.ToLower(desc), "default") {
+			desc = appendDefault(desc, def)
+		}
+		return matches[1], desc, deprecationMessage, parseArgDocType(matches[3] + matches[4]), true
+	}
+
+	return "", "", "", "", false
+}
+
+// argDocTypeRegexp matches the type annotations that some providers add to the parenthesized declaration preceding
+// an argument's description, including composite types such as "List of String" or "Map of List of Number".
+var argDocTypeRegexp = regexp.MustCompile(
+	`(?i)^(?:string|number|int|integer|float|bool|boolean|list|set|map|object|block)` +
+		`(?:\s+of\s+(?:string|number|int|integer|float|bool|boolean|list|set|map|object|block))*$`)
+
+// parseArgDocType returns the type annotation in the given parenthesized type declaration, or "" if there is none.
+//
+// Examples of type declarations with type annotations include (but are not limited to):
+//
+// - "(Optional, Number)" -> "Number"
+// - "(Required, List of String)" -> "List of String"
+// - "(Optional, Map, **Deprecated**)" -> "Map"
+func parseArgDocType(typeDecl string) string {
+	typeDecl = strings.TrimSpace(typeDecl)
+	if !strings.HasPrefix(typeDecl, "(") || !strings.HasSuffix(typeDecl, ")") {
+		return ""
+	}
+	for _, part := range strings.Split(typeDecl[1:len(typeDecl)-1], ",") {
+		if part = strings.Trim(strings.TrimSpace(part), "*_`"); argDocTypeRegexp.MatchString(part) {
+			return part
+		}
+	}
+	return ""
+}
+
+var (
+	deprecatedTypeDeclRegexp = regexp.MustCompile(`(?i)\bdeprecated\b`)
+	deprecatedMarkerRegexp   = regexp.MustCompile(`(?i)\*\*deprecated:?\*\*:?\s*|^deprecated:\s*`)
+)
+
+// parseArgDeprecation detects whether an argument is marked as deprecated, either in the parenthesized type
+// declaration preceding its description or with a marker in the description itself. It returns the description with
+// any marker removed and the deprecation message, which is empty if the argument is not deprecated.
+//
+// Examples of deprecated arguments include (but are not limited to):
+//
+// - "* `old_field` - (Optional, **Deprecated**) Use `new_field` instead."
+// - "* `old_field` - (Optional) **Deprecated:** Use `new_field` instead."
+// - "* `old_field` - (Optional) Deprecated: Use `new_field` instead."
+// - "* `old_field` - (Optional) The old field. **DEPRECATED** Use `new_field` instead."
+func parseArgDeprecation(typeDecl, desc string) (string, string) {
+	deprecated := deprecatedTypeDeclRegexp.MatchString(typeDecl)
+
+	message := desc
+	if loc := deprecatedMarkerRegexp.FindStringIndex(desc); loc != nil {
+		deprecated = true
+		before, after := strings.TrimSpace(desc[:loc[0]]), strings.TrimSpace(desc[loc[1]:])
+		desc = strings.TrimSpace(before + " " + after)
+		message = desc
+		if after != "" {
+			message = after
+		}
+	}
+
+	if !deprecated {
+		return desc, ""
+	}
+	if message == "" {
+		message = "Deprecated"
+	}
+	return desc, message
+}
+
+var (
+	// admonitionMarkerRegexp matches the marker that begins an admonition, e.g. "~> ".
+	admonitionMarkerRegexp = regexp.MustCompile(`^(?:[!~-]>|>)\s*`)
+	// admonitionLabelRegexp matches the bold label that follows an admonition marker, e.g. "**NOTE:** ".
+	admonitionLabelRegexp = regexp.MustCompile(`^\*\*[^*]+\*\*:?\s*`)
+	// entityDeprecationRegexp matches a sentence that announces the deprecation of the documented entity, e.g.
+	// "This resource has been deprecated in favor of `widgets_gadget`."
+	entityDeprecationRegexp = regexp.MustCompile(
+		`(?i)^(?:this|the \S+) (?:resource|data[ -]source)\b[^.]*?\b(?:is|has been|was) (?:now )?deprecated\b`)
+)
+
+// parseEntityDeprecation returns the deprecation message of an entity if its description leads with an admonition that
+// the entity is deprecated, or the empty string otherwise. Mentions of deprecation elsewhere in the description, e.g.
+// of one of the entity's arguments, are incidental and are ignored.
+//
+// Examples of deprecation admonitions include (but are not limited to):
+//
+// - "~> **Deprecated:** Use the `widgets_gadget` resource instead."
+// - "!> **WARNING:** This resource is deprecated and will be removed in the next major version."
+// - "**DEPRECATED** This data source has been replaced by `widgets_gadgets`."
+func parseEntityDeprecation(description string) string {
+	// The admonition must be the first paragraph of the description.
+	var paragraph []string
+	for _, line := range strings.Split(strings.TrimSpace(description), "\n") {
+		if isBlank(line) {
+			break
+		}
+		paragraph = append(paragraph, strings.TrimSpace(line))
+	}
+	text := strings.Join(paragraph, " ")
+
+	isAdmonition := false
+	if loc := admonitionMarkerRegexp.FindStringIndex(text); loc != nil {
+		text, isAdmonition = text[loc[1]:], true
+	}
+
+	// A leading deprecation marker suffices, whether or not it is part of an admonition.
+	if loc := deprecatedMarkerRegexp.FindStringIndex(text); loc != nil && loc[0] == 0 {
+		if message := strings.TrimSpace(text[loc[1]:]); message != "" {
+			return message
+		}
+		return "Deprecated"
+	}
+
+	// Otherwise, an admonition must announce the deprecation of the entity itself.
+	if !isAdmonition {
+		return ""
+	}
+	text = admonitionLabelRegexp.ReplaceAllString(text, "")
+	if entityDeprecationRegexp.MatchString(text) {
+		return text
+	}
+	return ""
+}
+
+// getNestedBlockName take a line of a Terraform docs Markdown page and returns the name of the nested block it
+// describes. If the line does not describe a nested block, an empty string is returned.
+//
+// Examples of nested blocks include (but are not limited to):
+//
+// - "The `private_cluster_config` block supports:" -> "private_cluster_config"
+// - "The optional settings.backup_configuration subblock supports:" -> "settings.backup_configuration"
+// - "### Nested Schema for `settings.backup_configuration`" -> "settings.backup_configuration"
+func getNestedBlockName(line string) string {
+	nested := ""
+
+	// Some docs prefix the line with an HTML anchor, e.g. GCP's
+	// `<a name="nested_conditions"></a>The `conditions` block supports:`.
+	line = htmlAnchorPrefixRegexp.ReplaceAllString(line, "")
+
+	nestedObjectRegexps := []*regexp.Regexp{
+		// For example:
+		// s3_bucket.html.markdown: "The `website` object supports the following:"
+		// ami.html.markdown: "When `virtualization_type` is "hvm" the following additional arguments apply:"
+		regexp.MustCompile("`([a-z_-]+)`.*following"),
+
+		// For example:
+		// monitor_diagnostic_setting.html.markdown: "A `retention_policy` block supports:"
+		regexp.MustCompile("`([a-z_-]+)` (?:block|object) supports:"),
+
+		// For example:
+		// athena_workgroup.html.markdown: "#### result_configuration Argument Reference"
+		regexp.MustCompile("(?i)## ([a-z_-]+).* argument reference"),
+
+		// Docs in the format used by terraform-plugin-framework providers (as generated by tfplugindocs), e.g.
+		// "### Nested Schema for `settings.backup_configuration`"
+		regexp.MustCompile("^(?:#+ )?Nested Schema for `([a-z0-9_.-]+)`"),
+	}
+
+	for _, match := range nestedObjectRegexps {
+		matches := match.FindStringSubmatch(line)
+		if len(matches) >= 2 {
+			nested = strings.ToLower(matches[1])
+			break
+		}
+	}
+
+	return nested
+}
+
+var htmlAnchorPrefixRegexp = regexp.MustCompile(`^\s*<a (?:name|id)="[^"]*">\s*</a>\s*`)
+
+var (
+	multipleNestedBlocksRegexp = regexp.MustCompile(
+		"((?:`[a-z0-9_.-]+`(?:, and |, | and ))+`[a-z0-9_.-]+`) (?:(?:configuration )?blocks|objects) (?:each )?support")
+	codeSpanRegexp = regexp.MustCompile("`([^`]+)`")
+)
+
+// getNestedBlocks returns the names of the nested blocks that a line of an Argument Reference section introduces, e.g.
+// ["a", "b"] for "The `a` and `b` blocks support:". If the line names several blocks, only those that the entity's TF
+// schema defines are returned, unless none of them are, in which case only the first block is returned as if the line
+// named it alone. If the line does not introduce a nested block, nil is returned.
+func (p *tfMarkdownParser) getNestedBlocks(line string) []string {
+	matches := multipleNestedBlocksRegexp.FindStringSubmatch(htmlAnchorPrefixRegexp.ReplaceAllString(line, ""))
+	if len(matches) < 2 {
+		if name := getNestedBlockName(line); name != "" {
+			return []string{p.resolveNestedBlockName(name)}
+		}
+		return nil
+	}
+
+	var names, blocks []string
+	for _, span := range codeSpanRegexp.FindAllStringSubmatch(matches[1], -1) {
+		name := p.resolveNestedBlockName(strings.ToLower(span[1]))
+		names = append(names, name)
+		if p.schemaHasBlock(name) {
+			blocks = append(blocks, name)
+		}
+	}
+	if len(blocks) == 0 {
+		return names[:1]
+	}
+	return blocks
+}
+
+// schemaHasBlock returns true if the entity's TF schema defines a block at the given dotted path, e.g.
+// "settings.backup_configuration". If the schema is not available, every block is assumed to exist.
+func (p *tfMarkdownParser) schemaHasBlock(path string) bool {
+	var res shim.Resource
+	var found bool
+	if prov := p.g.provider(); prov != nil {
+		if p.kind == DataSourceDocs {
+			res, found = prov.DataSourcesMap().GetOk(p.rawname)
+		} else {
+			res, found = prov.ResourcesMap().GetOk(p.rawname)
+		}
+	}
+	if !found {
+		return true
+	}
+
+	for _, name := range strings.Split(path, ".") {
+		sch, ok := res.Schema().GetOk(name)
+		if !ok {
+			return false
+		}
+		if res, ok = sch.Elem().(shim.Resource); !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// resolveNestedBlockName maps a hyphenated nested block name, e.g. "result-configuration", back to the underscored
+// name of an argument that has already been parsed, e.g. "result_configuration". Names that do not correspond to a
+// parsed argument are returned unchanged.
+func (p *tfMarkdownParser) resolveNestedBlockName(name string) string {
+	if !strings.Contains(name, "-") {
+		return name
+	}
+	if candidate := strings.ReplaceAll(name, "-", "_"); p.ret.Arguments[candidate] != nil {
+		return candidate
+	}
+	return name
+}
+
+// argumentTable tracks the columns of a markdown table of arguments, e.g.
+//
+//	| Name | Description | Type | Required | Default |
+//	|------|-------------|------|----------|---------|
+//	| `name` | The name of the widget. | `string` | yes | n/a |
+type argumentTable struct {
+	nameColumn, descriptionColumn, requiredColumn, defaultColumn int
+
+	// Whether the separator row between the header and the body has been seen.
+	inBody bool
+}
+
+// parseTableRow splits a markdown table row into its trimmed cells. If the line is not a table row, nil is returned.
+func parseTableRow(line string) []string {
+	line = strings.TrimSpace(line)
+	if !strings.HasPrefix(line, "|") {
+		return nil
+	}
+	line = strings.TrimSuffix(strings.TrimPrefix(line, "|"), "|")
+
+	cells := strings.Split(line, "|")
+	for i := range cells {
+		cells[i] = strings.TrimSpace(cells[i])
+	}
+	return cells
+}
+
+// isTableSeparatorRow returns true if the given cells are the separator row between a table's header and its body,
+// e.g. "|---|:---:|".
+func isTableSeparatorRow(cells []string) bool {
+	for _, cell := range cells {
+		if strings.Trim(cell, ":-") != "" || !strings.Contains(cell, "-") {
+			return false
+		}
+	}
+	return true
+}
+
+// newArgumentTable interprets the header row of a markdown table. If the table does not document arguments, nil is
+// returned.
+func newArgumentTable(header []string) *argumentTable {
+	t := &argumentTable{nameColumn: -1, descriptionColumn: -1, requiredColumn: -1, defaultColumn: -1}
+	for i, cell := range header {
+		switch strings.ToLower(strings.Trim(cell, "*_ ")) {
+		case "name", "argument", "parameter", "property":
+			t.nameColumn = i
+		case "description":
+			t.descriptionColumn = i
+		case "required":
+			t.requiredColumn = i
+		case "default", "default value":
+			t.defaultColumn = i
+		}
+	}
+	if t.nameColumn == -1 || t.descriptionColumn == -1 {
+		return nil
+	}
+	return t
+}
+
+// argumentRow is the argument documented by a row of an argument table.
+type argumentRow struct {
+	name, description string
+	// Whether the Required column marks the argument as required.
+	required bool
+	// The value of the Default column, if any, without its code span.
+	defaultValue string
+}
+
+// parseRow extracts the argument documented by a row of the table. Columns other than Name, Description, Required and
+// Default, e.g. Type, are dropped.
+func (t *argumentTable) parseRow(cells []string) (argumentRow, bool) {
+	if t.nameColumn >= len(cells) || t.descriptionColumn >= len(cells) {
+		return argumentRow{}, false
+	}
+
+	row := argumentRow{name: strings.Trim(cells[t.nameColumn], "`"), description: cells[t.descriptionColumn]}
+	if row.name == "" {
+		return argumentRow{}, false
+	}
+
+	if t.requiredColumn != -1 && t.requiredColumn < len(cells) {
+		switch strings.ToLower(strings.Trim(cells[t.requiredColumn], "`*_ ")) {
+		case "yes", "true", "required":
+			row.required = true
+		}
+	}
+	if t.defaultColumn != -1 && t.defaultColumn < len(cells) {
+		switch def := cells[t.defaultColumn]; strings.ToLower(def) {
+		case "", "-", "n/a", "none":
+			// No default.
+		default:
+			row.defaultValue = strings.Trim(def, "`")
+		}
+	}
+	return row, true
+}
+
+// appendDefault folds the given default value of an argument into its description.
+func appendDefault(desc, def string) string {
+	if !strings.HasPrefix(def, "`") {
+		def = "`" + def + "`"
+	}
+	if desc == "" {
+		return "Defaults to " + def + "."
+	}
+	return strings.TrimSuffix(desc, ".") + ". Defaults to " + def + "."
+}
+
+// attributesTransitionRegexp matches the sentence that ends the documentation of arguments and introduces that of
+// attributes, e.g. "In addition to all arguments above, the following attributes are exported:".
+var attributesTransitionRegexp = regexp.MustCompile(
+	`(?i)^\s*(?:in addition to (?:all )?(?:the )?arguments (?:listed )?above,?\s*)?` +
+		`the following attributes are (?:also )?(?:exported|available)\b`)
+
+// parseArgReferenceSection parses the arguments documented by the given subsection. If the subsection transitions to
+// documenting attributes, the lines that follow the transition are parsed as attributes and parseArgReferenceSection
+// returns true.
+func (p *tfMarkdownParser) parseArgReferenceSection(subsection []string) bool {
+	var lastMatch, nested string
+	var table *argumentTable
+	var inTable, inRequiredGroup bool
+
+	// inReadOnlyGroup is true while the bullets document attributes, i.e. they follow a "Read-Only:" grouping header as
+	// in the docs of terraform-plugin-framework providers. lastMatchIsTopLevel is true if lastMatch is such an
+	// attribute and was recorded as a top-level attribute.
+	var inReadOnlyGroup, lastMatchIsTopLevel bool
+
+	// listParent is the argument documented by the last top-level bullet, whose nested sub-list bullets document the
+	// arguments of its block. inSubList is true while lastMatch is such a nested bullet.
+	var listParent string
+	var inSubList bool
+
+	// siblings holds the blocks other than nested that share its arguments, e.g. "b" for "The `a` and `b` blocks
+	// support:".
+	var siblings []string
+	for i, line := range subsection {
+		anchor := p.trackAnchor(line)
+		if attributesTransitionRegexp.MatchString(line) {
+			p.parseAttributesReferenceSection(subsection[i+1:])
+			return true
+		}
+
+		if horizontalRuleRegexp.MatchString(line) {
+			// A horizontal rule separates independent groups of arguments, so anything that follows it must
+			// re-establish its parent block.
+			lastMatch, nested, siblings, table, inTable, inRequiredGroup = "", "", nil, nil, false, false
+			listParent, inSubList, inReadOnlyGroup = "", false, false
+			continue
+		}
+
+		if flags := parseParamFlagLiteral(strings.TrimSpace(line)); flags != nil {
+			// A grouping header, e.g. "Required:" or "Optional:", applies to the arguments that follow it until the
+			// next grouping header. The bullets that follow "Read-Only:" document attributes instead.
+			lastMatch, inRequiredGroup, inReadOnlyGroup = "", *flags == required, *flags == readonly
+			listParent, inSubList = "", false
+			continue
+		}
+
+		if cells := parseTableRow(line); cells != nil {
+			switch {
+			case !inTable:
+				// The first row of a table is its header.
+				table, inTable = newArgumentTable(cells), true
+			case table == nil:
+				// This table does not document arguments.
+			case !table.inBody:
+				table.inBody = isTableSeparatorRow(cells)
+			default:
+				if row, ok := table.parseRow(cells); ok {
+					p.recordArgument(nested, row.name, row.description)
+					if arg := p.ret.Arguments[row.name]; nested == "" || arg.isNested {
+						arg.isRequired, arg.defaultValue = row.required, row.defaultValue
+					}
+				}
+			}
+			lastMatch, listParent, inSubList = "", "", false
+			continue
+		}
+		table, inTable = nil, false
+
+		if markdownHeaderRegexp.MatchString(line) {
+			// A header either introduces a nested block or is a purely prose subsection, in which case the arguments
+			// that follow it are not nested.
+			nested = p.resolveNestedSchemaName(anchor, p.getNestedBlockFromHeader(line))
+			lastMatch, siblings, inRequiredGroup, inReadOnlyGroup = "", nil, false, false
+			listParent, inSubList = "", false
+			if flags := parseParamFlagLiteral(strings.TrimSpace(strings.TrimLeft(line, "#"))); flags != nil {
+				// A header may also group the top-level arguments, e.g. "### Read-Only".
+				nested, inRequiredGroup, inReadOnlyGroup = "", *flags == required, *flags == readonly
+			}
+			continue
+		}
+
+		name, desc, deprecationMessage, docType, matchFound := parseArgFromMarkdownLine(line)
+
+		if matchFound && strings.HasSuffix(line, "supports the following:") {
+			// This bullet introduces a nested block rather than documenting an argument, e.g.
+			// "* `retention_policy` supports the following:"
+			if nestedBlockCurrentLine := getNestedBlockName(line); nestedBlockCurrentLine != "" {
+				nested = p.resolveNestedBlockName(nestedBlockCurrentLine)
+			} else {
+				nested = name
+			}
+			lastMatch, siblings, listParent, inSubList, inReadOnlyGroup = "", nil, "", false, false
+		} else if matchFound && inReadOnlyGroup {
+			// This bullet documents an attribute of the entity or of the nested block.
+			p.linkNestedSchema(nested, name, desc)
+			lastMatchIsTopLevel = p.recordAttribute(nested, name, cleanDesc(desc))
+			lastMatch = name
+		} else if matchFound && listParent != "" && isSubListBullet(line) {
+			// This bullet is nested under the previous top-level bullet, so it documents an argument of that bullet's
+			// block, e.g. "    * `enabled` - ..." under "* `logging` - ...". The block gets the same name that a
+			// later "The `logging` block supports:" intro would give it, so such an intro does not nest it again.
+			p.recordArgument(listParent, name, desc)
+			if arg := p.ret.Arguments[name]; arg.isNested && docType != "" {
+				arg.docType = docType
+			}
+			lastMatch, inSubList = name, true
+		} else if matchFound {
+			// found a property bullet, extract the name and description
+			p.recordArgument(nested, name, desc)
+			for _, sibling := range siblings {
+				p.recordArgument(sibling, name, desc)
+			}
+			if nested == "" && deprecationMessage != "" {
+				p.ret.Arguments[name].deprecationMessage = deprecationMessage
+			}
+			if arg := p.ret.Arguments[name]; nested == "" || arg.isNested {
+				if inRequiredGroup {
+					arg.isRequired = true
+				}
+				if docType != "" {
+					arg.docType = docType
+				}
+			}
+			lastMatch, inSubList = name, false
+			if !isSubListBullet(line) {
+				listParent = name
+			}
+		} else if linkFooterRegexp.MatchString(line) {
+			// A footer link definition, e.g. "[1]: https://example.com", is not part of any description. Lines that
+			// merely start with a footer reference, e.g. "[1] for details.", are continuations like any other.
+			lastMatch, listParent, inSubList = "", "", false
+		} else if !isBlank(line) && lastMatch != "" {
+			// this is a continuation of the previous bullet
+			if inReadOnlyGroup {
+				if nested != "" {
+					p.ret.NestedAttributes[nested][lastMatch] += "\n" + strings.TrimSpace(line)
+				}
+				if lastMatchIsTopLevel {
+					p.ret.Attributes[lastMatch] += "\n" + strings.TrimSpace(line)
+				}
+			} else if inSubList {
+				p.ret.Arguments[listParent].arguments[lastMatch] += "\n" + strings.TrimSpace(line)
+				if p.ret.Arguments[lastMatch].isNested {
+					p.ret.Arguments[lastMatch].description += "\n" + strings.TrimSpace(line)
+				}
+			} else if nested != "" {
+				p.ret.Arguments[nested].arguments[lastMatch] += "\n" + strings.TrimSpace(line)
+				for _, sibling := range siblings {
+					p.ret.Arguments[sibling].arguments[lastMatch] += "\n" + strings.TrimSpace(line)
+				}
+
+				// Also update the top-level argument if we took it from a nested field.
+				if p.ret.Arguments[lastMatch].isNested {
+					p.ret.Arguments[lastMatch].description += "\n" + strings.TrimSpace(line)
+				}
+			} else {
+				p.ret.Arguments[lastMatch].description += "\n" + strings.TrimSpace(line)
+			}
+		} else {
+			// This line might declare the beginning of one or more nested objects.
+			// If we do not find a "nested", then this is an empty line or there were no bullets yet.
+			if blocks := p.getNestedBlocks(line); len(blocks) != 0 {
+				nested, siblings, inReadOnlyGroup = blocks[0], blocks[1:], false
+			}
+
+			// Clear the lastMatch. A blank line does not end a list, so nested sub-list bullets may still follow it.
+			lastMatch, inSubList = "", false
+			if !isBlank(line) {
+				listParent = ""
+			}
+		}
+	}
+	return false
+}
+
+// isSubListBullet returns true if the given bullet is indented by at least four columns, which nests it in a sub-list
+// of the preceding bullet. Shallower indentation is common in lists that are not nested at all.
+func isSubListBullet(line string) bool {
+	indent := 0
+	for _, c := range line {
+		switch c {
+		case ' ':
+			indent++
+		case '\t':
+			indent += 4
+		default:
+			return indent >= 4
+		}
+	}
+	return false
+}
+
+var (
+	markdownHeaderRegexp = regexp.MustCompile(`^#{3,} `)
+	blockHeaderRegexp    = regexp.MustCompile(
+		"(?i)^#+ (?:Nested Schema for `[a-z0-9_.-]+`|`?[a-z0-9_-]+`? argument reference)\\s*$")
+	argHeaderDecorationRegexp = regexp.MustCompile(
+		`(?i)\s+(?:configuration block|block|object|arguments?(?: reference)?|supports(?: the following)?:?)\s*$`)
+)
+
+// getNestedBlockFromHeader classifies a header within an Argument Reference section. Headers that explicitly introduce
+// a block, e.g. "#### result_configuration Argument Reference" or "### Nested Schema for `settings`", always return the
+// name of the block. Other headers only do so if they name an argument that has already been parsed, e.g.
+// "### `settings` Configuration Block". If the header is a prose subsection, e.g. "### Advanced Options", an empty
+// string is returned so that no phantom block is created.
+func (p *tfMarkdownParser) getNestedBlockFromHeader(line string) string {
+	name := parseArgNameFromHeader(line)
+	if name == "" {
+		return ""
+	}
+	if blockHeaderRegexp.MatchString(line) {
+		return p.resolveNestedBlockName(name)
+	}
+	if matches := p.getMatchingArgNames(name); len(matches) != 0 {
+		return matches[0]
+	}
+	return ""
+}
+
+// parseArgNameFromHeader returns the name of the argument that a markdown header may describe, e.g.
+//
+// - "### `private_cluster_config` Configuration Block" -> "private_cluster_config"
+// - "#### result_configuration Argument Reference" -> "result_configuration"
+// - "### Advanced Options" -> "advanced_options"
+//
+// The returned name is only a candidate: whether it refers to an argument is up to the caller.
+func parseArgNameFromHeader(line string) string {
+	if name := getNestedBlockName(line); name != "" {
+		return name
+	}
+
+	header := strings.TrimSpace(strings.TrimLeft(line, "#"))
+	header = htmlAnchorPrefixRegexp.ReplaceAllString(header, "")
+	header = argHeaderDecorationRegexp.ReplaceAllString(header, "")
+	header = strings.ToLower(strings.Trim(header, "`* "))
+	return strings.Join(strings.Fields(header), "_")
+}
+
+// getMatchingArgNames returns the names of the already-parsed arguments that the given name refers to, if any. Hyphens
+// are treated as underscores, and a dotted name, e.g. "settings.backup_configuration", matches if its last element is
+// documented as an argument of its parent.
+func (p *tfMarkdownParser) getMatchingArgNames(name string) []string {
+	var matches []string
+	for _, candidate := range []string{name, strings.ReplaceAll(name, "-", "_")} {
+		if len(matches) != 0 && matches[0] == candidate {
+			continue
+		}
+		if p.ret.Arguments[candidate] != nil {
+			matches = append(matches, candidate)
+			continue
+		}
+		if i := strings.LastIndex(candidate, "."); i != -1 {
+			if parent := p.ret.Arguments[candidate[:i]]; parent != nil {
+				if _, ok := parent.arguments[candidate[i+1:]]; ok {
+					matches = append(matches, candidate)
+				}
+			}
+		}
+	}
+	return matches
+}
+
+var (
+	// validValuesTriggerRegexp matches the phrases that introduce an enumeration of valid values. The number of values
+	// required for the enumeration to be accepted is keyed by the phrase: "one of" is used often enough in prose to
+	// require at least two values.
+	validValuesTriggerRegexp = regexp.MustCompile(
+		`(?i)\b(?:(valid|possible|allowed|supported) values(?: are| include)?|(one of))(?: the following)?\s*:?\s*`)
+	validValueRegexp          = regexp.MustCompile("^\\s*`\"?([^`\"]+)\"?`")
+	validValueSeparatorRegexp = regexp.MustCompile(`^\s*(?:,\s*(?:or|and)?|or|and)\s`)
+)
+
+// parseValidValues heuristically extracts an enumeration of valid values from an argument's description, e.g.
+// ["BLOCK", "ALLOW", "COUNT"] from "valid values are: `BLOCK`, `ALLOW`, or `COUNT`". Only code spans that immediately
+// follow a trigger phrase such as "valid values are" or "one of" are considered, so that code spans that are merely
+// examples are not mistaken for an enumeration.
+func parseValidValues(desc string) []string {
+	for _, loc := range validValuesTriggerRegexp.FindAllStringSubmatchIndex(desc, -1) {
+		minValues := 1
+		if loc[4] != -1 {
+			// "one of"
+			minValues = 2
+		}
+
+		var values []string
+		rest := desc[loc[1]:]
+		for {
+			m := validValueRegexp.FindStringSubmatchIndex(rest)
+			if m == nil {
+				break
+			}
+			values, rest = append(values, rest[m[2]:m[3]]), rest[m[1]:]
+
+			sep := validValueSeparatorRegexp.FindStringIndex(rest)
+			if sep == nil {
+				break
+			}
+			rest = rest[sep[1]-1:]
+		}
+		if len(values) >= minValues {
+			return values
+		}
+	}
+	return nil
+}
+
+// leadingDefaultRegexp matches the phrase that opens a description that starts by describing a default in prose.
+var leadingDefaultRegexp = regexp.MustCompile(`^[Dd]efaults to\s`)
+
+// parseLeadingDefault returns the first sentence of an argument's description if the description opens with a prose
+// default, e.g. "Defaults to the region of the provider." from "Defaults to the region of the provider. The region in
+// which to create the bucket.". Such defaults are not structured values, so they are recorded as written. To stay
+// conservative, a "Defaults to" that appears later in the description is not considered.
+func parseLeadingDefault(desc string) string {
+	desc = strings.TrimSpace(desc)
+	if !leadingDefaultRegexp.MatchString(desc) {
+		return ""
+	}
+	return strings.TrimSpace(desc[:firstSentenceEnd(desc)])
+}
+
+// firstSentenceEnd returns the index just past the period that ends the first sentence of the given text, or the
+// length of the text if the text is a single sentence. Periods within code spans and in "e.g." or "i.e." do not end
+// a sentence.
+func firstSentenceEnd(text string) int {
+	inCode := false
+	for i := 0; i < len(text); i++ {
+		switch text[i] {
+		case '`':
+			inCode = !inCode
+		case '.':
+			if inCode || i+1 < len(text) && text[i+1] != ' ' && text[i+1] != '\n' && text[i+1] != '\t' {
+				continue
+			}
+			if lower := strings.ToLower(text[:i+1]); strings.HasSuffix(lower, "e.g.") ||
+				strings.HasSuffix(lower, "i.e.") {
+				continue
+			}
+			return i + 1
+		}
+	}
+	return len(text)
 }
 
-// parseArgFromMarkdownLine takes a line of Markdown and attempts to parse it for a Terraform argument and its
-// description
-func parseArgFromMarkdownLine(line string) (string, string, bool) {
-	argumentBulletRegexp = regexp.MustCompile(
-		"^\\s*[*+-]\\s+`([a-zA-z0-9_]*)`\\s*(\\([a-zA-Z]*\\)\\s*)?[–-]?\\s+(\\([^\\)]*\\)\\s*)?(.*)")
-
-	matches := argumentBulletRegexp.FindStringSubmatch(line)
-
-	if len(matches) > 4 {
-		return matches[1], matches[4], true
+// deeplyNestedArguments returns the sorted, dotted paths of the parsed arguments that are nested more than limit levels
+// deep. A top-level argument has a depth of 1, and each argument of a nested block is one level deeper than the block.
+func deeplyNestedArguments(arguments map[string]*argumentDocs, limit int) []string {
+	paths := map[string]bool{}
+	for name, arg := range arguments {
+		depth := strings.Count(name, ".") + 1
+		if depth > limit {
+			paths[name] = true
+		}
+		if depth+1 > limit {
+			for nested := range arg.arguments {
+				paths[name+"."+nested] = true
+			}
+		}
 	}
 
-	return "", "", false
+	sorted := make([]string, 0, len(paths))
+	for path := range paths {
+		sorted = append(sorted, path)
+	}
+	sort.Strings(sorted)
+	return sorted
 }
 
-// getNestedBlockName take a line of a Terraform docs Markdown page and returns the name of the nested block it
-// describes. If the line does not describe a nested block, an empty string is returned.
-//
-// Examples of nested blocks include (but are not limited to):
-//
-// - "The `private_cluster_config` block supports:" -> "private_cluster_config"
-// - "The optional settings.backup_configuration subblock supports:" -> "settings.backup_configuration"
-func getNestedBlockName(line string) string {
-	nested := ""
-
-	nestedObjectRegexps := []*regexp.Regexp{
-		// For example:
-		// s3_bucket.html.markdown: "The `website` object supports the following:"
-		// ami.html.markdown: "When `virtualization_type` is "hvm" the following additional arguments apply:"
-		regexp.MustCompile("`([a-z_]+)`.*following"),
+// recordArgument records the description of an argument. If nested is not empty, the argument is recorded as an
+// argument of the nested block.
+func (p *tfMarkdownParser) recordArgument(nested, name, desc string) {
+	// Drop the pointers to nested schemata used by docs in the format of terraform-plugin-framework providers, but
+	// remember which nested schema each points to.
+	if seeBelowPattern.MatchString(desc) {
+		p.linkNestedSchema(nested, name, desc)
+		desc = cleanDesc(desc)
+	}
 
-		// For example:
-		// athena_workgroup.html.markdown: "#### result_configuration Argument Reference"
-		regexp.MustCompile("(?i)## ([a-z_]+).* argument reference"),
+	if nested == "" {
+		p.ret.Arguments[name] = &argumentDocs{description: desc}
+		totalArgumentsFromDocs++
+		return
 	}
 
-	for _, match := range nestedObjectRegexps {
-		matches := match.FindStringSubmatch(line)
-		if len(matches) >= 2 {
-			nested = strings.ToLower(matches[1])
-			break
+	// We found this line within a nested field. We should record it as such.
+	if p.ret.Arguments[nested] == nil {
+		p.ret.Arguments[nested] = &argumentDocs{
+			arguments: make(map[string]string),
+		}
+		totalArgumentsFromDocs++
+	} else if p.ret.Arguments[nested].arguments == nil {
+		p.ret.Arguments[nested].arguments = make(map[string]string)
+	}
+	p.ret.Arguments[nested].arguments[name] = desc
+
+	// Also record this as a top-level argument just in case, since sometimes the recorded nested
+	// argument doesn't match the resource's argument.
+	// For example, see `cors_rule` in s3_bucket.html.markdown.
+	if p.ret.Arguments[name] == nil {
+		p.ret.Arguments[name] = &argumentDocs{
+			description: desc,
+			isNested:    true, // Mark that this argument comes from a nested field.
 		}
 	}
-
-	return nested
 }
 
-func (p *tfMarkdownParser) parseArgReferenceSection(subsection []string) {
+func (p *tfMarkdownParser) parseAttributesReferenceSection(subsection []string) {
 	var lastMatch, nested string
+	var lastMatchIsTopLevel bool
 	for _, line := range subsection {
-		name, desc, matchFound := parseArgFromMarkdownLine(line)
-
-		if matchFound {
+		anchor := p.trackAnchor(line)
+		matches := attributeBulletRegexp.FindStringSubmatch(line)
+		if len(matches) >= 2 {
 			// found a property bullet, extract the name and description
-			if nested != "" {
-				// We found this line within a nested field. We should record it as such.
-				if p.ret.Arguments[nested] == nil {
-					p.ret.Arguments[nested] = &argumentDocs{
-						arguments: make(map[string]string),
-					}
-					totalArgumentsFromDocs++
-				} else if p.ret.Arguments[nested].arguments == nil {
-					p.ret.Arguments[nested].arguments = make(map[string]string)
-				}
-				p.ret.Arguments[nested].arguments[name] = desc
-
-				// Also record this as a top-level argument just in case, since sometimes the recorded nested
-				// argument doesn't match the resource's argument.
-				// For example, see `cors_rule` in s3_bucket.html.markdown.
-				if p.ret.Arguments[name] == nil {
-					p.ret.Arguments[name] = &argumentDocs{
-						description: desc,
-						isNested:    true, // Mark that this argument comes from a nested field.
-					}
-				}
-			} else {
-				if !strings.HasSuffix(line, "supports the following:") {
-					p.ret.Arguments[name] = &argumentDocs{description: desc}
-					totalArgumentsFromDocs++
-				}
-			}
-			lastMatch = name
+			p.linkNestedSchema(nested, matches[1], matches[3])
+			desc := cleanDesc(frameworkTypeDeclRegexp.ReplaceAllString(matches[3], ""))
+			lastMatch, lastMatchIsTopLevel = matches[1], p.recordAttribute(nested, matches[1], desc)
 		} else if !isBlank(line) && lastMatch != "" {
 			// this is a continuation of the previous bullet
 			if nested != "" {
-				p.ret.Arguments[nested].arguments[lastMatch] += "\n" + strings.TrimSpace(line)
-
-				// Also update the top-level argument if we took it from a nested field.
-				if p.ret.Arguments[lastMatch].isNested {
-					p.ret.Arguments[lastMatch].description += "\n" + strings.TrimSpace(line)
-				}
-			} else {
-				p.ret.Arguments[lastMatch].description += "\n" + strings.TrimSpace(line)
+				p.ret.NestedAttributes[nested][lastMatch] += "\n" + strings.TrimSpace(line)
+			}
+			if lastMatchIsTopLevel {
+				p.ret.Attributes[lastMatch] += "\n" + strings.TrimSpace(line)
 			}
 		} else {
-			// This line might declare the beginning of a nested object.
-			// If we do not find a "nested", then this is an empty line or there were no bullets yet.
-			nestedBlockCurrentLine := getNestedBlockName(line)
-
-			if nestedBlockCurrentLine != "" {
-				nested = nestedBlockCurrentLine
+			// This line might declare the beginning of a nested block, e.g.
+			// "For `environment` the following attributes are supported:".
+			// Otherwise, this is an empty line or there were no bullets yet.
+			if nestedBlockCurrentLine := getNestedBlockName(line); nestedBlockCurrentLine != "" {
+				nested = p.resolveNestedSchemaName(anchor, p.resolveNestedBlockName(nestedBlockCurrentLine))
 			}
 
 			// Clear the lastMatch.
@@ -857,22 +2279,74 @@ func (p *tfMarkdownParser) parseArgReferenceSection(subsection []string) {
 	}
 }
 
-func (p *tfMarkdownParser) parseAttributesReferenceSection(subsection []string) {
-	var lastMatch string
-	for _, line := range subsection {
-		matches := attributeBulletRegexp.FindStringSubmatch(line)
-		if len(matches) >= 2 {
-			// found a property bullet, extract the name and description
-			p.ret.Attributes[matches[1]] = matches[2]
-			lastMatch = matches[1]
-		} else if !isBlank(line) && lastMatch != "" {
-			// this is a continuation of the previous bullet
-			p.ret.Attributes[lastMatch] += "\n" + strings.TrimSpace(line)
-		} else {
-			// This is an empty line or there were no bullets yet - clear the lastMatch
-			lastMatch = ""
-		}
+var htmlAnchorLineRegexp = regexp.MustCompile(`^\s*<a (?:name|id)="([^"]*)">\s*</a>\s*$`)
+
+// trackAnchor updates the anchor that precedes the current line and returns the anchor that preceded the given line.
+// Blank lines do not separate an anchor from the header that it names.
+func (p *tfMarkdownParser) trackAnchor(line string) string {
+	anchor := p.anchor
+	if matches := htmlAnchorLineRegexp.FindStringSubmatch(line); matches != nil {
+		p.anchor = matches[1]
+	} else if !isBlank(line) {
+		p.anchor = ""
+	}
+	return anchor
+}
+
+// linkNestedSchema records the nested schema that the description of an argument or attribute links to, if any, e.g.
+// "nestedatt--settings" for "(see [below for nested schema](#nestedatt--settings))".
+func (p *tfMarkdownParser) linkNestedSchema(nested, name, desc string) {
+	id := nestedSchemaLinkID(desc)
+	if id == "" {
+		return
+	}
+	if nested != "" {
+		name = nested + "." + name
+	}
+	if p.nestedSchemaLinks == nil {
+		p.nestedSchemaLinks = map[string]string{}
 	}
+	p.nestedSchemaLinks[id] = name
+}
+
+// resolveNestedSchemaName returns the path of the argument or attribute that links to the given anchor, which precedes
+// the header of a nested schema, e.g. "settings.backup_configuration" for "nestedatt--settings--backup_configuration".
+// Headers may abbreviate the path of the schema's block, so the link takes precedence. If no link points to the
+// anchor, or the header does not introduce a nested schema, the name from the header is returned unchanged.
+func (p *tfMarkdownParser) resolveNestedSchemaName(anchor, name string) string {
+	if name == "" || anchor == "" {
+		return name
+	}
+	if path, ok := p.nestedSchemaLinks[anchor]; ok {
+		return path
+	}
+	return name
+}
+
+// frameworkTypeDeclRegexp matches the type declaration that prefixes the description of each attribute in docs in the
+// format used by terraform-plugin-framework providers, e.g. "(String)" or "(Attributes List)".
+var frameworkTypeDeclRegexp = regexp.MustCompile(
+	`^\((?:String|Number|Boolean|Bool|Dynamic|Object|Attributes|Block|List|Set|Map)\b[^)]*\)\s*`)
+
+// recordAttribute records the description of an attribute. If nested is not empty, the attribute is recorded as an
+// attribute of the nested block, and also as a top-level attribute if there is none of the same name. It returns true
+// if the attribute was recorded as a top-level attribute.
+func (p *tfMarkdownParser) recordAttribute(nested, name, desc string) bool {
+	if nested == "" {
+		p.ret.Attributes[name] = desc
+		return true
+	}
+
+	if p.ret.NestedAttributes[nested] == nil {
+		p.ret.NestedAttributes[nested] = make(map[string]string)
+	}
+	p.ret.NestedAttributes[nested][name] = desc
+
+	if _, ok := p.ret.Attributes[name]; ok {
+		return false
+	}
+	p.ret.Attributes[name] = desc
+	return true
 }
 
 func (p *tfMarkdownParser) parseImports(subsection []string) {
@@ -912,6 +2386,7 @@ func (p *tfMarkdownParser) parseImports(subsection []string) {
 			// Next, remove `terraform import` from the codeblock
 			section = strings.Replace(section, "terraform import ", "", -1)
 			importString := ""
+			var importID []string
 			parts := strings.Split(section, " ")
 			for i, p := range parts {
 				switch i {
@@ -926,9 +2401,13 @@ func (p *tfMarkdownParser) parseImports(subsection []string) {
 				default:
 					if !isBlank(p) {
 						importString = fmt.Sprintf("%s %s", importString, p)
+						importID = append(importID, strings.TrimSpace(p))
 					}
 				}
 			}
+			if p.ret.ImportID == "" {
+				p.ret.ImportID = strings.Join(importID, " ")
+			}
 			var tok string
 			if p.info != nil && p.info.GetTok() != "" {
 				tok = p.info.GetTok().String()
@@ -938,8 +2417,7 @@ func (p *tfMarkdownParser) parseImports(subsection []string) {
 			// We are going to use a placeholder here for the linebreak so that when we get into converting examples
 			// we can format our Import section outside of the examples section
 			importCommand := fmt.Sprintf("$ pulumi import %s%s", tok, importString)
-			importDetails := []string{"<break><break>```sh<break>", importCommand, "<break>```<break><break>"}
-			importDocString = append(importDocString, importDetails...)
+			importDocString = append(importDocString, importCommandDetails(importCommand)...)
 		} else {
 			if !isBlank(section) {
 				importDocString = append(importDocString, section)
@@ -952,22 +2430,56 @@ func (p *tfMarkdownParser) parseImports(subsection []string) {
 	}
 }
 
-func (p *tfMarkdownParser) parseFrontMatter(subsection []string) {
-	// The header of the MarkDown will have two "---"s paired up to delineate the header. Skip this.
-	var foundEndHeader bool
-	for len(subsection) > 0 {
-		curr := subsection[0]
-		subsection = subsection[1:]
-		if curr == "---" {
-			foundEndHeader = true
-			break
-		}
+// importCommandDetails returns the import details that hold the given `pulumi import` command. We use a placeholder
+// for the linebreaks so that the import section can be formatted outside of the examples section.
+func importCommandDetails(importCommand string) []string {
+	return []string{"<break><break>```sh<break>", importCommand, "<break>```<break><break>"}
+}
+
+// frontMatterDelimiter opens and closes the YAML front matter of a doc.
+const frontMatterDelimiter = "---"
+
+// frontMatterLineRegexp matches the lines that may appear in front matter: a field, the continuation of a field's
+// value (which is indented), a comment, or a blank line.
+var frontMatterLineRegexp = regexp.MustCompile(`^(?:[A-Za-z0-9_-]+:.*|\s+.*|#.*|)$`)
+
+// frontMatterFieldRegexp matches a front matter field with a scalar value, e.g. `subcategory: "Storage"`.
+var frontMatterFieldRegexp = regexp.MustCompile(`^([A-Za-z0-9_-]+):\s*(.*?)\s*$`)
+
+// extractFrontMatter extracts the YAML front matter that opens the given markdown, if any, and returns its scalar
+// fields along with the markdown that follows it. The markdown is only considered to have front matter if its first
+// line is a "---" delimiter that is closed by another before any line that cannot be part of the front matter, so that
+// a later "---", e.g. a horizontal rule, is never mistaken for front matter.
+func extractFrontMatter(markdown string) (map[string]string, string, bool) {
+	lines := strings.Split(markdown, "\n")
+	if len(lines) == 0 || strings.TrimSpace(lines[0]) != frontMatterDelimiter {
+		return nil, markdown, false
 	}
-	if !foundEndHeader {
-		p.g.warn("", "Expected to pair --- begin/end for resource %v's Markdown header", p.rawname)
+
+	fields := map[string]string{}
+	for i, line := range lines[1:] {
+		if strings.TrimSpace(line) == frontMatterDelimiter {
+			return fields, strings.Join(lines[i+2:], "\n"), true
+		}
+		if !frontMatterLineRegexp.MatchString(line) {
+			return nil, markdown, false
+		}
+		// Block scalars, which begin with "|" or ">", and fields without a value on the same line are not recorded.
+		if m := frontMatterFieldRegexp.FindStringSubmatch(line); m != nil && m[2] != "" && m[2][0] != '|' &&
+			m[2][0] != '>' {
+			value := m[2]
+			if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') && value[len(value)-1] == value[0] {
+				value = value[1 : len(value)-1]
+			}
+			fields[m[1]] = value
+		}
 	}
+	return nil, markdown, false
+}
 
-	// Now extract the description section. We assume here that the first H1 (line starting with #) is the name
+// parseFrontMatter parses the section that opens the doc, which follows its front matter, into the description.
+func (p *tfMarkdownParser) parseFrontMatter(subsection []string) {
+	// Extract the description section. We assume here that the first H1 (line starting with #) is the name
 	// of the resource, because we aren't detecting code fencing. Comments in HCL are prefixed with # (the
 	// same as H1 in Markdown, so we treat further H1's in this section as part of the description. If there
 	// are no matching H1s, we emit a warning for the resource as it is likely a problem with the documentation.
@@ -985,7 +2497,7 @@ func (p *tfMarkdownParser) parseFrontMatter(subsection []string) {
 		}
 	}
 	if !foundH1Resource {
-		p.g.warn("Expected an H1 in markdown for resource %v", p.rawname)
+		p.g.warnFor(p.rawname, "", "Expected an H1 in markdown for resource %v", p.rawname)
 	}
 }
 
@@ -1100,7 +2612,7 @@ func (g *Generator) convertExamples(docs, name string, stripSubsectionsWithError
 							exampleTitle = strings.Replace(subsection[0], "### ", "", -1)
 						}
 
-						langs := genLanguageToSlice(g.language)
+						langs := g.languagesToConvert()
 						codeBlock, err := g.convertHCL(hcl, name, exampleTitle, langs)
 
 						if err != nil {
@@ -1172,24 +2684,91 @@ func (g *Generator) convertExamples(docs, name string, stripSubsectionsWithError
 	return output.String()
 }
 
-// convert wraps convert.Convert so that it returns an error in the event of a panic in convert.Convert
+// conversionTimeoutError is returned by convert when the conversion of an example outlives the generator's
+// exampleConversionTimeout, or when the conversion is skipped because an earlier one did.
+type conversionTimeoutError struct {
+	timeout time.Duration
+	// skipped is true if the conversion was not attempted because an earlier conversion timed out.
+	skipped bool
+}
+
+func (e *conversionTimeoutError) Error() string {
+	if e.skipped {
+		return fmt.Sprintf("conversion skipped because an earlier conversion timed out after %v", e.timeout)
+	}
+	return fmt.Sprintf("conversion timed out after %v", e.timeout)
+}
+
+// isConversionTimeout returns true if err is, or wraps, a conversionTimeoutError.
+func isConversionTimeout(err error) bool {
+	var timeoutErr *conversionTimeoutError
+	return errors.As(err, &timeoutErr)
+}
+
+// hclConversionResult holds the outcome of a single call to the generator's HCLConverter.
+type hclConversionResult struct {
+	files map[string][]byte
+	diags convert.Diagnostics
+	err   error
+	// panicValue is the value with which the conversion panicked, if any.
+	panicValue interface{}
+}
+
+// convert wraps the generator's HCLConverter so that it returns an error in the event of a panic in the conversion or,
+// if the generator has an exampleConversionTimeout, in the event that the conversion takes too long. A conversion that
+// times out is abandoned rather than cancelled, as the converter offers no means of cancellation. The abandoned
+// conversion may keep running against the generator's shared conversion state indefinitely, so every later conversion
+// is skipped with a conversionTimeoutError rather than started alongside it or made to wait for it.
 //
-// Note: If this issue is fixed, the call to convert.Convert can be unwrapped and this function can be deleted:
+// Note: If this issue is fixed, the panic recovery can be removed:
 // https://github.com/pulumi/pulumi-terraform-bridge/issues/477
-func (g *Generator) convert(input afero.Fs, languageName string) (files map[string][]byte, diags convert.Diagnostics,
-	err error) {
-	defer func() {
-		v := recover()
-		if v != nil {
-			files = map[string][]byte{}
-			diags = convert.Diagnostics{}
-			err = fmt.Errorf("panic converting HCL: %v", v)
+func (g *Generator) convert(input afero.Fs, languageName string) (map[string][]byte, convert.Diagnostics, error) {
+	var result hclConversionResult
+	if g.conversionAbandoned {
+		return map[string][]byte{}, convert.Diagnostics{},
+			&conversionTimeoutError{timeout: g.exampleConversionTimeout, skipped: true}
+	}
+
+	if g.exampleConversionTimeout <= 0 {
+		result = g.runHCLConverter(input, languageName)
+	} else {
+		timer := time.NewTimer(g.exampleConversionTimeout)
+		defer timer.Stop()
+
+		done := make(chan hclConversionResult, 1)
+		go func() {
+			done <- g.runHCLConverter(input, languageName)
+		}()
+
+		select {
+		case result = <-done:
+		case <-timer.C:
+			g.conversionAbandoned = true
+			return map[string][]byte{}, convert.Diagnostics{},
+				&conversionTimeoutError{timeout: g.exampleConversionTimeout}
+		}
+	}
+
+	if result.panicValue != nil {
+		g.coverageTracker.languageConversionPanic(languageName, fmt.Sprintf("%v", result.panicValue))
+		return map[string][]byte{}, convert.Diagnostics{}, fmt.Errorf("panic converting HCL: %v", result.panicValue)
+	}
+	return result.files, result.diags, result.err
+}
 
-			g.coverageTracker.languageConversionPanic(languageName, fmt.Sprintf("%v", v))
+// runHCLConverter calls the generator's HCLConverter, recovering from any panic in the conversion.
+func (g *Generator) runHCLConverter(input afero.Fs, languageName string) (result hclConversionResult) {
+	defer func() {
+		if v := recover(); v != nil {
+			result = hclConversionResult{panicValue: v}
 		}
 	}()
 
-	files, diags, err = convert.Convert(convert.Options{
+	converter := g.hclConverter
+	if converter == nil {
+		converter = DefaultHCLConverter()
+	}
+	files, diags, err := converter.Convert(convert.Options{
 		Loader:                   newLoader(g.pluginHost),
 		Root:                     input,
 		TargetLanguage:           languageName,
@@ -1202,8 +2781,7 @@ func (g *Generator) convert(input afero.Fs, languageName string) (files map[stri
 		SkipResourceTypechecking: true,
 		TerraformVersion:         g.terraformVersion,
 	})
-
-	return
+	return hclConversionResult{files: files, diags: diags, err: err}
 }
 
 // convertHCLToString hides the implementation details of the upstream implementation for HCL conversion and provides
@@ -1222,8 +2800,8 @@ func (g *Generator) convertHCLToString(hcl, path, languageName string) (string,
 	// By observation on the GCP provider, convert.Convert() will either panic (in which case the wrapped method above
 	// will return an error) or it will return a non-zero value for diags.
 	if err != nil {
-		// Because this condition is presumably the result of a panic that we wrap as an error, we do not need to add
-		// anything to g.coverageTracker - that's covered in the panic recovery above.
+		// Because this condition is presumably the result of a panic or a timeout that we wrap as an error, we do not
+		// need to add anything to g.coverageTracker - panics are covered by convert above.
 		return "", fmt.Errorf("failed to convert HCL for %s to %v: %w", path, languageName, err)
 	}
 	if diags.All.HasErrors() {
@@ -1292,10 +2870,30 @@ func (s languages) Less(i, j int) bool {
 	}
 }
 
+// filterLanguages returns the languages in langs that also appear in filter, in their original order. If filter is
+// empty, langs is returned as-is.
+func filterLanguages(langs, filter []string) []string {
+	if len(filter) == 0 {
+		return langs
+	}
+
+	var filtered []string
+	for _, lang := range langs {
+		for _, f := range filter {
+			if lang == f {
+				filtered = append(filtered, lang)
+				break
+			}
+		}
+	}
+	return filtered
+}
+
 // hclMapToString takes a map of hclConversions to various languages and returns a single Markdown string for use in
 // Pulumi's docs. The key in hclConversions is expected to match the language hint in the generated code fences, e.g.
-// "typescript" -> ```typescript, and the corresponding value is expected to be the converted code.
-func hclConversionsToString(hclConversions map[string]string) string {
+// "typescript" -> ```typescript, and the corresponding value is expected to be the converted code. If filter is not
+// empty, only the conversions to the languages in filter are emitted.
+func hclConversionsToString(hclConversions map[string]string, filter []string) string {
 	var result strings.Builder
 
 	// We have to use a custom comparator to get the keys to iterate in a deterministic order. We need a deterministic
@@ -1305,6 +2903,7 @@ func hclConversionsToString(hclConversions map[string]string) string {
 	for k := range hclConversions {
 		keys = append(keys, k)
 	}
+	keys = filterLanguages(keys, filter)
 	sort.Sort(keys)
 
 	for _, key := range keys {
@@ -1332,6 +2931,11 @@ func hclConversionsToString(hclConversions map[string]string) string {
 // If some languages fail to convert, the returned string contain any successful conversions and no error will be
 // returned, but conversion failures will be logged via the Generator.
 func (g *Generator) convertHCL(hcl, path, exampleTitle string, languages []string) (string, error) {
+	// There is nothing to convert if the example language filter excludes every language.
+	if len(languages) == 0 {
+		return "", nil
+	}
+
 	g.debug("converting HCL for %s", path)
 
 	// Fixup the HCL as necessary.
@@ -1348,13 +2952,32 @@ func (g *Generator) convertHCL(hcl, path, exampleTitle string, languages []strin
 	for _, lang := range languages {
 		var convertErr error
 		hclConversions[lang], convertErr = g.convertHCLToString(hcl, path, lang)
+		if isConversionTimeout(convertErr) {
+			// The timed-out conversion is abandoned, and every later conversion is skipped. Only the timeout itself is
+			// reported, as the skipped examples would otherwise each produce a warning.
+			hclAllLangsConversionFailures++
+			var timeoutErr *conversionTimeoutError
+			if errors.As(convertErr, &timeoutErr) && timeoutErr.skipped {
+				return "", convertErr
+			}
+			if exampleTitle == "" {
+				g.warn(fmt.Sprintf("timed out converting HCL example for Pulumi entity '%s' to %s after %v. The "+
+					"example and all later examples will be dropped from any generated docs or SDKs.", path, lang,
+					g.exampleConversionTimeout))
+			} else {
+				g.warn(fmt.Sprintf("timed out converting HCL example '%s' for Pulumi entity '%s' to %s after %v. The "+
+					"example and all later examples will be dropped from any generated docs or SDKs.", exampleTitle,
+					path, lang, g.exampleConversionTimeout))
+			}
+			return "", convertErr
+		}
 		if convertErr != nil {
 			failedLangs[lang] = convertErr
 			err = multierror.Append(err, convertErr)
 		}
 	}
 
-	result.WriteString(hclConversionsToString(hclConversions))
+	result.WriteString(hclConversionsToString(hclConversions, g.exampleLanguages))
 
 	if len(failedLangs) == len(languages) {
 		hclAllLangsConversionFailures++
@@ -1407,6 +3030,12 @@ func (g *Generator) convertHCL(hcl, path, exampleTitle string, languages []strin
 	return result.String(), nil
 }
 
+// languagesToConvert returns the languages to which examples are converted: those of the generator's target language
+// that pass its example language filter, if any.
+func (g *Generator) languagesToConvert() []string {
+	return filterLanguages(genLanguageToSlice(g.language), g.exampleLanguages)
+}
+
 // genLanguageToSlice maps a Language on a Generator to a slice of strings suitable to pass to HCL conversion.
 func genLanguageToSlice(input Language) []string {
 	switch input {
@@ -1435,55 +3064,116 @@ func genLanguageToSlice(input Language) []string {
 	}
 }
 
-func cleanupDoc(name string, g *Generator, doc entityDocs, footerLinks map[string]string) (entityDocs, bool) {
+// cleanupDoc reformats the text of each part of doc. Any description that contains an <elided> reference is dropped,
+// or, if elidedReplacement is not nil, replaced with the result of calling elidedReplacement with the path of the
+// description. The path of the entity's own description is its name; the paths of argument, nested argument, and
+// attribute descriptions are the dotted paths to those properties, e.g. "aws_s3_bucket.website.index_document".
+// References to the entity's renamed fields are rewritten according to fieldRenames; see getFieldRenames.
+func cleanupDoc(name string, g *Generator, doc entityDocs, footerLinks, fieldRenames map[string]string,
+	elidedReplacement func(path string) string) (entityDocs, bool) {
+
 	elidedDoc := false
 	newargs := make(map[string]*argumentDocs, len(doc.Arguments))
 
+	// fate describes what happens to an elided description for the sake of our warnings.
+	fate := "dropped"
+	if elidedReplacement != nil {
+		fate = "replaced"
+	}
+	replaceElided := func(path string) string {
+		if elidedReplacement == nil {
+			return ""
+		}
+		return elidedReplacement(path)
+	}
+
+	// The descriptions of arguments only rewrite quoted references to the names of the entity's arguments.
+	arguments := make(map[string]bool, len(doc.Arguments))
 	for k, v := range doc.Arguments {
+		arguments[k] = true
+		for kk := range v.arguments {
+			arguments[kk] = true
+		}
+	}
+
+	// Arguments and attributes are visited in lexical order so that the warnings that are recorded along the way are
+	// deterministic.
+	for _, k := range sortedKeys(doc.Arguments) {
+		v := doc.Arguments[k]
 		g.debug("Cleaning up text for argument [%v] in [%v]", k, name)
-		cleanedText, elided := reformatText(g, v.description, footerLinks)
+		cleanedText, elided := reformatArgumentText(g, v.description, footerLinks, fieldRenames, arguments)
 		if elided {
 			elidedArguments++
-			g.warn("Found <elided> in docs for argument [%v] in [%v]. The argument's description will be dropped in "+
-				"the Pulumi provider.", k, name)
+			g.warnFor(name, k, "Found <elided> in docs for argument [%v] in [%v]. The argument's description will be %s in "+
+				"the Pulumi provider.", k, name, fate)
 			elidedDoc = true
+			cleanedText = replaceElided(name + "." + k)
 		}
 
 		newargs[k] = &argumentDocs{
-			description: cleanedText,
-			arguments:   make(map[string]string, len(v.arguments)),
-			isNested:    v.isNested,
+			description:        cleanedText,
+			arguments:          make(map[string]string, len(v.arguments)),
+			isNested:           v.isNested,
+			deprecationMessage: v.deprecationMessage,
+			validValues:        v.validValues,
+			isRequired:         v.isRequired,
+			docType:            v.docType,
+			defaultDescription: v.defaultDescription,
+			defaultValue:       v.defaultValue,
 		}
 
 		// Clean nested arguments (if any)
-		for kk, vv := range v.arguments {
+		for _, kk := range sortedKeys(v.arguments) {
+			vv := v.arguments[kk]
 			g.debug("Cleaning up text for nested argument [%v] in [%v]", kk, name)
-			cleanedText, elided := reformatText(g, vv, footerLinks)
+			cleanedText, elided := reformatArgumentText(g, vv, footerLinks, fieldRenames, arguments)
 			if elided {
 				elidedNestedArguments++
-				g.warn("Found <elided> in docs for nested argument [%v] in [%v]. The argument's description will be "+
-					"dropped in the Pulumi provider.", kk, name)
+				g.warnFor(name, k+"."+kk, "Found <elided> in docs for nested argument [%v] in [%v]. The argument's "+
+					"description will be %s in the Pulumi provider.", kk, name, fate)
 				elidedDoc = true
+				cleanedText = replaceElided(name + "." + k + "." + kk)
 			}
 			newargs[k].arguments[kk] = cleanedText
 		}
 	}
 
+	newnestedattrs := make(map[string]map[string]string, len(doc.NestedAttributes))
+	for _, k := range sortedKeys(doc.NestedAttributes) {
+		v := doc.NestedAttributes[k]
+		newnestedattrs[k] = make(map[string]string, len(v))
+		for _, kk := range sortedKeys(v) {
+			vv := v[kk]
+			g.debug("Cleaning up text for nested attribute [%v] in [%v]", kk, name)
+			cleanedText, elided := reformatText(g, vv, footerLinks, fieldRenames)
+			if elided {
+				elidedAttributes++
+				g.warnFor(name, k+"."+kk, "Found <elided> in docs for nested attribute [%v] in [%v]. The attribute's "+
+					"description will be %s in the Pulumi provider.", kk, name, fate)
+				elidedDoc = true
+				cleanedText = replaceElided(name + "." + k + "." + kk)
+			}
+			newnestedattrs[k][kk] = cleanedText
+		}
+	}
+
 	newattrs := make(map[string]string, len(doc.Attributes))
-	for k, v := range doc.Attributes {
+	for _, k := range sortedKeys(doc.Attributes) {
+		v := doc.Attributes[k]
 		g.debug("Cleaning up text for attribute [%v] in [%v]", k, name)
-		cleanedText, elided := reformatText(g, v, footerLinks)
+		cleanedText, elided := reformatText(g, v, footerLinks, fieldRenames)
 		if elided {
 			elidedAttributes++
-			g.warn("Found <elided> in docs for attribute [%v] in [%v]. The attribute's description will be dropped "+
-				"in the Pulumi provider.", k, name)
+			g.warnFor(name, k, "Found <elided> in docs for attribute [%v] in [%v]. The attribute's description will be %s "+
+				"in the Pulumi provider.", k, name, fate)
 			elidedDoc = true
+			cleanedText = replaceElided(name + "." + k)
 		}
 		newattrs[k] = cleanedText
 	}
 
 	g.debug("Cleaning up description text for [%v]", name)
-	cleanupText, elided := reformatText(g, doc.Description, footerLinks)
+	cleanupText, elided := reformatText(g, doc.Description, footerLinks, fieldRenames)
 	if elided {
 		g.debug("Found <elided> in the description. Attempting to extract examples from the description and " +
 			"reformat examples only.")
@@ -1495,35 +3185,117 @@ func cleanupDoc(name string, g *Generator, doc entityDocs, footerLinks map[strin
 			g.debug("Unable to find any examples in the description text. The entire description will be discarded.")
 
 			elidedDescriptions++
-			g.warn("Found <elided> in description for [%v]. The description and any examples will be dropped in the "+
-				"Pulumi provider.", name)
+			g.warnFor(name, "", "Found <elided> in description for [%v]. The description and any examples will be %s in the "+
+				"Pulumi provider.", name, fate)
 			elidedDoc = true
+			cleanupText = replaceElided(name)
 		} else {
 			g.debug("Found examples in the description text. Attempting to reformat the examples.")
 
-			cleanedupExamples, examplesElided := reformatText(g, examples, footerLinks)
+			cleanedupExamples, examplesElided := reformatText(g, examples, footerLinks, fieldRenames)
 			if examplesElided {
 				elidedDescriptions++
-				g.warn("Found <elided> in description for [%v]. The description and any examples will be dropped in "+
-					"the Pulumi provider.", name)
+				g.warnFor(name, "", "Found <elided> in description for [%v]. The description and any examples will be %s in "+
+					"the Pulumi provider.", name, fate)
 				elidedDoc = true
+				cleanupText = replaceElided(name)
 			} else {
 				elidedDescriptionsOnly++
-				g.warn("Found <elided> in description for [%v], but was able to preserve the examples. The description "+
-					"proper will be dropped in the Pulumi provider.", name)
+				g.warnFor(name, "", "Found <elided> in description for [%v], but was able to preserve the examples. "+
+					"The description proper will be %s in the Pulumi provider.", name, fate)
 				cleanupText = cleanedupExamples
+				if replacement := replaceElided(name); replacement != "" {
+					cleanupText = replacement + "\n\n" + cleanedupExamples
+				}
 			}
 		}
 	}
 
 	return entityDocs{
-		Description: cleanupText,
-		Arguments:   newargs,
-		Attributes:  newattrs,
-		Import:      doc.Import,
+		Description:        cleanupText,
+		Arguments:          newargs,
+		Attributes:         newattrs,
+		NestedAttributes:   newnestedattrs,
+		Import:             doc.Import,
+		ImportID:           doc.ImportID,
+		DeprecationMessage: doc.DeprecationMessage,
+		FrontMatter:        doc.FrontMatter,
 	}, elidedDoc
 }
 
+// truncateDescriptions returns a copy of the given docs whose argument and attribute descriptions are truncated to the
+// generator's maximum description length, if any. Truncated descriptions link to the entity's upstream docs.
+func (g *Generator) truncateDescriptions(doc entityDocs, kind DocKind, rawname string) entityDocs {
+	if g.maxDescriptionLength <= 0 {
+		return doc
+	}
+
+	url := registryDocsURL(g.info.GetGitHubOrg(), g.info.Name, g.info.GetResourcePrefix(), kind, rawname)
+	truncate := func(desc string) string {
+		return truncateDescription(desc, g.maxDescriptionLength, url)
+	}
+
+	doc = doc.clone()
+	for _, arg := range doc.Arguments {
+		arg.description = truncate(arg.description)
+		for name, desc := range arg.arguments {
+			arg.arguments[name] = truncate(desc)
+		}
+	}
+	for name, desc := range doc.Attributes {
+		doc.Attributes[name] = truncate(desc)
+	}
+	for _, attrs := range doc.NestedAttributes {
+		for name, desc := range attrs {
+			attrs[name] = truncate(desc)
+		}
+	}
+	return doc
+}
+
+// registryDocsURL returns the URL of the Terraform Registry page that documents the given entity.
+func registryDocsURL(org, provider, resourcePrefix string, kind DocKind, rawname string) string {
+	name := strings.TrimPrefix(rawname, resourcePrefix+"_")
+	return fmt.Sprintf("https://registry.terraform.io/providers/%s/%s/latest/docs/%s/%s", org, provider, string(kind),
+		name)
+}
+
+// descriptionUnbreakableRegexp matches the parts of a description that must not be split by truncation: code blocks,
+// code spans, and links.
+var descriptionUnbreakableRegexp = regexp.MustCompile("(?s)```.*?```|`[^`]*`|\\[[^\\]]*\\]\\([^)]*\\)")
+
+// truncateDescription truncates the given description to at most maxLength bytes, not counting the suffix that links to
+// the full docs at url. The description is cut at the last word boundary that fits and that is not within a code block,
+// code span, or link. If there is no such boundary, the description is returned unchanged.
+func truncateDescription(desc string, maxLength int, url string) string {
+	if len(desc) <= maxLength {
+		return desc
+	}
+
+	unbreakable := descriptionUnbreakableRegexp.FindAllStringIndex(desc, -1)
+	isBreakable := func(i int) bool {
+		for _, span := range unbreakable {
+			if span[0] <= i && i < span[1] {
+				return false
+			}
+		}
+		return true
+	}
+
+	for i := maxLength; i > 0; i-- {
+		if unicode.IsSpace(rune(desc[i])) && isBreakable(i) {
+			text := strings.TrimRightFunc(desc[:i], func(r rune) bool {
+				return unicode.IsSpace(r) || unicode.IsPunct(r) && r != '`' && r != ')'
+			})
+			if text == "" {
+				break
+			}
+			return fmt.Sprintf("%s... See the [full documentation](%s).", text, url)
+		}
+	}
+	return desc
+}
+
 //nolint:lll
 var (
 	// Match a [markdown](link)
@@ -1545,7 +3317,123 @@ var markdownPageReferenceLink = regexp.MustCompile(`\[[1-9]+\]: /docs/providers(
 
 const elidedDocComment = "<elided>"
 
-func fixupPropertyReferences(language Language, pkg string, info tfbridge.ProviderInfo, text string) string {
+// getFieldRenames returns the custom Pulumi names of the fields of the given resource or data source, keyed by their
+// Terraform names. The renames of nested fields are included as well, since references in the docs are not qualified
+// by their parent block; if the same Terraform name is renamed differently by different blocks, top-level fields take
+// precedence and conflicting nested renames are ignored.
+func getFieldRenames(info tfbridge.ResourceOrDataSourceInfo) map[string]string {
+	if info == nil {
+		return nil
+	}
+
+	renames, conflicts := map[string]string{}, map[string]bool{}
+	var collect func(fields map[string]*tfbridge.SchemaInfo, nested bool)
+	collect = func(fields map[string]*tfbridge.SchemaInfo, nested bool) {
+		var nestedFields []map[string]*tfbridge.SchemaInfo
+		for _, name := range sortedKeys(fields) {
+			field := fields[name]
+			if field == nil {
+				continue
+			}
+			if field.Name != "" {
+				if existing, ok := renames[name]; !ok {
+					renames[name] = field.Name
+				} else if nested && existing != field.Name {
+					conflicts[name] = true
+				}
+			}
+			if field.Elem != nil && len(field.Elem.Fields) != 0 {
+				nestedFields = append(nestedFields, field.Elem.Fields)
+			}
+			if len(field.Fields) != 0 {
+				nestedFields = append(nestedFields, field.Fields)
+			}
+		}
+		for _, fields := range nestedFields {
+			collect(fields, true)
+		}
+	}
+	collect(info.GetFields(), false)
+
+	for name := range conflicts {
+		delete(renames, name)
+	}
+	if len(renames) == 0 {
+		return nil
+	}
+	return renames
+}
+
+// camelCaseWithAcronyms camelizes the given snake_case name like propertyName, except that words after the first that
+// match one of the given acronyms (case-insensitively) take the acronym's spelling, e.g. `db_arn` becomes `dbARN`
+// rather than `dbArn`. Names with leading, trailing, or repeated underscores are camelized by propertyName.
+func camelCaseWithAcronyms(name string, acronyms []string) string {
+	words := strings.Split(name, "_")
+	if len(acronyms) == 0 || len(words) < 2 {
+		return propertyName(name, nil, nil)
+	}
+	for _, word := range words {
+		if word == "" {
+			return propertyName(name, nil, nil)
+		}
+	}
+
+	var result strings.Builder
+	result.WriteString(words[0])
+	for _, word := range words[1:] {
+		acronym := ""
+		for _, a := range acronyms {
+			if strings.EqualFold(a, word) {
+				acronym = a
+				break
+			}
+		}
+		if acronym != "" {
+			result.WriteString(acronym)
+		} else {
+			result.WriteString(strings.ToUpper(word[:1]) + word[1:])
+		}
+	}
+	return result.String()
+}
+
+// getReferencedResource returns the info for the resource with the given Terraform name, including a token derived by
+// the provider's TokenStrategy, if the name is that of a resource that the provider info maps or the provider defines.
+// Other names, which may merely look like those of resources, are not resolved by the TokenStrategy.
+func getReferencedResource(info tfbridge.ProviderInfo, name string) (*tfbridge.ResourceInfo, bool) {
+	if _, ok := info.Resources[name]; ok {
+		return info.GetResource(name), true
+	}
+	if info.P == nil {
+		return nil, false
+	}
+	if _, ok := info.P.ResourcesMap().GetOk(name); !ok {
+		return nil, false
+	}
+	derived := info.GetResource(name)
+	return derived, derived != nil
+}
+
+// getReferencedDataSource is like getReferencedResource, but for data sources.
+func getReferencedDataSource(info tfbridge.ProviderInfo, name string) (*tfbridge.DataSourceInfo, bool) {
+	if _, ok := info.DataSources[name]; ok {
+		return info.GetDataSource(name), true
+	}
+	if info.P == nil {
+		return nil, false
+	}
+	if _, ok := info.P.DataSourcesMap().GetOk(name); !ok {
+		return nil, false
+	}
+	derived := info.GetDataSource(name)
+	return derived, derived != nil
+}
+
+// fixupPropertyReferences rewrites the references to resources, data sources, and properties in the given text to
+// their Pulumi names. If arguments is non-nil, backtick-quoted references to properties are only rewritten if they
+// name one of the given arguments or a field in fieldRenames.
+func fixupPropertyReferences(language Language, pkg string, info tfbridge.ProviderInfo, fieldRenames map[string]string,
+	acronyms []string, arguments map[string]bool, text string) string {
 	return codeLikeSingleWord.ReplaceAllStringFunc(text, func(match string) string {
 		parts := codeLikeSingleWord.FindStringSubmatch(match)
 
@@ -1556,7 +3444,7 @@ func fixupPropertyReferences(language Language, pkg string, info tfbridge.Provid
 			open, name, close = "`", parts[7], "`"
 		}
 
-		if resInfo, hasResourceInfo := info.Resources[name]; hasResourceInfo {
+		if resInfo, hasResourceInfo := getReferencedResource(info, name); hasResourceInfo {
 			// This is a resource name
 			resname, mod := resourceName(info.GetResourcePrefix(), name, resInfo, false)
 			modname := extractModuleName(mod)
@@ -1572,7 +3460,7 @@ func fixupPropertyReferences(language Language, pkg string, info tfbridge.Provid
 				// Use `aws.ec2.Instance` format
 				return open + pkg + "." + modname + resname + close
 			}
-		} else if dataInfo, hasDatasourceInfo := info.DataSources[name]; hasDatasourceInfo {
+		} else if dataInfo, hasDatasourceInfo := getReferencedDataSource(info, name); hasDatasourceInfo {
 			// This is a data source name
 			getname, mod := dataSourceName(info.GetResourcePrefix(), name, dataInfo)
 			modname := extractModuleName(mod)
@@ -1592,12 +3480,25 @@ func fixupPropertyReferences(language Language, pkg string, info tfbridge.Provid
 				return open + pkg + "." + modname + getname + close
 			}
 		}
-		// Else just treat as a property name
+		// Else just treat as a property name, unless it is quoted and names neither one of the known arguments nor a
+		// renamed field, in which case it's more likely to be a value, e.g. `lowest_price`.
+		renamed, isRenamed := fieldRenames[name]
+		if arguments != nil && open == "`" && close == "`" && !arguments[name] && !isRenamed {
+			return match
+		}
 		switch language {
 		case NodeJS, Golang:
 			// Use `camelCase` format
-			pname := propertyName(name, nil, nil)
-			return open + pname + close
+			if isRenamed {
+				return open + renamed + close
+			}
+			return open + camelCaseWithAcronyms(name, acronyms) + close
+		case Python:
+			if isRenamed {
+				// Use the `snake_case` form of the custom name.
+				return open + python.PyName(renamed) + close
+			}
+			return match
 		default:
 			return match
 		}
@@ -1614,11 +3515,31 @@ func extractExamples(description string) string {
 		return ""
 	}
 
-	return strings.Replace(description, parts[0], "", -1)
+	// The examples end at the next H2 outside of their code blocks, wherever they appear in the description.
+	lines := strings.Split(separator+parts[1], "\n")
+	inCode := false
+	for i, line := range lines[1:] {
+		if strings.HasPrefix(line, "```") {
+			inCode = !inCode
+		} else if !inCode && strings.HasPrefix(line, "## ") {
+			return strings.TrimRight(strings.Join(lines[:i+1], "\n"), "\n")
+		}
+	}
+	return separator + parts[1]
+}
+
+// reformatText processes markdown strings from TF docs and cleans them for inclusion in Pulumi docs. References to
+// properties are rendered with their Pulumi names, including the names of fields that are renamed in fieldRenames.
+func reformatText(g *Generator, text string, footerLinks, fieldRenames map[string]string) (string, bool) {
+	return reformatArgumentText(g, text, footerLinks, fieldRenames, nil)
 }
 
-// reformatText processes markdown strings from TF docs and cleans them for inclusion in Pulumi docs
-func reformatText(g *Generator, text string, footerLinks map[string]string) (string, bool) {
+// reformatArgumentText is like reformatText, but for the description of an argument whose sibling arguments are
+// known: backtick-quoted snake_case names are only rendered with their Pulumi names if they name one of the sibling
+// arguments, e.g. "Conflicts with `launch_specification`", or a renamed field. Other quoted names are left alone. If
+// arguments is nil, every reference is rewritten as by reformatText.
+func reformatArgumentText(g *Generator, text string, footerLinks, fieldRenames map[string]string,
+	arguments map[string]bool) (string, bool) {
 
 	cleanupText := func(text string) (string, bool) {
 		// Remove incorrect documentation that should have been cleaned up in our forks.
@@ -1631,6 +3552,10 @@ func reformatText(g *Generator, text string, footerLinks map[string]string) (str
 		text = strings.Replace(text, "-> ", "> ", -1)
 		text = strings.Replace(text, "~> ", "> ", -1)
 
+		if g.convertInlineHTML {
+			text = convertInlineHTML(text)
+		}
+
 		// Trim Prefixes we see when the description is spread across multiple lines.
 		text = strings.TrimPrefix(text, "-\n(Required)\n")
 		text = strings.TrimPrefix(text, "-\n(Optional)\n")
@@ -1665,10 +3590,24 @@ func reformatText(g *Generator, text string, footerLinks map[string]string) (str
 			return parts[1]
 		})
 
-		// Fixup resource and property name references
-		text = fixupPropertyReferences(g.language, g.pkg, g.info, text)
+		// Fixup resource and property name references. The URL portions of any links are left untouched so that we
+		// don't break links whose paths or queries contain snake_case.
+		var fixed strings.Builder
+		last := 0
+		for _, link := range markdownLink.FindAllStringSubmatchIndex(text, -1) {
+			// link[2:4] is the link text and link[4:6] is the URL.
+			fixed.WriteString(fixupPropertyReferences(g.language, g.pkg, g.info, fieldRenames, g.acronyms, arguments,
+				text[last:link[4]]))
+			fixed.WriteString(text[link[4]:link[5]])
+			last = link[5]
+		}
+		fixed.WriteString(fixupPropertyReferences(g.language, g.pkg, g.info, fieldRenames, g.acronyms, arguments,
+			text[last:]))
 
-		return text, false
+		if g.escapeMarkdown {
+			return escapeMarkdown(fixed.String()), false
+		}
+		return fixed.String(), false
 	}
 
 	// Detect all code blocks in the text so we can avoid processing them.
@@ -1699,6 +3638,57 @@ func reformatText(g *Generator, text string, footerLinks map[string]string) (str
 	return strings.TrimSpace(strings.Join(parts, "")), false
 }
 
+var (
+	// For example:
+	// <code>name</code>
+	htmlCodeRegexp = regexp.MustCompile(`(?is)<code>(.*?)</code>`)
+
+	// For example:
+	// <br>, <br/>, <br />
+	htmlBreakRegexp = regexp.MustCompile(`(?i)<br\s*/?>`)
+
+	// For example:
+	// <a href="https://example.com" target="_blank">example</a>
+	htmlLinkRegexp = regexp.MustCompile(`(?is)<a\s[^>]*?href\s*=\s*["']([^"']*)["'][^>]*>(.*?)</a>`)
+
+	// Formatting tags that are dropped without replacement, e.g. <b>, </p>, or <span class="x">.
+	htmlFormattingTagRegexp = regexp.MustCompile(`(?i)</?(?:b|strong|i|em|p|span|div)(?:\s[^>]*)?>`)
+)
+
+// convertInlineHTML converts common inline HTML in a description to its markdown equivalent and strips formatting
+// tags that have none. Other tags, which are as likely to be placeholders such as "<name>" as they are to be HTML, are
+// left alone.
+func convertInlineHTML(text string) string {
+	text = htmlCodeRegexp.ReplaceAllString(text, "`$1`")
+	text = htmlBreakRegexp.ReplaceAllString(text, "\n")
+	text = htmlLinkRegexp.ReplaceAllString(text, "[$2]($1)")
+	return htmlFormattingTagRegexp.ReplaceAllString(text, "")
+}
+
+// markdownEscapeProtectedRegexp matches the parts of a description whose markdown special characters are intentional
+// and must not be escaped: code spans, links, bare URLs, HTML tags, list markers, strong emphasis (which is used for
+// notes, e.g. "**NOTE:**"), and already-escaped characters.
+var markdownEscapeProtectedRegexp = regexp.MustCompile(
+	"(?m)`[^`]*`|\\*\\*[^*\n]+\\*\\*|\\[[^\\]]*\\]\\([^)]*\\)|https?://[^\\s)]+|<[^<>\\s][^<>]*>|^[ \t]*[*+-][ \t]|\\\\.")
+
+// markdownSpecialCharReplacer escapes the markdown special characters that most often produce unintended formatting
+// when prose is embedded in markdown.
+var markdownSpecialCharReplacer = strings.NewReplacer("*", `\*`, "_", `\_`, "[", `\[`, "]", `\]`)
+
+// escapeMarkdown escapes the markdown special characters in the given text, which must not contain code blocks. Code
+// spans, links, bare URLs, HTML tags, list markers, strong emphasis, and already-escaped characters are left alone.
+func escapeMarkdown(text string) string {
+	var escaped strings.Builder
+	last := 0
+	for _, protected := range markdownEscapeProtectedRegexp.FindAllStringIndex(text, -1) {
+		escaped.WriteString(markdownSpecialCharReplacer.Replace(text[last:protected[0]]))
+		escaped.WriteString(text[protected[0]:protected[1]])
+		last = protected[1]
+	}
+	escaped.WriteString(markdownSpecialCharReplacer.Replace(text[last:]))
+	return escaped.String()
+}
+
 // For example:
 // [What is AWS Lambda?][1]
 var linkWithFooterRefRegexp = regexp.MustCompile(`(\[[a-zA-Z?.! ]+\])(\[[0-9]+\])`)
@@ -0,0 +1,215 @@
+// Copyright 2016-2022, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tfgen
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/common/diag"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/tokens"
+
+	shim "github.com/pulumi/pulumi-terraform-bridge/v3/pkg/tfshim"
+)
+
+// docsParserVersion identifies the behavior of the markdown parser. It is part of every DocsCache key, and must be
+// bumped whenever a change to the parser alters its output so that stale cache entries are not reused.
+const docsParserVersion = "20"
+
+// DocsCache caches the docs parsed from upstream markdown so that unchanged docs need not be re-parsed. Only the raw
+// parse of the markdown is cached; the docs are always cleaned up for the target language afresh, so a cache may be
+// shared by generators for different languages. Keys are derived from the content of the markdown, the version of the
+// parser, and the other inputs of the raw parse. Cached values are opaque to the cache.
+type DocsCache interface {
+	// Get returns the cached docs for the given key, if any.
+	Get(key string) (interface{}, bool)
+	// Put caches the docs for the given key.
+	Put(key string, docs interface{})
+}
+
+// NewInMemoryDocsCache returns a DocsCache that holds its entries in memory. It is safe for concurrent use.
+func NewInMemoryDocsCache() DocsCache {
+	return &inMemoryDocsCache{}
+}
+
+type inMemoryDocsCache struct {
+	entries sync.Map
+}
+
+func (c *inMemoryDocsCache) Get(key string) (interface{}, bool) {
+	return c.entries.Load(key)
+}
+
+func (c *inMemoryDocsCache) Put(key string, docs interface{}) {
+	c.entries.Store(key, docs)
+}
+
+// parseTFMarkdownFunc parses markdown on behalf of parseEntityMarkdown. It is a variable so that tests can observe
+// parsing.
+var parseTFMarkdownFunc = parseTFMarkdown
+
+// parseRawMarkdownCached runs the parser's raw parse, consulting the generator's DocsCache (if any) first. The
+// warnings raised by the raw parse are cached with its result and raised again whenever the result is reused.
+func parseRawMarkdownCached(p *tfMarkdownParser) (rawEntityDocs, error) {
+	g := p.g
+
+	// Examples that are replaced by supplementary files depend on more than the markdown, so they are never cached.
+	if g.docsCache == nil || p.info != nil && p.info.GetDocs() != nil && p.info.ReplaceExamplesSection() {
+		return p.parseRaw()
+	}
+
+	key := docsCacheKey(p)
+	if cached, ok := g.docsCache.Get(key); ok {
+		if raw, ok := cached.(rawEntityDocs); ok {
+			for _, w := range raw.warnings {
+				g.replayWarning(w)
+			}
+			return raw.clone(), nil
+		}
+	}
+
+	start := len(g.warnings)
+	raw, err := p.parseRaw()
+	if err != nil {
+		return rawEntityDocs{}, err
+	}
+	raw.warnings = append([]GenerationWarning(nil), g.warnings[start:]...)
+	g.docsCache.Put(key, raw.clone())
+	return raw, nil
+}
+
+// replayWarning raises a warning that was recorded by an earlier raw parse again.
+func (g *Generator) replayWarning(w GenerationWarning) {
+	g.recordWarning(w.Entity, w.Path, w.Severity, "%s", w.Message)
+	if w.Severity == diag.Error {
+		g.sink.Errorf(diag.Message("", "%s"), w.Message)
+	} else {
+		g.sink.Warningf(diag.Message("", "%s"), w.Message)
+	}
+}
+
+// docsCacheKey returns the DocsCache key for the raw parse of the parser's markdown. Besides the markdown itself, the
+// key covers the parser version and every other input that affects the raw parse: the entity's token and import
+// details, and the blocks that its TF schema defines.
+func docsCacheKey(p *tfMarkdownParser) string {
+	var tok tokens.Token
+	var importDetails string
+	if p.info != nil {
+		tok = p.info.GetTok()
+		if docs := p.info.GetDocs(); docs != nil {
+			importDetails = docs.ImportDetails
+		}
+	}
+
+	h := sha256.New()
+	fmt.Fprintf(h, "%s\x00%s\x00%s\x00%s\x00%v\x00%s\x00%s\x00", docsParserVersion, p.kind, p.resourcePrefix,
+		p.rawname, p.g.collapseDuplicateExamples, tok, importDetails)
+	if prov := p.g.provider(); prov != nil {
+		var res shim.Resource
+		var found bool
+		if p.kind == DataSourceDocs {
+			res, found = prov.DataSourcesMap().GetOk(p.rawname)
+		} else {
+			res, found = prov.ResourcesMap().GetOk(p.rawname)
+		}
+		if found {
+			// Without a schema every block is assumed to exist, so its absence must be distinguished from a schema
+			// that defines no blocks.
+			h.Write([]byte("schema\x00"))
+			for _, path := range schemaBlockPaths(res, "") {
+				fmt.Fprintf(h, "block:%s\x00", path)
+			}
+		}
+	}
+	h.Write([]byte(p.markdown))
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// schemaBlockPaths returns the sorted dotted paths of the blocks that the given resource's schema defines, as
+// consulted by schemaHasBlock.
+func schemaBlockPaths(res shim.Resource, prefix string) []string {
+	var paths []string
+	res.Schema().Range(func(name string, sch shim.Schema) bool {
+		if elem, ok := sch.Elem().(shim.Resource); ok {
+			path := prefix + name
+			paths = append(paths, path)
+			paths = append(paths, schemaBlockPaths(elem, path+".")...)
+		}
+		return true
+	})
+	sort.Strings(paths)
+	return paths
+}
+
+// clone returns a deep copy of the raw docs, as callers are free to modify the docs they are handed.
+func (raw rawEntityDocs) clone() rawEntityDocs {
+	result := raw
+	result.doc = raw.doc.clone()
+	if raw.footerLinks != nil {
+		result.footerLinks = make(map[string]string, len(raw.footerLinks))
+		for k, v := range raw.footerLinks {
+			result.footerLinks[k] = v
+		}
+	}
+	result.warnings = append([]GenerationWarning(nil), raw.warnings...)
+	return result
+}
+
+// clone returns a deep copy of the docs, as callers are free to modify the docs they are handed.
+func (ed entityDocs) clone() entityDocs {
+	result := ed
+	if ed.Arguments != nil {
+		result.Arguments = make(map[string]*argumentDocs, len(ed.Arguments))
+		for name, arg := range ed.Arguments {
+			argCopy := *arg
+			if arg.validValues != nil {
+				argCopy.validValues = append([]string(nil), arg.validValues...)
+			}
+			if arg.arguments != nil {
+				argCopy.arguments = make(map[string]string, len(arg.arguments))
+				for k, v := range arg.arguments {
+					argCopy.arguments[k] = v
+				}
+			}
+			result.Arguments[name] = &argCopy
+		}
+	}
+	if ed.Attributes != nil {
+		result.Attributes = make(map[string]string, len(ed.Attributes))
+		for k, v := range ed.Attributes {
+			result.Attributes[k] = v
+		}
+	}
+	if ed.FrontMatter != nil {
+		result.FrontMatter = make(map[string]string, len(ed.FrontMatter))
+		for k, v := range ed.FrontMatter {
+			result.FrontMatter[k] = v
+		}
+	}
+	if ed.NestedAttributes != nil {
+		result.NestedAttributes = make(map[string]map[string]string, len(ed.NestedAttributes))
+		for block, attrs := range ed.NestedAttributes {
+			attrsCopy := make(map[string]string, len(attrs))
+			for k, v := range attrs {
+				attrsCopy[k] = v
+			}
+			result.NestedAttributes[block] = attrsCopy
+		}
+	}
+	return result
+}
@@ -0,0 +1,156 @@
+// Copyright 2016-2022, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tfgen
+
+import (
+	"io"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/diag"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/diag/colors"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi-terraform-bridge/v3/pkg/tfbridge"
+	shimv1 "github.com/pulumi/pulumi-terraform-bridge/v3/pkg/tfshim/sdk-v1"
+)
+
+// countingDocsCache is a DocsCache that counts the entries that are put into it, i.e. the raw parses that missed.
+type countingDocsCache struct {
+	DocsCache
+	puts int
+}
+
+func (c *countingDocsCache) Put(key string, docs interface{}) {
+	c.puts++
+	c.DocsCache.Put(key, docs)
+}
+
+func newDocsCacheTestGenerator(t *testing.T, language Language, cache DocsCache) *Generator {
+	g, err := NewGenerator(GeneratorOptions{
+		Package:  "widgets",
+		Version:  "0.0.1",
+		Language: language,
+		ProviderInfo: tfbridge.ProviderInfo{
+			Name: "widgets",
+		},
+		Sink: diag.DefaultSink(io.Discard, io.Discard, diag.FormatOptions{
+			Color: colors.Never,
+		}),
+		DocsCache: cache,
+	})
+	assert.NoError(t, err)
+	return g
+}
+
+func getWidgetDocs(t *testing.T, g *Generator, markdown string) entityDocs {
+	info := &tfbridge.ResourceInfo{Docs: &tfbridge.DocInfo{Markdown: []byte(markdown)}}
+	doc, err := getDocsForProvider(g, "", "widgets", "widgets", ResourceDocs, "widgets_widget", info, "", "")
+	assert.NoError(t, err)
+	return doc
+}
+
+func TestDocsCache(t *testing.T) {
+	cache := &countingDocsCache{DocsCache: NewInMemoryDocsCache()}
+	g := newDocsCacheTestGenerator(t, NodeJS, cache)
+
+	markdown := "# widgets_widget\n\nProvides a widget.\n\n## Argument Reference\n\n" +
+		"* `name` - (Required) The name of the widget.\n"
+
+	first := getWidgetDocs(t, g, markdown)
+	assert.Equal(t, 1, cache.puts)
+	assert.Equal(t, "The name of the widget.", first.Arguments["name"].description)
+
+	// Modifying the returned docs does not affect the cached entry.
+	first.Arguments["name"].description = "modified"
+
+	second := getWidgetDocs(t, g, markdown)
+	assert.Equal(t, 1, cache.puts)
+	assert.Equal(t, "The name of the widget.", second.Arguments["name"].description)
+
+	// Changed markdown is parsed afresh.
+	third := getWidgetDocs(t, g, markdown+"* `size` - (Optional) The size of the widget.\n")
+	assert.Equal(t, 2, cache.puts)
+	assert.Contains(t, third.Arguments, "size")
+}
+
+func TestDocsCacheSharedAcrossLanguages(t *testing.T) {
+	cache := &countingDocsCache{DocsCache: NewInMemoryDocsCache()}
+	nodejs := newDocsCacheTestGenerator(t, NodeJS, cache)
+	python := newDocsCacheTestGenerator(t, Python, cache)
+
+	markdown := "---\nlayout: \"widgets\"\n\n# widgets_widget\n\nProvides a widget.\n\n## Argument Reference\n\n" +
+		"* `name` - (Optional) The name of the widget. Conflicts with `name_prefix`.\n" +
+		"* `name_prefix` - (Optional) The prefix of the name of the widget.\n"
+
+	nodejsDoc := getWidgetDocs(t, nodejs, markdown)
+	pythonDoc := getWidgetDocs(t, python, markdown)
+	assert.Equal(t, 1, cache.puts)
+
+	// The raw parse is shared, but the docs are cleaned up for each language.
+	assert.Equal(t, "The name of the widget. Conflicts with `namePrefix`.", nodejsDoc.Arguments["name"].description)
+	assert.Equal(t, "The name of the widget. Conflicts with `name_prefix`.", pythonDoc.Arguments["name"].description)
+
+	// The warnings raised by the raw parse are raised again when it is reused.
+	assert.NotEmpty(t, nodejs.Warnings())
+	assert.Equal(t, nodejs.Warnings(), python.Warnings())
+}
+
+func TestDocsCacheKey(t *testing.T) {
+	g := &Generator{}
+	key := func(kind DocKind, markdown, rawname string, info tfbridge.ResourceOrDataSourceInfo) string {
+		return docsCacheKey(&tfMarkdownParser{
+			g:              g,
+			info:           info,
+			kind:           kind,
+			markdown:       markdown,
+			resourcePrefix: "widgets",
+			rawname:        rawname,
+		})
+	}
+
+	base := key(ResourceDocs, "# widgets_widget", "widgets_widget", nil)
+	assert.Equal(t, base, key(ResourceDocs, "# widgets_widget", "widgets_widget", nil))
+	assert.NotEqual(t, base, key(ResourceDocs, "# widgets_gadget", "widgets_widget", nil))
+	assert.NotEqual(t, base, key(DataSourceDocs, "# widgets_widget", "widgets_widget", nil))
+	assert.NotEqual(t, base, key(ResourceDocs, "# widgets_widget", "widgets_gadget", nil))
+	assert.NotEqual(t, base, key(ResourceDocs, "# widgets_widget", "widgets_widget",
+		&tfbridge.ResourceInfo{Tok: "widgets:index/widget:Widget"}))
+	assert.NotEqual(t, base, key(ResourceDocs, "# widgets_widget", "widgets_widget",
+		&tfbridge.ResourceInfo{Docs: &tfbridge.DocInfo{ImportDetails: "Widgets cannot be imported."}}))
+
+	// The blocks defined by the entity's schema determine how nested argument sections are parsed.
+	withSchema := func(blocks ...string) string {
+		res := &schema.Resource{Schema: map[string]*schema.Schema{
+			"name": {Type: schema.TypeString, Optional: true},
+		}}
+		for _, block := range blocks {
+			res.Schema[block] = &schema.Schema{Type: schema.TypeList, Optional: true, Elem: &schema.Resource{}}
+		}
+		g.info.P = shimv1.NewProvider(&schema.Provider{ResourcesMap: map[string]*schema.Resource{
+			"widgets_widget": res,
+		}})
+		defer func() { g.info.P = nil }()
+		return key(ResourceDocs, "# widgets_widget", "widgets_widget", nil)
+	}
+	assert.NotEqual(t, base, withSchema())
+	assert.Equal(t, withSchema("settings"), withSchema("settings"))
+	assert.NotEqual(t, withSchema(), withSchema("settings"))
+	assert.NotEqual(t, withSchema("settings"), withSchema("options"))
+
+	// Renamed fields only affect the cleanup of the docs, which is not cached.
+	assert.Equal(t, base, key(ResourceDocs, "# widgets_widget", "widgets_widget",
+		&tfbridge.ResourceInfo{Fields: map[string]*tfbridge.SchemaInfo{"name": {Name: "widgetName"}}}))
+}
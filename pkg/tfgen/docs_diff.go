@@ -0,0 +1,100 @@
+// Copyright 2016-2022, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tfgen
+
+// DocsDiff describes the differences between two parses of the docs for the same entity, e.g. before and after a
+// change to the parser or to the upstream docs. Each part of the docs is identified by its fully-qualified path; see
+// flattenKeys. Every list is sorted by path so that diffs can be compared across runs.
+type DocsDiff struct {
+	// Added lists the paths that are only documented by the new docs.
+	Added []string `json:"added,omitempty"`
+	// Removed lists the paths that are only documented by the old docs.
+	Removed []string `json:"removed,omitempty"`
+	// Changed lists the paths whose descriptions differ between the old and new docs.
+	Changed []DocsChange `json:"changed,omitempty"`
+}
+
+// DocsChange records the old and new descriptions at a path whose description changed.
+type DocsChange struct {
+	Path string `json:"path"`
+	Old  string `json:"old"`
+	New  string `json:"new"`
+}
+
+// Empty returns true if the diff records no differences.
+func (d DocsDiff) Empty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Changed) == 0
+}
+
+// DiffEntityDocs compares the old and new docs for an entity and reports the arguments and attributes (and the
+// description of the entity itself) that were added, removed, or changed.
+func DiffEntityDocs(oldDocs, newDocs entityDocs) DocsDiff {
+	oldKeys, newKeys := flattenKeys(oldDocs), flattenKeys(newDocs)
+
+	var diff DocsDiff
+	for _, path := range sortedKeys(oldKeys) {
+		newDesc, ok := newKeys[path]
+		switch {
+		case !ok:
+			diff.Removed = append(diff.Removed, path)
+		case newDesc != oldKeys[path]:
+			diff.Changed = append(diff.Changed, DocsChange{Path: path, Old: oldKeys[path], New: newDesc})
+		}
+	}
+	for _, path := range sortedKeys(newKeys) {
+		if _, ok := oldKeys[path]; !ok {
+			diff.Added = append(diff.Added, path)
+		}
+	}
+	return diff
+}
+
+// flattenKeys maps the fully-qualified path of each part of the given docs to its description. The paths are
+//
+// - "description" for the description of the entity itself,
+// - "arguments.<name>" for arguments, e.g. "arguments.website",
+// - "arguments.<block>.<name>" for the arguments of nested blocks, e.g. "arguments.website.index_document",
+// - "attributes.<name>" for attributes, and
+// - "attributes.<block>.<name>" for nested attributes.
+//
+// Arguments recorded under their dotted paths take precedence over the arguments that their blocks record.
+func flattenKeys(doc entityDocs) map[string]string {
+	keys := map[string]string{}
+	if doc.Description != "" {
+		keys["description"] = doc.Description
+	}
+
+	for name, arg := range doc.Arguments {
+		keys["arguments."+name] = arg.description
+	}
+	for name, arg := range doc.Arguments {
+		for nested, desc := range arg.arguments {
+			path := "arguments." + name + "." + nested
+			if _, ok := keys[path]; !ok {
+				keys[path] = desc
+			}
+		}
+	}
+
+	for name := range doc.Attributes {
+		keys["attributes."+name] = doc.attributeDescription(name)
+	}
+	for block, attrs := range doc.NestedAttributes {
+		for name, desc := range attrs {
+			keys["attributes."+block+"."+name] = desc
+		}
+	}
+	return keys
+}
@@ -0,0 +1,95 @@
+// Copyright 2016-2022, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tfgen
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiffEntityDocs(t *testing.T) {
+	oldDocs := entityDocs{
+		Description: "Manages a widget.",
+		Arguments: map[string]*argumentDocs{
+			"name": {description: "The name of the widget."},
+			"size": {description: "The size of the widget."},
+			"settings": {
+				description: "The settings of the widget.",
+				arguments:   map[string]string{"color": "The color of the widget."},
+			},
+		},
+		Attributes: map[string]string{"arn": "The ARN of the widget."},
+	}
+	newDocs := entityDocs{
+		Description: "Manages a widget.",
+		Arguments: map[string]*argumentDocs{
+			"name": {description: "The unique name of the widget."},
+			"settings": {
+				description: "The settings of the widget.",
+				arguments: map[string]string{
+					"color": "The color of the widget.",
+					"shade": "The shade of the widget.",
+				},
+			},
+		},
+		Attributes: map[string]string{"arn": "The ARN of the widget."},
+	}
+
+	diff := DiffEntityDocs(oldDocs, newDocs)
+	assert.Equal(t, DocsDiff{
+		Added:   []string{"arguments.settings.shade"},
+		Removed: []string{"arguments.size"},
+		Changed: []DocsChange{{
+			Path: "arguments.name",
+			Old:  "The name of the widget.",
+			New:  "The unique name of the widget.",
+		}},
+	}, diff)
+	assert.False(t, diff.Empty())
+	assert.True(t, DiffEntityDocs(newDocs, newDocs).Empty())
+
+	bytes, err := json.Marshal(diff)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{
+		"added": ["arguments.settings.shade"],
+		"removed": ["arguments.size"],
+		"changed": [{"path": "arguments.name", "old": "The name of the widget.", "new": "The unique name of the widget."}]
+	}`, string(bytes))
+}
+
+func TestFlattenKeys(t *testing.T) {
+	doc := entityDocs{
+		Description: "Manages a widget.",
+		Arguments: map[string]*argumentDocs{
+			"settings": {
+				description: "The settings of the widget.",
+				arguments:   map[string]string{"backup": "The backup settings."},
+			},
+			"settings.backup": {description: "The backup settings of the widget."},
+		},
+		Attributes:       map[string]string{"settings": argumentDescriptionRef("settings")},
+		NestedAttributes: map[string]map[string]string{"status": {"state": "The state of the widget."}},
+	}
+
+	assert.Equal(t, map[string]string{
+		"description":               "Manages a widget.",
+		"arguments.settings":        "The settings of the widget.",
+		"arguments.settings.backup": "The backup settings of the widget.",
+		"attributes.settings":       "The settings of the widget.",
+		"attributes.status.state":   "The state of the widget.",
+	}, flattenKeys(doc))
+}
@@ -0,0 +1,238 @@
+// Copyright 2016-2022, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tfgen
+
+import (
+	"sync"
+
+	"github.com/pulumi/pulumi-terraform-bridge/v3/pkg/tfbridge"
+	shim "github.com/pulumi/pulumi-terraform-bridge/v3/pkg/tfshim"
+)
+
+// EntityDocs is the documentation parsed from the upstream docs of a resource or data source, as returned by
+// DocsForToken.
+type EntityDocs struct {
+	// Kind indicates whether the docs are those of a resource or a data source.
+	Kind DocKind
+	// TerraformName is the Terraform name of the resource or data source, e.g. "aws_s3_bucket".
+	TerraformName string
+	// Description is the description of the resource or data source.
+	Description string
+	// Arguments maps the name of each documented argument to its docs. Arguments of nested blocks are also recorded
+	// by their own names if no top-level argument of the same name is documented.
+	Arguments map[string]ArgumentDocs
+	// Attributes maps the name of each documented attribute to its description.
+	Attributes map[string]string
+	// NestedAttributes maps the name of each nested block of attributes to the names and descriptions of its
+	// attributes.
+	NestedAttributes map[string]map[string]string
+	// Import is the description of how the resource is imported, if any.
+	Import string
+	// DeprecationMessage is the deprecation message of the resource or data source, if its docs lead with an
+	// admonition that it is deprecated.
+	DeprecationMessage string
+}
+
+// ArgumentDocs is the documentation of a single argument of a resource or data source.
+type ArgumentDocs struct {
+	// Description is the description of the argument.
+	Description string
+	// Arguments maps the name of each argument of the argument's block, if any, to its description.
+	Arguments map[string]string
+	// DeprecationMessage is the deprecation message of the argument, if the docs mark it as deprecated.
+	DeprecationMessage string
+	// Type is the type that the docs annotate the argument with, if any, e.g. "List of String".
+	Type string
+	// ValidValues lists the values that the description enumerates as valid for the argument, if any, e.g.
+	// ["BLOCK", "ALLOW"] for "Valid values are `BLOCK` or `ALLOW`".
+	ValidValues []string
+	// Required is true if the docs mark the argument as required, either by listing it under a "Required:" grouping
+	// header or in the Required column of an argument table.
+	Required bool
+	// Default is the value that the Default column of an argument table gives for the argument, if any.
+	Default string
+	// DefaultDescription is the sentence that opens the description if it describes the argument's default in prose,
+	// e.g. "Defaults to the region of the provider.". The sentence is also part of the Description.
+	DefaultDescription string
+}
+
+// docsQuery holds the state behind DocsForToken: the resources and data sources indexed by Pulumi token, and the docs
+// that have been parsed so far.
+type docsQuery struct {
+	once     sync.Once
+	entities map[string]docsQueryEntity
+
+	m    sync.Mutex
+	docs map[string]EntityDocs
+}
+
+// docsQueryEntity identifies the resource or data source with a given Pulumi token.
+type docsQueryEntity struct {
+	kind    DocKind
+	rawname string
+	info    tfbridge.ResourceOrDataSourceInfo
+}
+
+// DocsForToken returns the parsed docs of the resource or data source with the given Pulumi token, e.g.
+// "aws:s3/bucket:Bucket". The docs are parsed the first time that they are requested and cached thereafter. If no
+// resource or data source has the given token, false is returned. It is safe to call DocsForToken concurrently.
+func (g *Generator) DocsForToken(token string) (EntityDocs, bool, error) {
+	q := &g.docsQuery
+	q.once.Do(func() {
+		q.entities = map[string]docsQueryEntity{}
+		resources, dataSources := g.entityNames()
+		for _, rawname := range resources {
+			if info := g.info.GetResource(rawname); info != nil && info.Tok != "" {
+				q.entities[string(info.Tok)] = docsQueryEntity{kind: ResourceDocs, rawname: rawname, info: info}
+			}
+		}
+		for _, rawname := range dataSources {
+			if info := g.info.GetDataSource(rawname); info != nil && info.Tok != "" {
+				q.entities[string(info.Tok)] = docsQueryEntity{kind: DataSourceDocs, rawname: rawname, info: info}
+			}
+		}
+	})
+
+	entity, ok := q.entities[token]
+	if !ok {
+		return EntityDocs{}, false, nil
+	}
+
+	q.m.Lock()
+	defer q.m.Unlock()
+
+	if docs, ok := q.docs[token]; ok {
+		return docs.clone(), true, nil
+	}
+
+	doc, err := getDocsForProvider(g, g.info.GetGitHubOrg(), g.info.Name, g.info.GetResourcePrefix(), entity.kind,
+		entity.rawname, entity.info, g.info.GetProviderModuleVersion(), g.info.GetGitHubHost())
+	if err != nil {
+		return EntityDocs{}, false, err
+	}
+
+	docs := newEntityDocs(entity.kind, entity.rawname, doc)
+	if q.docs == nil {
+		q.docs = map[string]EntityDocs{}
+	}
+	q.docs[token] = docs
+	return docs.clone(), true, nil
+}
+
+// entityNames returns the Terraform names of the resources and data sources that are either defined by the provider,
+// whose tokens may be derived by the TokenStrategy, or mapped explicitly by the provider info.
+func (g *Generator) entityNames() ([]string, []string) {
+	collect := func(m shim.ResourceMap, names []string) []string {
+		if m != nil {
+			m.Range(func(name string, _ shim.Resource) bool {
+				names = append(names, name)
+				return true
+			})
+		}
+		return names
+	}
+
+	var resources, dataSources []string
+	for name := range g.info.Resources {
+		resources = append(resources, name)
+	}
+	for name := range g.info.DataSources {
+		dataSources = append(dataSources, name)
+	}
+	if p := g.provider(); p != nil {
+		resources, dataSources = collect(p.ResourcesMap(), resources), collect(p.DataSourcesMap(), dataSources)
+	}
+	return resources, dataSources
+}
+
+// newEntityDocs converts the parser's docs for an entity into their exported form.
+func newEntityDocs(kind DocKind, rawname string, doc entityDocs) EntityDocs {
+	docs := EntityDocs{
+		Kind:               kind,
+		TerraformName:      rawname,
+		Description:        doc.Description,
+		Import:             doc.Import,
+		DeprecationMessage: doc.DeprecationMessage,
+	}
+	if len(doc.Arguments) != 0 {
+		docs.Arguments = make(map[string]ArgumentDocs, len(doc.Arguments))
+		for name, arg := range doc.Arguments {
+			docs.Arguments[name] = ArgumentDocs{
+				Description:        arg.description,
+				Arguments:          copyStringMap(arg.arguments),
+				DeprecationMessage: arg.deprecationMessage,
+				Type:               arg.docType,
+				ValidValues:        copyStrings(arg.validValues),
+				Required:           arg.isRequired,
+				Default:            arg.defaultValue,
+				DefaultDescription: arg.defaultDescription,
+			}
+		}
+	}
+	if len(doc.Attributes) != 0 {
+		docs.Attributes = make(map[string]string, len(doc.Attributes))
+		for name := range doc.Attributes {
+			docs.Attributes[name] = doc.attributeDescription(name)
+		}
+	}
+	if len(doc.NestedAttributes) != 0 {
+		docs.NestedAttributes = make(map[string]map[string]string, len(doc.NestedAttributes))
+		for block, attrs := range doc.NestedAttributes {
+			docs.NestedAttributes[block] = copyStringMap(attrs)
+		}
+	}
+	return docs
+}
+
+// clone returns a deep copy of the docs, so that callers cannot modify the cached docs.
+func (d EntityDocs) clone() EntityDocs {
+	result := d
+	if d.Arguments != nil {
+		result.Arguments = make(map[string]ArgumentDocs, len(d.Arguments))
+		for name, arg := range d.Arguments {
+			arg.Arguments = copyStringMap(arg.Arguments)
+			arg.ValidValues = copyStrings(arg.ValidValues)
+			result.Arguments[name] = arg
+		}
+	}
+	result.Attributes = copyStringMap(d.Attributes)
+	if d.NestedAttributes != nil {
+		result.NestedAttributes = make(map[string]map[string]string, len(d.NestedAttributes))
+		for block, attrs := range d.NestedAttributes {
+			result.NestedAttributes[block] = copyStringMap(attrs)
+		}
+	}
+	return result
+}
+
+// copyStrings returns a copy of the given slice, or nil if the slice is empty.
+func copyStrings(s []string) []string {
+	if len(s) == 0 {
+		return nil
+	}
+	return append([]string(nil), s...)
+}
+
+// copyStringMap returns a copy of the given map, or nil if the map is empty.
+func copyStringMap(m map[string]string) map[string]string {
+	if len(m) == 0 {
+		return nil
+	}
+	result := make(map[string]string, len(m))
+	for k, v := range m {
+		result[k] = v
+	}
+	return result
+}
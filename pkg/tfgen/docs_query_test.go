@@ -0,0 +1,167 @@
+// Copyright 2016-2022, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tfgen
+
+import (
+	"io"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/diag"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/diag/colors"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi-terraform-bridge/v3/pkg/tfbridge"
+	shimv1 "github.com/pulumi/pulumi-terraform-bridge/v3/pkg/tfshim/sdk-v1"
+)
+
+func TestDocsForToken(t *testing.T) {
+	parses := 0
+	defer func(parse func(*Generator, tfbridge.ResourceOrDataSourceInfo, DocKind, string, string, string,
+		string) (entityDocs, error)) {
+		parseTFMarkdownFunc = parse
+	}(parseTFMarkdownFunc)
+	parseTFMarkdownFunc = func(g *Generator, info tfbridge.ResourceOrDataSourceInfo, kind DocKind,
+		markdown, markdownFileName, resourcePrefix, rawname string) (entityDocs, error) {
+		parses++
+		return parseTFMarkdown(g, info, kind, markdown, markdownFileName, resourcePrefix, rawname)
+	}
+
+	markdown := "# widgets_widget\n\nProvides a widget.\n\n## Argument Reference\n\n" +
+		"* `name` - (Required, String) The name of the widget.\n" +
+		"* `mode` - (Optional) Defaults to the mode of the provider. Valid values are `fast` or `slow`.\n" +
+		"* `settings` - (Optional) The settings of the widget. Documented below.\n\n" +
+		"The `settings` block supports:\n\n" +
+		"* `shade` - (Optional) The shade of the widget.\n\n" +
+		"## Attributes Reference\n\n" +
+		"* `arn` - The ARN of the widget.\n"
+
+	g, err := NewGenerator(GeneratorOptions{
+		Package:  "widgets",
+		Version:  "0.0.1",
+		Language: "nodejs",
+		ProviderInfo: tfbridge.ProviderInfo{
+			Name: "widgets",
+			Resources: map[string]*tfbridge.ResourceInfo{
+				"widgets_widget": {
+					Tok:  "widgets:index/widget:Widget",
+					Docs: &tfbridge.DocInfo{Markdown: []byte(markdown)},
+				},
+			},
+		},
+		Sink: diag.DefaultSink(io.Discard, io.Discard, diag.FormatOptions{
+			Color: colors.Never,
+		}),
+	})
+	assert.NoError(t, err)
+
+	docs, ok, err := g.DocsForToken("widgets:index/widget:Widget")
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, ResourceDocs, docs.Kind)
+	assert.Equal(t, "widgets_widget", docs.TerraformName)
+	assert.Contains(t, docs.Description, "Provides a widget.")
+	assert.Equal(t, ArgumentDocs{Description: "The name of the widget.", Type: "String"}, docs.Arguments["name"])
+	assert.Equal(t, ArgumentDocs{
+		Description:        "Defaults to the mode of the provider. Valid values are `fast` or `slow`.",
+		ValidValues:        []string{"fast", "slow"},
+		DefaultDescription: "Defaults to the mode of the provider.",
+	}, docs.Arguments["mode"])
+	assert.Equal(t, map[string]string{"shade": "The shade of the widget."}, docs.Arguments["settings"].Arguments)
+	assert.Equal(t, "The ARN of the widget.", docs.Attributes["arn"])
+	assert.Equal(t, 1, parses)
+
+	// The docs are parsed only once, and modifying the returned docs does not affect those that are cached.
+	docs.Arguments["settings"].Arguments["shade"] = "modified"
+	again, ok, err := g.DocsForToken("widgets:index/widget:Widget")
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, "The shade of the widget.", again.Arguments["settings"].Arguments["shade"])
+	assert.Equal(t, 1, parses)
+
+	_, ok, err = g.DocsForToken("widgets:index/gadget:Gadget")
+	assert.NoError(t, err)
+	assert.False(t, ok)
+}
+
+func TestDocsForTokenWithTokenStrategy(t *testing.T) {
+	widget := &schema.Resource{Schema: map[string]*schema.Schema{
+		"name": {Type: schema.TypeString, Optional: true},
+	}}
+	markdown := "# widgets_widget\n\nProvides a widget.\n\n## Argument Reference\n\n" +
+		"* `name` - (Optional) The name of the widget.\n"
+
+	g, err := NewGenerator(GeneratorOptions{
+		Package:  "widgets",
+		Version:  "0.0.1",
+		Language: "nodejs",
+		ProviderInfo: tfbridge.ProviderInfo{
+			P: shimv1.NewProvider(&schema.Provider{
+				ResourcesMap: map[string]*schema.Resource{"widgets_widget": widget},
+			}),
+			Name: "widgets",
+			Resources: map[string]*tfbridge.ResourceInfo{
+				"widgets_widget": {Docs: &tfbridge.DocInfo{Markdown: []byte(markdown)}},
+			},
+			TokenStrategy: func(tfToken string) (string, bool) {
+				return "widgets:index/widget:Widget", tfToken == "widgets_widget"
+			},
+		},
+		Sink: diag.DefaultSink(io.Discard, io.Discard, diag.FormatOptions{
+			Color: colors.Never,
+		}),
+	})
+	assert.NoError(t, err)
+
+	// The resource has no explicit token, so it is found by the token that the TokenStrategy derives for it.
+	docs, ok, err := g.DocsForToken("widgets:index/widget:Widget")
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, "widgets_widget", docs.TerraformName)
+	assert.Equal(t, "The name of the widget.", docs.Arguments["name"].Description)
+}
+
+func TestDocsForTokenArgumentTable(t *testing.T) {
+	markdown := "# widgets_widget\n\nProvides a widget.\n\n## Argument Reference\n\n" +
+		"| Name | Description | Required | Default |\n" +
+		"|------|-------------|----------|---------|\n" +
+		"| `name` | The name of the widget. | yes | n/a |\n" +
+		"| `size` | The size of the widget. | no | `3` |\n"
+
+	g, err := NewGenerator(GeneratorOptions{
+		Package:  "widgets",
+		Version:  "0.0.1",
+		Language: "nodejs",
+		ProviderInfo: tfbridge.ProviderInfo{
+			Name: "widgets",
+			Resources: map[string]*tfbridge.ResourceInfo{
+				"widgets_widget": {
+					Tok:  "widgets:index/widget:Widget",
+					Docs: &tfbridge.DocInfo{Markdown: []byte(markdown)},
+				},
+			},
+		},
+		Sink: diag.DefaultSink(io.Discard, io.Discard, diag.FormatOptions{
+			Color: colors.Never,
+		}),
+	})
+	assert.NoError(t, err)
+
+	docs, ok, err := g.DocsForToken("widgets:index/widget:Widget")
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, ArgumentDocs{Description: "The name of the widget.", Required: true}, docs.Arguments["name"])
+	assert.Equal(t, ArgumentDocs{Description: "The size of the widget.", Default: "3"}, docs.Arguments["size"])
+}
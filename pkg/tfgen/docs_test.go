@@ -17,11 +17,20 @@ package tfgen
 
 import (
 	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
 	"strings"
 	"testing"
 	"text/template"
 
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/diag"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/diag/colors"
+
 	"github.com/pulumi/pulumi-terraform-bridge/v3/pkg/tfbridge"
+	shimv1 "github.com/pulumi/pulumi-terraform-bridge/v3/pkg/tfshim/sdk-v1"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -48,6 +57,10 @@ func TestURLRewrite(t *testing.T) {
 			Input:    "See google_container_node_pool for schema.",
 			Expected: "See google.container.NodePool for schema.",
 		},
+		{
+			Input:    "Set `node_count` as described in [the `node_pool` guide](https://cloud.google.com/kubernetes-engine/docs/node_pools?filter=\"machine_type\" [node_config]).", // nolint: lll
+			Expected: "Set `nodeCount` as described in [the `nodePool` guide](https://cloud.google.com/kubernetes-engine/docs/node_pools?filter=\"machine_type\" [node_config]).",   // nolint: lll
+		},
 	}
 
 	g, err := NewGenerator(GeneratorOptions{
@@ -64,11 +77,256 @@ func TestURLRewrite(t *testing.T) {
 	assert.NoError(t, err)
 
 	for _, test := range tests {
-		text, _ := reformatText(g, test.Input, nil)
+		text, _ := reformatText(g, test.Input, nil, nil)
 		assert.Equal(t, test.Expected, text)
 	}
 }
 
+func TestEscapeMarkdown(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"Use arn:aws:s3:::* to match any bucket.", `Use arn:aws:s3:::\* to match any bucket.`},
+		{"A list, e.g. [a, b], of *globs*.", `A list, e.g. \[a, b\], of \*globs\*.`},
+		{"The name of a_b_c.", `The name of a\_b\_c.`},
+		// Code spans, links, bare URLs, and HTML tags are left alone.
+		{"Set `tags[*]` to match any tag.", "Set `tags[*]` to match any tag."},
+		{"See [the_docs](https://example.com/a_b) or https://example.com/c_d.",
+			"See [the_docs](https://example.com/a_b) or https://example.com/c_d."},
+		{"In the form <account_id>:*.", `In the form <account_id>:\*.`},
+		// List markers and strong emphasis are intentional.
+		{"* A bullet with a *.\n- Another.", "* A bullet with a \\*.\n- Another."},
+		{"> **NOTE:** Use * with care.", `> **NOTE:** Use \* with care.`},
+		// Escaped characters are not escaped again.
+		{`Already \* escaped \[x\].`, `Already \* escaped \[x\].`},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.expected, escapeMarkdown(test.input))
+	}
+
+	newGenerator := func(escapeMarkdown bool) *Generator {
+		g, err := NewGenerator(GeneratorOptions{
+			Package:        "widgets",
+			Version:        "0.0.1",
+			Language:       "nodejs",
+			ProviderInfo:   tfbridge.ProviderInfo{Name: "widgets"},
+			EscapeMarkdown: escapeMarkdown,
+			Sink: diag.DefaultSink(io.Discard, io.Discard, diag.FormatOptions{
+				Color: colors.Never,
+			}),
+		})
+		assert.NoError(t, err)
+		return g
+	}
+
+	// Markdown is only escaped on request, and never within code blocks.
+	input := "Matches *.\n\n```\nresource \"a_b\" \"c\" {}\n```"
+	text, _ := reformatText(newGenerator(true), input, nil, nil)
+	assert.Equal(t, "Matches \\*.\n\n```\nresource \"a_b\" \"c\" {}\n```", text)
+	text, _ = reformatText(newGenerator(false), input, nil, nil)
+	assert.Equal(t, input, text)
+}
+
+func TestTruncateDescription(t *testing.T) {
+	const url = "https://example.com/docs"
+	tests := []struct {
+		input     string
+		maxLength int
+		expected  string
+	}{
+		// Short descriptions are left alone.
+		{"The name of the widget.", 40, "The name of the widget."},
+		// Descriptions are cut at the last word boundary that fits, less any trailing punctuation.
+		{
+			"The name of the widget, which must be unique within the project.",
+			24,
+			"The name of the widget... See the [full documentation](https://example.com/docs).",
+		},
+		// Links and code spans are never split.
+		{
+			"See [the widget guide](https://example.com/guide) for details.",
+			24,
+			"See... See the [full documentation](https://example.com/docs).",
+		},
+		{
+			"Set `widget_count` to the number of widgets.",
+			16,
+			"Set... See the [full documentation](https://example.com/docs).",
+		},
+		{
+			"See [the widget guide](https://example.com/guide) for the details of widget naming.",
+			52,
+			"See [the widget guide](https://example.com/guide)... See the [full documentation](https://example.com/docs).",
+		},
+		// A description with no word boundary that fits is left alone.
+		{"Anextraordinarilylongword and more.", 16, "Anextraordinarilylongword and more."},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.expected, truncateDescription(test.input, test.maxLength, url), test.input)
+	}
+
+	assert.Equal(t, "https://registry.terraform.io/providers/acme/widgets/latest/docs/data-sources/gadget",
+		registryDocsURL("acme", "widgets", "widgets", DataSourceDocs, "widgets_gadget"))
+}
+
+func TestTruncateDescriptions(t *testing.T) {
+	g, err := NewGenerator(GeneratorOptions{
+		Package:              "widgets",
+		Version:              "0.0.1",
+		Language:             "nodejs",
+		ProviderInfo:         tfbridge.ProviderInfo{Name: "widgets", GitHubOrg: "acme"},
+		MaxDescriptionLength: 16,
+		Sink: diag.DefaultSink(io.Discard, io.Discard, diag.FormatOptions{
+			Color: colors.Never,
+		}),
+	})
+	assert.NoError(t, err)
+
+	doc := entityDocs{
+		Description: "Manages a widget, which is a very long description.",
+		Arguments: map[string]*argumentDocs{
+			"name": {
+				description: "The name of the widget.",
+				arguments:   map[string]string{"size": "The size of the widget."},
+			},
+		},
+		Attributes:       map[string]string{"arn": "The ARN."},
+		NestedAttributes: map[string]map[string]string{"status": {"state": "The state of the widget."}},
+	}
+
+	const suffix = "... See the [full documentation](" +
+		"https://registry.terraform.io/providers/acme/widgets/latest/docs/resources/widget)."
+	truncated := g.truncateDescriptions(doc, ResourceDocs, "widgets_widget")
+	assert.Equal(t, "Manages a widget, which is a very long description.", truncated.Description)
+	assert.Equal(t, "The name of the"+suffix, truncated.Arguments["name"].description)
+	assert.Equal(t, "The size of the"+suffix, truncated.Arguments["name"].arguments["size"])
+	assert.Equal(t, "The ARN.", truncated.Attributes["arn"])
+	assert.Equal(t, "The state of the"+suffix, truncated.NestedAttributes["status"]["state"])
+
+	// The original docs retain their full text.
+	assert.Equal(t, "The name of the widget.", doc.Arguments["name"].description)
+	assert.Equal(t, "The state of the widget.", doc.NestedAttributes["status"]["state"])
+}
+
+func TestConvertInlineHTML(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"Set <code>enabled</code> to enable it.", "Set `enabled` to enable it."},
+		{"The first line.<br>The second line.<br/>The third.<BR />", "The first line.\nThe second line.\nThe third.\n"},
+		{
+			`See <a href="https://example.com/docs" target="_blank">the docs</a> for details.`,
+			"See [the docs](https://example.com/docs) for details.",
+		},
+		{"A <b>bold</b> and <em class=\"x\">emphatic</em> claim.<p>", "A bold and emphatic claim."},
+		// Tags that may be placeholders are left alone.
+		{"The ARN, in the form arn:aws:iam::<account>:role/<name>.", "The ARN, in the form arn:aws:iam::<account>:role/<name>."},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.expected, convertInlineHTML(test.input))
+	}
+
+	newGenerator := func(convertInlineHTML bool) *Generator {
+		g, err := NewGenerator(GeneratorOptions{
+			Package:           "widgets",
+			Version:           "0.0.1",
+			Language:          "nodejs",
+			ProviderInfo:      tfbridge.ProviderInfo{Name: "widgets"},
+			ConvertInlineHTML: convertInlineHTML,
+			Sink: diag.DefaultSink(io.Discard, io.Discard, diag.FormatOptions{
+				Color: colors.Never,
+			}),
+		})
+		assert.NoError(t, err)
+		return g
+	}
+
+	// HTML is only converted on request, and never within code blocks.
+	input := "Use <code>enabled</code>.\n\n```\n<code>enabled</code>\n```"
+	text, _ := reformatText(newGenerator(true), input, nil, nil)
+	assert.Equal(t, "Use `enabled`.\n\n```\n<code>enabled</code>\n```", text)
+	text, _ = reformatText(newGenerator(false), input, nil, nil)
+	assert.Equal(t, input, text)
+}
+
+func TestCamelCaseWithAcronyms(t *testing.T) {
+	acronyms := []string{"ARN", "URL", "HTTP", "CIDR", "VPC", "IPv6"}
+	tests := []struct {
+		name     string
+		expected string
+	}{
+		{"vpc_id", "vpcId"},
+		{"db_arn", "dbARN"},
+		{"arn_prefix", "arnPrefix"},
+		{"http_url", "httpURL"},
+		{"subnet_vpc_cidr_block", "subnetVPCCIDRBlock"},
+		{"ipv6_cidr", "ipv6CIDR"},
+		{"subnet_ipv6_cidr", "subnetIPv6CIDR"},
+		{"name", "name"},
+		{"_internal_arn", "_internalArn"},
+	}
+	for _, test := range tests {
+		assert.Equal(t, test.expected, camelCaseWithAcronyms(test.name, acronyms), test.name)
+	}
+
+	// Without acronyms, names are camelized as usual.
+	assert.Equal(t, "dbArn", camelCaseWithAcronyms("db_arn", nil))
+
+	g, err := NewGenerator(GeneratorOptions{
+		Package:      "widgets",
+		Version:      "0.0.1",
+		Language:     "nodejs",
+		ProviderInfo: tfbridge.ProviderInfo{Name: "widgets"},
+		Acronyms:     acronyms,
+		Sink: diag.DefaultSink(io.Discard, io.Discard, diag.FormatOptions{
+			Color: colors.Never,
+		}),
+	})
+	assert.NoError(t, err)
+
+	text, _ := reformatText(g, "Conflicts with `db_arn`, `arn_prefix`, and `vpc_id`.", nil, nil)
+	assert.Equal(t, "Conflicts with `dbARN`, `arnPrefix`, and `vpcId`.", text)
+
+	// Renames take precedence over acronyms.
+	text, _ = reformatText(g, "Conflicts with `db_arn`.", nil, map[string]string{"db_arn": "databaseArn"})
+	assert.Equal(t, "Conflicts with `databaseArn`.", text)
+}
+
+func TestFixupPropertyReferencesWithTokenStrategy(t *testing.T) {
+	widget := &schema.Resource{Schema: map[string]*schema.Schema{
+		"size": {Type: schema.TypeInt, Optional: true},
+	}}
+	info := tfbridge.ProviderInfo{
+		P: shimv1.NewProvider(&schema.Provider{
+			ResourcesMap:   map[string]*schema.Resource{"widgets_widget": widget},
+			DataSourcesMap: map[string]*schema.Resource{"widgets_thing": widget},
+		}),
+		Name: "widgets",
+		TokenStrategy: func(tfToken string) (string, bool) {
+			switch tfToken {
+			case "widgets_widget":
+				return "widgets:index/widget:Widget", true
+			case "widgets_thing":
+				return "widgets:index/getThing:getThing", true
+			case "widgets_gadget":
+				return "widgets:index/gadget:Gadget", true
+			}
+			return "", false
+		},
+	}
+
+	// References to the entities of the provider are resolved through the TokenStrategy, while names that the provider
+	// does not define are treated as property names.
+	text := "See `widgets_widget`, `widgets_thing` and `widgets_gadget`."
+	assert.Equal(t, "See `widgets.Widget`, `widgets.getThing` and `widgetsGadget`.",
+		fixupPropertyReferences(NodeJS, "widgets", info, nil, nil, nil, text))
+}
+
 func TestArgumentRegex(t *testing.T) {
 	tests := []struct {
 		input    []string
@@ -208,6 +466,104 @@ func TestArgumentRegex(t *testing.T) {
 				},
 			},
 		},
+		{
+			input: []string{
+				"* `name` - (Required) The name of the policy.",
+				"* `rule` - (Optional) A `rule` block as defined below.",
+				"* `schedule` - (Optional) A `schedule` block as defined below.",
+				"",
+				"---",
+				"",
+				"A `rule` block supports the following:",
+				"",
+				"* `action` - (Required) The action to take.",
+				"",
+				"---",
+				"",
+				"* `schedule` supports the following:",
+				"",
+				"* `days` - (Required) The number of days between runs.",
+			},
+			expected: map[string]*argumentDocs{
+				"name": {
+					description: "The name of the policy.",
+				},
+				"rule": {
+					description: "A `rule` block as defined below.",
+					arguments: map[string]string{
+						"action": "The action to take.",
+					},
+				},
+				"schedule": {
+					description: "A `schedule` block as defined below.",
+					arguments: map[string]string{
+						"days": "The number of days between runs.",
+					},
+				},
+				"action": {
+					description: "The action to take.",
+					isNested:    true,
+				},
+				"days": {
+					description: "The number of days between runs.",
+					isNested:    true,
+				},
+			},
+		},
+		{
+			input: []string{
+				"| Name | Description |",
+				"|------|-------------|",
+				"| `name` | The name of the widget. |",
+				"| `size` | The size of the widget. |",
+			},
+			expected: map[string]*argumentDocs{
+				"name": {
+					description: "The name of the widget.",
+				},
+				"size": {
+					description: "The size of the widget.",
+				},
+			},
+		},
+		{
+			input: []string{
+				"| Name | Description | Type | Required | Default |",
+				"|------|-------------|:----:|:--------:|---------|",
+				"| `name` | The name of the widget. | `string` | yes | n/a |",
+				"| `color` | The color of the widget | `string` | no | `\"blue\"` |",
+				"| `replicas` | The number of replicas. | `number` | no | 3 |",
+				"",
+				"The `scaling` block supports the following:",
+				"",
+				"| Name | Description | Default |",
+				"|------|-------------|---------|",
+				"| `min` | The minimum size. | - |",
+			},
+			expected: map[string]*argumentDocs{
+				"name": {
+					description: "The name of the widget.",
+					isRequired:  true,
+				},
+				"color": {
+					description:  "The color of the widget",
+					defaultValue: "\"blue\"",
+				},
+				"replicas": {
+					description:  "The number of replicas.",
+					defaultValue: "3",
+				},
+				"scaling": {
+					arguments: map[string]string{
+						"min": "The minimum size.",
+					},
+				},
+				"min": {
+					description: "The minimum size.",
+					isNested:    true,
+				},
+			},
+		},
 		{
 			input: []string{
 				"* `launch_template_config` - (Optional) Launch template configuration block. See [Launch Template Configs](#launch-template-configs) below for more details. Conflicts with `launch_specification`. At least one of `launch_specification` or `launch_template_config` is required.",
@@ -252,6 +608,170 @@ func TestArgumentRegex(t *testing.T) {
 				},
 			},
 		},
+		{
+			// Hyphenated block names in headers resolve to the underscored argument name. Names that don't match an
+			// argument are kept as-is.
+			input: []string{
+				"* `result_configuration` - (Optional) Configuration block with result settings.",
+				"",
+				"#### result-configuration Argument Reference",
+				"",
+				"* `output_location` - (Optional) The location in Amazon S3 where your query results are stored.",
+				"",
+				"#### encryption-options Argument Reference",
+				"",
+				"* `kms_key` - (Optional) The KMS key ARN.",
+			},
+			expected: map[string]*argumentDocs{
+				"result_configuration": {
+					description: "Configuration block with result settings.",
+					arguments: map[string]string{
+						"output_location": "The location in Amazon S3 where your query results are stored.",
+					},
+				},
+				"output_location": {
+					description: "The location in Amazon S3 where your query results are stored.",
+					isNested:    true,
+				},
+				"encryption-options": {
+					arguments: map[string]string{
+						"kms_key": "The KMS key ARN.",
+					},
+				},
+				"kms_key": {
+					description: "The KMS key ARN.",
+					isNested:    true,
+				},
+			},
+		},
+		{
+			// Prose subsection headers interleaved with the arguments create no phantom blocks, while headers that name
+			// an existing argument introduce its nested block.
+			input: []string{
+				"* `name` - (Required) The name of the widget.",
+				"* `settings` - (Optional) The widget's settings. Documented below.",
+				"",
+				"### Using `legacy_mode` with the following providers",
+				"",
+				"Legacy mode is deprecated.",
+				"",
+				"### `settings` Configuration Block",
+				"",
+				"* `color` - (Optional) The color of the widget.",
+				"",
+				"### Advanced Options",
+				"",
+				"* `timeout` - (Optional) How long to wait for the widget.",
+			},
+			expected: map[string]*argumentDocs{
+				"name": {
+					description: "The name of the widget.",
+				},
+				"settings": {
+					description: "The widget's settings. Documented below.",
+					arguments: map[string]string{
+						"color": "The color of the widget.",
+					},
+				},
+				"color": {
+					description: "The color of the widget.",
+					isNested:    true,
+				},
+				"timeout": {
+					description: "How long to wait for the widget.",
+				},
+			},
+		},
+		{
+			// GCP docs prefix the nested block intro sentence with an HTML anchor.
+			input: []string{
+				"* `conditions` - (Optional) A list of conditions. Structure is [documented below](#nested_conditions).",
+				"",
+				"<a name=\"nested_conditions\"></a>The `conditions` block supports:",
+				"",
+				"* `display_name` - (Required) A short name or phrase used to identify the condition.",
+			},
+			expected: map[string]*argumentDocs{
+				"conditions": {
+					description: "A list of conditions. Structure is [documented below](#nested_conditions).",
+					arguments: map[string]string{
+						"display_name": "A short name or phrase used to identify the condition.",
+					},
+				},
+				"display_name": {
+					description: "A short name or phrase used to identify the condition.",
+					isNested:    true,
+				},
+			},
+		},
+		{
+			// Descriptions may wrap onto a line that starts with a footer reference.
+			input: []string{
+				"* `launch_specification` - (Optional) Used to define the launch configuration of the",
+				"[spot-fleet request][1]. Can be specified multiple times.",
+				"* `spot_price` - (Optional) The maximum bid price per unit hour, as described in",
+				"[1] and [2].",
+				"* `valid_until` - (Optional) The end date and time of the request.",
+				"[1]: https://docs.aws.amazon.com/AWSEC2/latest/UserGuide/spot-fleet.html",
+				"[2]: https://docs.aws.amazon.com/AWSEC2/latest/UserGuide/spot-bid-status.html",
+			},
+			expected: map[string]*argumentDocs{
+				"launch_specification": {
+					description: "Used to define the launch configuration of the\n[spot-fleet request][1]. Can be specified multiple times.",
+				},
+				"spot_price": {
+					description: "The maximum bid price per unit hour, as described in\n[1] and [2].",
+				},
+				"valid_until": {
+					description: "The end date and time of the request.",
+				},
+			},
+		},
+		{
+			// Bullets in a sub-list of a top-level bullet document the arguments of its block, including when a
+			// later intro names the same block.
+			input: []string{
+				"* `name` - (Required) The name of the bucket.",
+				"* `logging` - (Optional) The logging settings of the bucket.",
+				"    * `target_bucket` - (Required) The bucket that receives the logs,",
+				"    which must exist.",
+				"",
+				"    * `target_prefix` - (Optional) The prefix of the log objects.",
+				"* `versioning` - (Optional) Whether versioning is enabled.",
+				"",
+				"The `logging` block supports:",
+				"",
+				"* `format` - (Optional) The format of the log objects.",
+			},
+			expected: map[string]*argumentDocs{
+				"name": {
+					description: "The name of the bucket.",
+				},
+				"logging": {
+					description: "The logging settings of the bucket.",
+					arguments: map[string]string{
+						"target_bucket": "The bucket that receives the logs,\nwhich must exist.",
+						"target_prefix": "The prefix of the log objects.",
+						"format":        "The format of the log objects.",
+					},
+				},
+				"target_bucket": {
+					description: "The bucket that receives the logs,\nwhich must exist.",
+					isNested:    true,
+				},
+				"target_prefix": {
+					description: "The prefix of the log objects.",
+					isNested:    true,
+				},
+				"format": {
+					description: "The format of the log objects.",
+					isNested:    true,
+				},
+				"versioning": {
+					description: "Whether versioning is enabled.",
+				},
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -322,6 +842,46 @@ func TestReplaceFooterLinks(t *testing.T) {
 	assert.Equal(t, inputText, actual)
 }
 
+func TestSharedFooterLinks(t *testing.T) {
+	shared := getSharedFooterLinks([]string{
+		"# a\n\n[1]: https://example.com/guide\n[2]: https://example.com/a\n",
+		"# b\n\n[1]: https://example.com/guide\n[2]: https://example.com/b\n",
+	})
+	// Inconsistently defined references are ambiguous and are omitted.
+	assert.Equal(t, map[string]string{"[1]": "https://example.com/guide"}, shared)
+
+	g, err := NewGenerator(GeneratorOptions{
+		Package:      "widgets",
+		Version:      "0.0.1",
+		Language:     "nodejs",
+		ProviderInfo: tfbridge.ProviderInfo{Name: "widgets"},
+		Sink: diag.DefaultSink(io.Discard, io.Discard, diag.FormatOptions{
+			Color: colors.Never,
+		}),
+	})
+	assert.NoError(t, err)
+	g.sharedFooterLinks = map[string]string{
+		"[1]": "https://example.com/shared-guide",
+		"[2]": "https://example.com/shared-overview",
+	}
+
+	markdown := "# widgets_widget\n\nProvides a widget. See [The Guide][1] and [The Overview][2].\n\n" +
+		"## Argument Reference\n\n* `name` - (Required) The name of the widget.\n\n" +
+		"[2]: https://example.com/local-overview\n"
+
+	// A reference that is only defined by a sibling doc resolves via the shared table, while a locally-defined
+	// reference wins over the shared one.
+	doc, err := parseTFMarkdown(g, nil, ResourceDocs, markdown, "widget.html.markdown", "widgets", "widgets_widget")
+	assert.NoError(t, err)
+	assert.Contains(t, doc.Description, "[The Guide](https://example.com/shared-guide)")
+	assert.Contains(t, doc.Description, "[The Overview](https://example.com/local-overview)")
+
+	// Docs that are not part of the doc set do not consult the shared table.
+	doc, err = parseTFMarkdown(g, nil, ResourceDocs, markdown, "", "widgets", "widgets_widget")
+	assert.NoError(t, err)
+	assert.Contains(t, doc.Description, "[The Guide][1]")
+}
+
 func TestFixExamplesHeaders(t *testing.T) {
 	codeFence := "```"
 	t.Run("WithCodeFences", func(t *testing.T) {
@@ -379,6 +939,26 @@ Misleading example title without any actual code fences. We should not modify th
 	})
 }
 
+func TestNormalizeFenceLanguages(t *testing.T) {
+	lines := []string{
+		"```tf", "a", "```",
+		"```HCL", "b", "```",
+		"```Terraform", "c", "```",
+		"```hcl", "d", "```",
+		"```sh", "e", "```",
+		"```", "f", "```",
+	}
+	normalizeFenceLanguages(lines)
+	assert.Equal(t, []string{
+		"```hcl", "a", "```",
+		"```hcl", "b", "```",
+		"```hcl", "c", "```",
+		"```hcl", "d", "```",
+		"```sh", "e", "```",
+		"```", "f", "```",
+	}, lines)
+}
+
 func TestExtractExamples(t *testing.T) {
 	basic := `Previews a CIDR from an IPAM address pool. Only works for private IPv4.
 
@@ -401,6 +981,56 @@ Basic usage:`
 	Some other use case
 `
 	assert.Equal(t, "", extractExamples(multipleExampleUsages))
+
+	// Text of the description proper that recurs in the examples is kept in the examples.
+	recurring := "Manages a widget.\n\n## Example Usage\n\n```hcl\n# Manages a widget.\n\nresource \"a\" \"a\" {}\n```"
+	assert.Equal(t, "## Example Usage\n\n```hcl\n# Manages a widget.\n\nresource \"a\" \"a\" {}\n```",
+		extractExamples(recurring))
+
+	// The examples end at the next H2, even if it follows them, but not at comments in their code.
+	examplesFirst := "## # test_widget\n\n## Example Usage\n\n```hcl\n## A comment\nresource \"a\" \"a\" {}\n```\n\n" +
+		"## Overview\n\nManages a widget.\n"
+	assert.Equal(t, "## Example Usage\n\n```hcl\n## A comment\nresource \"a\" \"a\" {}\n```",
+		extractExamples(examplesFirst))
+}
+
+func TestExamplesBeforeDescription(t *testing.T) {
+	markdown := "---\nsubcategory: \"Widgets\"\n---\n\n# test_widget\n\n## Example Usage\n\n" +
+		"```hcl\nresource \"test_widget\" \"a\" {}\n```\n\n" +
+		"## Overview\n\nManages a widget.\n\n" +
+		"## Argument Reference\n\n* `name` - (Optional) The name of the widget.\n"
+
+	sections := moveExamplesAfterDescription(splitGroupLines(markdown, "## "))
+	headers := make([]string, len(sections))
+	for i, s := range sections {
+		headers[i] = s[0]
+	}
+	assert.Equal(t, []string{"---", "## Overview", "## Example Usage", "## Argument Reference"}, headers)
+
+	// Examples that follow the description are left in place.
+	inOrder := "# test_widget\n\nManages a widget.\n\n## Example Usage\n\n```hcl\nresource \"test_widget\" \"a\" {}\n" +
+		"```\n\n## Notes\n\nSome notes.\n"
+	assert.Equal(t, splitGroupLines(inOrder, "## "), moveExamplesAfterDescription(splitGroupLines(inOrder, "## ")))
+
+	g, err := NewGenerator(GeneratorOptions{
+		Package:      "test",
+		Version:      "0.0.1",
+		Language:     "nodejs",
+		ProviderInfo: tfbridge.ProviderInfo{Name: "test"},
+		Sink: diag.DefaultSink(io.Discard, io.Discard, diag.FormatOptions{
+			Color: colors.Never,
+		}),
+	})
+	assert.NoError(t, err)
+
+	doc, err := parseTFMarkdown(g, nil, ResourceDocs, markdown, "widget.html.markdown", "test", "test_widget")
+	assert.NoError(t, err)
+	overview, examples := strings.Index(doc.Description, "Manages a widget."), strings.Index(doc.Description,
+		"## Example Usage")
+	assert.NotEqual(t, -1, overview)
+	assert.NotEqual(t, -1, examples)
+	assert.Less(t, overview, examples)
+	assert.Contains(t, doc.Arguments, "name")
 }
 
 func TestReformatExamples(t *testing.T) {
@@ -497,6 +1127,34 @@ content 2`
 	runTest(gcpDoc2, gcpDoc2Expected)
 }
 
+func TestReformatExamplesPreservesAdmonitions(t *testing.T) {
+	input := "description\n\n## Example Usage\n\n```hcl\nresource \"a\" \"a\" {}\n```\n" +
+		"-> **Note** The second example requires the first.\n```tf\nresource \"b\" \"b\" {\n" +
+		"~> not an admonition\n}\n```\n\n~> **Warning** Use with care.\n"
+
+	assert.Equal(t, [][]string{
+		{"description", ""},
+		{
+			"## Example Usage",
+			"",
+			"```hcl",
+			`resource "a" "a" {}`,
+			"```",
+			"",
+			"-> **Note** The second example requires the first.",
+			"",
+			"```hcl",
+			`resource "b" "b" {`,
+			"~> not an admonition",
+			"}",
+			"```",
+			"",
+			"~> **Warning** Use with care.",
+			"",
+		},
+	}, reformatExamples(splitGroupLines(input, "## ")))
+}
+
 func TestFormatEntityName(t *testing.T) {
 	assert.Equal(t, "'prov_entity'", formatEntityName("prov_entity"))
 	assert.Equal(t, "'prov_entity' (aliased or renamed)", formatEntityName("prov_entity_legacy"))
@@ -548,7 +1206,11 @@ FooFactory fooFactory = new FooFactory();
 	var buf = bytes.Buffer{}
 	_ = outputTemplate.Execute(&buf, data)
 
-	assert.Equal(t, buf.String(), hclConversionsToString(input))
+	assert.Equal(t, buf.String(), hclConversionsToString(input, nil))
+
+	// A filter restricts the output to the given languages, which keep their usual order.
+	assert.Equal(t, "```python\nfoo = bar\n```\n```go\nfoo := bar\n```",
+		hclConversionsToString(input, []string{"go", "python"}))
 }
 
 func TestGroupLines(t *testing.T) {
@@ -587,35 +1249,125 @@ subtitle 2 content
 func TestParseArgFromMarkdownLine(t *testing.T) {
 	// nolint:lll
 	tests := []struct {
-		input         string
-		expectedName  string
-		expectedDesc  string
-		expectedFound bool
+		input              string
+		expectedName       string
+		expectedDesc       string
+		expectedDeprecated string
+		expectedFound      bool
 	}{
-		{"* `name` - (Required) A unique name to give the role.", "name", "A unique name to give the role.", true},
-		{"* `key_vault_key_id` - (Optional) The Key Vault key URI for CMK encryption. Changing this forces a new resource to be created.", "key_vault_key_id", "The Key Vault key URI for CMK encryption. Changing this forces a new resource to be created.", true},
+		{"* `name` - (Required) A unique name to give the role.", "name", "A unique name to give the role.", "", true},
+		{"* `key_vault_key_id` - (Optional) The Key Vault key URI for CMK encryption. Changing this forces a new resource to be created.", "key_vault_key_id", "The Key Vault key URI for CMK encryption. Changing this forces a new resource to be created.", "", true},
 		// In rare cases, we may have a match where description is empty like the following, taken from https://github.com/hashicorp/terraform-provider-aws/blob/main/website/docs/r/spot_fleet_request.html.markdown
-		{"* `instance_pools_to_use_count` - (Optional; Default: 1)", "instance_pools_to_use_count", "", true},
-		{"", "", "", false},
-		{"Most of these arguments directly correspond to the", "", "", false},
+		{"* `instance_pools_to_use_count` - (Optional; Default: 1)", "instance_pools_to_use_count", "", "", true},
+		{"", "", "", "", false},
+		{"Most of these arguments directly correspond to the", "", "", "", false},
+		// Deprecated arguments.
+		{"* `old_field` - (Optional, **Deprecated**) Use `new_field` instead.", "old_field", "Use `new_field` instead.", "Use `new_field` instead.", true},
+		{"* `old_field` (Deprecated) - Use `new_field` instead.", "old_field", "Use `new_field` instead.", "Use `new_field` instead.", true},
+		{"* `old_field` - (Optional) **Deprecated:** Use `new_field` instead.", "old_field", "Use `new_field` instead.", "Use `new_field` instead.", true},
+		{"* `old_field` - (Optional) **Deprecated**: Use `new_field` instead.", "old_field", "Use `new_field` instead.", "Use `new_field` instead.", true},
+		{"* `old_field` - (Optional) Deprecated: Use `new_field` instead.", "old_field", "Use `new_field` instead.", "Use `new_field` instead.", true},
+		{"* `old_field` - (Optional) The old field. **DEPRECATED** Use `new_field` instead.", "old_field", "The old field. Use `new_field` instead.", "Use `new_field` instead.", true},
+		{"* `old_field` - (Optional, Deprecated)", "old_field", "", "Deprecated", true},
+		// A mention of deprecation that is not a marker does not deprecate the argument.
+		{"* `new_field` - (Optional) Replaces the deprecated `old_field`.", "new_field", "Replaces the deprecated `old_field`.", "", true},
+		// Code spans that assign a value to the argument.
+		{"* `name = \"value\"` - (Optional) The name of the widget.", "name", "The name of the widget. Defaults to `\"value\"`.", "", true},
+		{"* `retries=3` - (Optional) The number of retries", "retries", "The number of retries. Defaults to `3`.", "", true},
+		{"* `timeout = 30` - (Optional) The timeout. Defaults to 30 seconds.", "timeout", "The timeout. Defaults to 30 seconds.", "", true},
+		{"* `enabled = true` - (Optional)", "enabled", "Defaults to `true`.", "", true},
+		{"* `tags {}` - (Optional) A map of tags.", "tags", "A map of tags.", "", true},
+		{"* `snake_case_name` - (Optional) Names without spaces are left intact.", "snake_case_name", "Names without spaces are left intact.", "", true},
+		// Numbered and dash bullets.
+		{"1. `name` - (Required) The name of the widget.", "name", "The name of the widget.", "", true},
+		{"12. `size` - (Optional) The size of the widget.", "size", "The size of the widget.", "", true},
+		{"- `name` - (Required) The name of the widget.", "name", "The name of the widget.", "", true},
+		{"+ `name` - (Required) The name of the widget.", "name", "The name of the widget.", "", true},
+		{"1. Create the widget before configuring it.", "", "", "", false},
+		{"2. Name the widget - the name must be unique.", "", "", "", false},
 	}
 
 	for _, test := range tests {
-		name, desc, found := parseArgFromMarkdownLine(test.input)
+		name, desc, deprecationMessage, _, found := parseArgFromMarkdownLine(test.input)
 		assert.Equal(t, test.expectedName, name)
 		assert.Equal(t, test.expectedDesc, desc)
+		assert.Equal(t, test.expectedDeprecated, deprecationMessage)
 		assert.Equal(t, test.expectedFound, found)
 	}
 }
 
-func TestGetNestedBlockName(t *testing.T) {
-	var tests = []struct {
-		input, expected string
+func TestParseEntityDeprecation(t *testing.T) {
+	tests := []struct {
+		description string
+		expected    string
 	}{
-		{"", ""},
-		{"The `website` object supports the following:", "website"},
-		{"#### result_configuration Argument Reference", "result_configuration"},
-		// This is a common starting line of base arguments, so should result in zero value:
+		{"~> **Deprecated:** Use the `widgets_gadget` resource instead.", "Use the `widgets_gadget` resource instead."},
+		{
+			"!> **WARNING:** This resource is deprecated and will be removed\nin the next major version.\n\nManages a widget.",
+			"This resource is deprecated and will be removed in the next major version.",
+		},
+		{"-> This data source has been deprecated in favor of `widgets_gadgets`.",
+			"This data source has been deprecated in favor of `widgets_gadgets`."},
+		{"**DEPRECATED**\n\nManages a widget.", "Deprecated"},
+		// Deprecation that is mentioned incidentally is ignored.
+		{"Manages a widget. This resource is deprecated.", ""},
+		{"Manages a widget.\n\n~> **NOTE:** This resource is deprecated.", ""},
+		{"~> **NOTE:** The `size` argument is deprecated.", ""},
+		{"Manages a widget. The `size` argument is **Deprecated**.", ""},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.expected, parseEntityDeprecation(test.description), test.description)
+	}
+}
+
+func TestParseDeprecatedResource(t *testing.T) {
+	markdown := `---
+layout: "widgets"
+page_title: "Widgets: widgets_widget"
+---
+
+# widgets_widget
+
+~> **Deprecated:** This resource has been deprecated in favor of ` + "`widgets_gadget`" + `.
+
+Manages a widget.
+
+## Argument Reference
+
+* ` + "`name`" + ` - (Required) The name of the widget.
+`
+
+	g, err := NewGenerator(GeneratorOptions{
+		Package:      "widgets",
+		Version:      "0.0.1",
+		Language:     "nodejs",
+		ProviderInfo: tfbridge.ProviderInfo{Name: "widgets"},
+		Sink: diag.DefaultSink(io.Discard, io.Discard, diag.FormatOptions{
+			Color: colors.Never,
+		}),
+	})
+	assert.NoError(t, err)
+
+	doc, err := parseTFMarkdown(g, nil, ResourceDocs, markdown, "widget.html.markdown", "widgets", "widgets_widget")
+	assert.NoError(t, err)
+	assert.Equal(t, "This resource has been deprecated in favor of `widgets_gadget`.", doc.DeprecationMessage)
+	assert.Equal(t, "", doc.Arguments["name"].deprecationMessage)
+}
+
+func TestGetNestedBlockName(t *testing.T) {
+	var tests = []struct {
+		input, expected string
+	}{
+		{"", ""},
+		{"The `website` object supports the following:", "website"},
+		{"#### result_configuration Argument Reference", "result_configuration"},
+		{"A `retention_policy` block supports:", "retention_policy"},
+		{"#### result-configuration Argument Reference", "result-configuration"},
+		{"<a name=\"nested_conditions\"></a>The `conditions` block supports:", "conditions"},
+		{"### Nested Schema for `settings`", "settings"},
+		{"### Nested Schema for `settings.backup_configuration`", "settings.backup_configuration"},
+		// This is a common starting line of base arguments, so should result in zero value:
 		{"The following arguments are supported:", ""},
 	}
 
@@ -652,92 +1404,1670 @@ func TestOverlayAttributesToAttributes(t *testing.T) {
 	assert.Equal(t, expected, dest)
 }
 
-func TestOverlayArgsToAttributes(t *testing.T) {
+func TestAttributeDescriptionOverrides(t *testing.T) {
+	markdown := `# widgets_widget
+
+Manages a widget.
+
+## Argument Reference
+
+* ` + "`name`" + ` - (Required) The name of the widget.
+
+## Attributes Reference
+
+* ` + "`arn`" + ` - The ARN as parsed from upstream.
+* ` + "`status`" + ` - The status of the widget.
+`
+
+	var stderr bytes.Buffer
+	g, err := NewGenerator(GeneratorOptions{
+		Package:  "widgets",
+		Version:  "0.0.1",
+		Language: "nodejs",
+		ProviderInfo: tfbridge.ProviderInfo{
+			Name: "widgets",
+			P: shimv1.NewProvider(&schema.Provider{
+				ResourcesMap: map[string]*schema.Resource{
+					"widgets_widget": {Schema: map[string]*schema.Schema{
+						"name":   {Type: schema.TypeString, Required: true},
+						"arn":    {Type: schema.TypeString, Computed: true},
+						"status": {Type: schema.TypeString, Computed: true},
+					}},
+				},
+			}),
+		},
+		Sink: diag.DefaultSink(io.Discard, &stderr, diag.FormatOptions{
+			Color: colors.Never,
+		}),
+	})
+	assert.NoError(t, err)
+
+	info := &tfbridge.ResourceInfo{
+		Tok: "widgets:index/widget:Widget",
+		Docs: &tfbridge.DocInfo{
+			Markdown: []byte(markdown),
+			AttributeDescriptions: map[string]string{
+				"arn":     "The ARN of the widget.",
+				"missing": "An attribute that does not exist.",
+			},
+		},
+	}
+	doc, err := getDocsForProvider(g, "", "widgets", "widgets", ResourceDocs, "widgets_widget", info, "", "")
+	assert.NoError(t, err)
+
+	assert.Equal(t, "The ARN of the widget.", doc.Attributes["arn"])
+	assert.Equal(t, "The status of the widget.", doc.Attributes["status"])
+	assert.Contains(t, stderr.String(), `unknown attribute "missing"`)
+	assert.NotContains(t, stderr.String(), `unknown attribute "arn"`)
+}
+
+func TestOverlayNestedAttributesToAttributes(t *testing.T) {
 	source := entityDocs{
-		Arguments: map[string]*argumentDocs{
-			"overwrite_me": {
-				description: "overwritten_desc",
+		NestedAttributes: map[string]map[string]string{
+			"environment": {
+				"overwrite_me": "overwritten_desc",
+				"source_only":  "source_only_desc",
 			},
-			"source_only": {
-				description: "source_only_desc",
+			"source_block": {
+				"name": "source_block_name_desc",
 			},
 		},
 	}
 
 	dest := entityDocs{
-		Attributes: map[string]string{
-			"overwrite_me": "original_desc",
-			"dest_only":    "dest_only_desc",
+		Attributes: map[string]string{},
+		NestedAttributes: map[string]map[string]string{
+			"environment": {
+				"overwrite_me": "original_desc",
+				"dest_only":    "dest_only_desc",
+			},
 		},
 	}
 
-	expected := entityDocs{
-		Attributes: map[string]string{
+	expected := map[string]map[string]string{
+		"environment": {
 			"overwrite_me": "overwritten_desc",
 			"source_only":  "source_only_desc",
 			"dest_only":    "dest_only_desc",
 		},
+		"source_block": {
+			"name": "source_block_name_desc",
+		},
 	}
 
-	overlayArgsToAttributes(source, dest)
+	overlayAttributesToAttributes(source, dest)
 
-	assert.Equal(t, expected, dest)
+	assert.Equal(t, expected, dest.NestedAttributes)
 }
 
-func TestOverlayArgsToArgs(t *testing.T) {
-	source := entityDocs{
-		Arguments: map[string]*argumentDocs{
-			"overwrite_me": {
-				description: "overwritten_desc",
-				arguments: map[string]string{
-					"nested_source_only":  "nested_source_only_desc",
-					"nested_overwrite_me": "nested_overwrite_me_overwritten_desc",
-				},
-			},
-			"source_only": {
-				description: "source_only_desc",
-				arguments:   map[string]string{},
-			},
-		},
-	}
+func TestParseNestedAttributes(t *testing.T) {
+	markdown := `# test_function
 
-	dest := entityDocs{
-		Arguments: map[string]*argumentDocs{
-			"overwrite_me": {
-				description: "original_desc",
-				arguments: map[string]string{
-					"nested_dest_only":    "should not appear",
-					"nested_overwrite_me": "nested_overwrite_me original desc",
-				},
-			},
-			"dest_only": {
-				description: "dest_only_desc",
-				arguments:   map[string]string{},
-			},
+Manages a function.
+
+## Attributes Reference
+
+In addition to all arguments above, the following attributes are exported:
+
+* ` + "`arn`" + ` - The ARN of the function.
+* ` + "`environment`" + ` - The environment of the function.
+* ` + "`name`" + ` - The name of the function.
+
+For ` + "`environment`" + ` the following attributes are supported:
+
+* ` + "`variables`" + ` - The variables of the environment.
+  Variables are exported as a map.
+* ` + "`name`" + ` - The name of the environment.
+`
+
+	g, err := NewGenerator(GeneratorOptions{
+		Package:      "test",
+		Version:      "0.0.1",
+		Language:     "nodejs",
+		ProviderInfo: tfbridge.ProviderInfo{Name: "test"},
+		Sink: diag.DefaultSink(io.Discard, io.Discard, diag.FormatOptions{
+			Color: colors.Never,
+		}),
+	})
+	assert.NoError(t, err)
+
+	doc, err := parseTFMarkdown(g, nil, ResourceDocs, markdown, "function.html.markdown", "test", "test_function")
+	assert.NoError(t, err)
+
+	assert.Equal(t, map[string]map[string]string{
+		"environment": {
+			"variables": "The variables of the environment.\nVariables are exported as a map.",
+			"name":      "The name of the environment.",
 		},
+	}, doc.NestedAttributes)
+
+	// Top-level attributes take precedence over nested attributes of the same name.
+	assert.Equal(t, "The name of the function.", doc.Attributes["name"])
+	assert.Equal(t, "The variables of the environment.\nVariables are exported as a map.", doc.Attributes["variables"])
+
+	desc, fromAttributes := getNestedDescriptionFromParsedDocs(doc, "environment", "name")
+	assert.Equal(t, "The name of the environment.", desc)
+	assert.True(t, fromAttributes)
+}
+
+func TestParseAttributesTransition(t *testing.T) {
+	tests := []struct {
+		name       string
+		transition string
+	}{
+		{"exported", "In addition to all arguments above, the following attributes are exported:"},
+		{"available", "In addition to the arguments listed above, the following attributes are available:"},
+		{"without prefix", "The following attributes are exported:"},
 	}
 
-	expected := entityDocs{
-		Arguments: map[string]*argumentDocs{
-			"overwrite_me": {
-				description: "overwritten_desc",
-				arguments: map[string]string{
-					"nested_source_only":  "nested_source_only_desc",
-					"nested_overwrite_me": "nested_overwrite_me_overwritten_desc",
-				},
-			},
-			"source_only": {
-				description: "source_only_desc",
-				arguments:   map[string]string{},
-			},
-			"dest_only": {
-				description: "dest_only_desc",
-				arguments:   map[string]string{},
-			},
-		},
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			markdown := `# test_function
+
+Manages a function.
+
+## Argument Reference
+
+The following arguments are supported:
+
+* ` + "`name`" + ` - (Required) The name of the function.
+
+` + tt.transition + `
+
+* ` + "`arn`" + ` - The ARN of the function.
+  The ARN is unique.
+
+### Versions
+
+* ` + "`version`" + ` - The latest version of the function.
+`
+
+			g, err := NewGenerator(GeneratorOptions{
+				Package:      "test",
+				Version:      "0.0.1",
+				Language:     "nodejs",
+				ProviderInfo: tfbridge.ProviderInfo{Name: "test"},
+				Sink: diag.DefaultSink(io.Discard, io.Discard, diag.FormatOptions{
+					Color: colors.Never,
+				}),
+			})
+			assert.NoError(t, err)
+
+			doc, err := parseTFMarkdown(g, nil, ResourceDocs, markdown, "function.html.markdown", "test",
+				"test_function")
+			assert.NoError(t, err)
+
+			assert.Len(t, doc.Arguments, 1)
+			assert.Equal(t, "The name of the function.", doc.Arguments["name"].description)
+			assert.Equal(t, map[string]string{
+				"arn":     "The ARN of the function.\nThe ARN is unique.",
+				"version": "The latest version of the function.",
+			}, doc.Attributes)
+		})
 	}
+}
 
-	overlayArgsToArgs(source, dest)
+func TestParseFrameworkNestedSchema(t *testing.T) {
+	// Framework-style docs that use "Nested Schema" sections within the legacy reference sections.
+	framework := `# test_database
 
-	assert.Equal(t, expected, dest)
+Manages a database.
+
+## Argument Reference
+
+- ` + "`name`" + ` (String) The name of the database.
+- ` + "`settings`" + ` (Attributes) The settings of the database. (see [below for nested schema](#nestedatt--settings))
+
+<a id="nestedatt--settings"></a>
+### Nested Schema for ` + "`settings`" + `
+
+Required:
+
+- ` + "`tier`" + ` (String) The tier of the database.
+
+Optional:
+
+- ` + "`backup_configuration`" + ` (Attributes) The backup configuration. (see [below for nested schema](#nestedatt--settings--backup_configuration))
+
+<a id="nestedatt--settings--backup_configuration"></a>
+### Nested Schema for ` + "`settings.backup_configuration`" + `
+
+Optional:
+
+- ` + "`enabled`" + ` (Boolean) Whether backups are enabled.
+
+## Attributes Reference
+
+- ` + "`id`" + ` (String) The ID of the database.
+- ` + "`status`" + ` (Attributes) The status of the database.
+
+### Nested Schema for ` + "`status`" + `
+
+- ` + "`state`" + ` (String) The state of the database.
+`
+
+	// Legacy docs parsed by the same generator.
+	legacy := `# test_user
+
+Manages a user.
+
+## Argument Reference
+
+* ` + "`name`" + ` - (Required) The name of the user.
+* ` + "`password`" + ` - (Optional) The password of the user.
+
+The ` + "`password`" + ` block supports:
+
+* ` + "`value`" + ` - (Required) The value of the password.
+
+## Attributes Reference
+
+* ` + "`id`" + ` - The ID of the user.
+`
+
+	g, err := NewGenerator(GeneratorOptions{
+		Package:      "test",
+		Version:      "0.0.1",
+		Language:     "nodejs",
+		ProviderInfo: tfbridge.ProviderInfo{Name: "test"},
+		Sink: diag.DefaultSink(io.Discard, io.Discard, diag.FormatOptions{
+			Color: colors.Never,
+		}),
+	})
+	assert.NoError(t, err)
+
+	doc, err := parseTFMarkdown(g, nil, ResourceDocs, framework, "database.md", "test", "test_database")
+	assert.NoError(t, err)
+
+	assert.Equal(t, "The name of the database.", doc.Arguments["name"].description)
+	assert.Equal(t, map[string]string{
+		"tier":                 "The tier of the database.",
+		"backup_configuration": "The backup configuration.",
+	}, doc.Arguments["settings"].arguments)
+	assert.Equal(t, map[string]string{
+		"enabled": "Whether backups are enabled.",
+	}, doc.Arguments["settings.backup_configuration"].arguments)
+
+	assert.Equal(t, "The ID of the database.", doc.Attributes["id"])
+	assert.Equal(t, map[string]string{"state": "The state of the database."}, doc.NestedAttributes["status"])
+
+	doc, err = parseTFMarkdown(g, nil, ResourceDocs, legacy, "user.html.markdown", "test", "test_user")
+	assert.NoError(t, err)
+
+	assert.Equal(t, "The name of the user.", doc.Arguments["name"].description)
+	assert.Equal(t, map[string]string{"value": "The value of the password."}, doc.Arguments["password"].arguments)
+	assert.Equal(t, "The ID of the user.", doc.Attributes["id"])
+}
+
+func TestParseFrameworkNestedSchemaLinks(t *testing.T) {
+	// The headers abbreviate the paths of the nested blocks, which the links to their anchors resolve.
+	argsReference := `# test_database
+
+Manages a database.
+
+## Argument Reference
+
+- ` + "`settings`" + ` (Attributes) The settings of the database. (see [below for nested schema](#nestedatt--settings))
+
+<a id="nestedatt--settings"></a>
+### Nested Schema for ` + "`settings`" + `
+
+Optional:
+
+- ` + "`backup_configuration`" + ` (Block List) The backup configuration. (see [below for nested schema](#nestedblock--settings--backup_configuration))
+
+<a id="nestedblock--settings--backup_configuration"></a>
+### Nested Schema for ` + "`backup_configuration`" + `
+
+Optional:
+
+- ` + "`enabled`" + ` (Boolean) Whether backups are enabled.
+`
+
+	// Nested attributes that are linked from a read-only attribute document attributes rather than arguments.
+	schema := `# test_database
+
+Manages a database.
+
+## Schema
+
+### Optional
+
+- ` + "`settings`" + ` (Block List) The settings of the database. (see [below for nested schema](#nestedblock--settings))
+
+### Read-Only
+
+- ` + "`status`" + ` (Attributes) The status of the database. (see [below for nested schema](#nestedatt--status))
+
+<a id="nestedblock--settings"></a>
+### Nested Schema for ` + "`settings`" + `
+
+Optional:
+
+- ` + "`tier`" + ` (String) The tier of the database.
+
+<a id="nestedatt--status"></a>
+### Nested Schema for ` + "`status`" + `
+
+Read-Only:
+
+- ` + "`state`" + ` (String) The state of the database.
+- ` + "`replica`" + ` (Attributes) The replica of the database. (see [below for nested schema](#nestedatt--status--replica))
+
+<a id="nestedatt--status--replica"></a>
+### Nested Schema for ` + "`replica`" + `
+
+Read-Only:
+
+- ` + "`region`" + ` (String) The region of the replica.
+`
+
+	g, err := NewGenerator(GeneratorOptions{
+		Package:      "test",
+		Version:      "0.0.1",
+		Language:     "nodejs",
+		ProviderInfo: tfbridge.ProviderInfo{Name: "test"},
+		Sink: diag.DefaultSink(io.Discard, io.Discard, diag.FormatOptions{
+			Color: colors.Never,
+		}),
+	})
+	assert.NoError(t, err)
+
+	doc, err := parseTFMarkdown(g, nil, ResourceDocs, argsReference, "database.md", "test", "test_database")
+	assert.NoError(t, err)
+
+	assert.Equal(t, map[string]string{
+		"backup_configuration": "The backup configuration.",
+	}, doc.Arguments["settings"].arguments)
+	assert.Equal(t, map[string]string{
+		"enabled": "Whether backups are enabled.",
+	}, doc.Arguments["settings.backup_configuration"].arguments)
+	assert.Empty(t, doc.Arguments["backup_configuration"].arguments)
+
+	doc, err = parseTFMarkdown(g, nil, ResourceDocs, schema, "database.md", "test", "test_database")
+	assert.NoError(t, err)
+
+	assert.Equal(t, "The tier of the database.", doc.Arguments["settings.tier"].description)
+	assert.NotContains(t, doc.Arguments, "status")
+	assert.NotContains(t, doc.Arguments, "status.state")
+	assert.Equal(t, map[string]string{
+		"state":   "The state of the database.",
+		"replica": "The replica of the database.",
+	}, doc.NestedAttributes["status"])
+	assert.Equal(t, map[string]string{
+		"region": "The region of the replica.",
+	}, doc.NestedAttributes["status.replica"])
+}
+
+func TestParseRequiredGroupingHeaders(t *testing.T) {
+	// The grouping headers apply to the arguments that follow them until the next grouping header.
+	argsReference := `# test_database
+
+Manages a database.
+
+## Argument Reference
+
+Required:
+
+- ` + "`name`" + ` (String) The name of the database.
+
+Optional:
+
+- ` + "`settings`" + ` (Attributes) The settings of the database. (see [below for nested schema](#nestedatt--settings))
+
+<a id="nestedatt--settings"></a>
+### Nested Schema for ` + "`settings`" + `
+
+Required:
+
+- ` + "`tier`" + ` (String) The tier of the database.
+- ` + "`edition`" + ` (String) The edition of the database.
+
+Optional:
+
+- ` + "`replicas`" + ` (Number) The number of replicas.
+`
+
+	// The same grouping headers in a top-level "Schema" section.
+	schema := `# test_database
+
+Manages a database.
+
+## Schema
+
+### Optional
+
+- ` + "`settings`" + ` (Block List) The settings of the database. (see [below for nested schema](#nestedblock--settings))
+
+<a id="nestedblock--settings"></a>
+### Nested Schema for ` + "`settings`" + `
+
+Required:
+
+- ` + "`tier`" + ` (String) The tier of the database.
+
+Optional:
+
+- ` + "`replicas`" + ` (Number) The number of replicas.
+`
+
+	g, err := NewGenerator(GeneratorOptions{
+		Package:      "test",
+		Version:      "0.0.1",
+		Language:     "nodejs",
+		ProviderInfo: tfbridge.ProviderInfo{Name: "test"},
+		Sink: diag.DefaultSink(io.Discard, io.Discard, diag.FormatOptions{
+			Color: colors.Never,
+		}),
+	})
+	assert.NoError(t, err)
+
+	doc, err := parseTFMarkdown(g, nil, ResourceDocs, argsReference, "database.md", "test", "test_database")
+	assert.NoError(t, err)
+
+	assert.NotContains(t, doc.Arguments, "required")
+	assert.NotContains(t, doc.Arguments, "optional")
+	assert.True(t, doc.Arguments["name"].isRequired)
+	assert.False(t, doc.Arguments["settings"].isRequired)
+	assert.Equal(t, map[string]string{
+		"tier":     "The tier of the database.",
+		"edition":  "The edition of the database.",
+		"replicas": "The number of replicas.",
+	}, doc.Arguments["settings"].arguments)
+	assert.True(t, doc.Arguments["tier"].isRequired)
+	assert.True(t, doc.Arguments["edition"].isRequired)
+	assert.False(t, doc.Arguments["replicas"].isRequired)
+
+	doc, err = parseTFMarkdown(g, nil, ResourceDocs, schema, "database.md", "test", "test_database")
+	assert.NoError(t, err)
+
+	assert.True(t, doc.Arguments["settings.tier"].isRequired)
+	assert.False(t, doc.Arguments["settings.replicas"].isRequired)
+}
+
+func TestParseReadOnlyGroups(t *testing.T) {
+	markdown := `# test_database
+
+Manages a database.
+
+## Argument Reference
+
+Required:
+
+- ` + "`name`" + ` (String) The name of the database.
+
+Optional:
+
+- ` + "`settings`" + ` (Attributes) The settings of the database. (see [below for nested schema](#nestedatt--settings))
+
+Read-Only:
+
+- ` + "`id`" + ` (String) The ID of the database.
+- ` + "`endpoint`" + ` (String) The endpoint of the database.
+  It is only reachable from within the VPC.
+
+<a id="nestedatt--settings"></a>
+### Nested Schema for ` + "`settings`" + `
+
+Optional:
+
+- ` + "`tier`" + ` (String) The tier of the database.
+
+Read-Only:
+
+- ` + "`effective_tier`" + ` (String) The tier that is in effect.
+`
+
+	// The same groups as headers in a top-level "Schema" section.
+	schema := `# test_database
+
+Manages a database.
+
+## Schema
+
+### Optional
+
+- ` + "`settings`" + ` (Block List) The settings of the database. (see [below for nested schema](#nestedblock--settings))
+
+### Read-Only
+
+- ` + "`id`" + ` (String) The ID of the database.
+
+<a id="nestedblock--settings"></a>
+### Nested Schema for ` + "`settings`" + `
+
+Optional:
+
+- ` + "`tier`" + ` (String) The tier of the database.
+
+Read-Only:
+
+- ` + "`effective_tier`" + ` (String) The tier that is in effect.
+`
+
+	g, err := NewGenerator(GeneratorOptions{
+		Package:      "test",
+		Version:      "0.0.1",
+		Language:     "nodejs",
+		ProviderInfo: tfbridge.ProviderInfo{Name: "test"},
+		Sink: diag.DefaultSink(io.Discard, io.Discard, diag.FormatOptions{
+			Color: colors.Never,
+		}),
+	})
+	assert.NoError(t, err)
+
+	doc, err := parseTFMarkdown(g, nil, ResourceDocs, markdown, "database.md", "test", "test_database")
+	assert.NoError(t, err)
+
+	assert.Equal(t, "The name of the database.", doc.Arguments["name"].description)
+	assert.NotContains(t, doc.Arguments, "id")
+	assert.NotContains(t, doc.Arguments, "endpoint")
+	assert.Equal(t, "The ID of the database.", doc.Attributes["id"])
+	assert.Equal(t, "The endpoint of the database.\nIt is only reachable from within the VPC.",
+		doc.Attributes["endpoint"])
+	assert.Equal(t, map[string]string{"tier": "The tier of the database."}, doc.Arguments["settings"].arguments)
+	assert.Equal(t, map[string]string{"effective_tier": "The tier that is in effect."},
+		doc.NestedAttributes["settings"])
+	assert.Equal(t, "The tier that is in effect.", doc.Attributes["effective_tier"])
+
+	doc, err = parseTFMarkdown(g, nil, ResourceDocs, schema, "database.md", "test", "test_database")
+	assert.NoError(t, err)
+
+	assert.Equal(t, "The ID of the database.", doc.Attributes["id"])
+	assert.Equal(t, map[string]string{"tier": "The tier of the database."}, doc.Arguments["settings"].arguments)
+	assert.NotContains(t, doc.Arguments, "settings.effective_tier")
+	assert.Equal(t, map[string]string{"effective_tier": "The tier that is in effect."},
+		doc.NestedAttributes["settings"])
+
+	// A grouping header may also be a markdown header within an Argument Reference section.
+	headers := strings.Replace(markdown, "Read-Only:\n\n- `id`", "### Read-Only\n\n- `id`", 1)
+	doc, err = parseTFMarkdown(g, nil, ResourceDocs, headers, "database.md", "test", "test_database")
+	assert.NoError(t, err)
+
+	assert.NotContains(t, doc.Arguments, "id")
+	assert.Equal(t, "The ID of the database.", doc.Attributes["id"])
+}
+
+func TestParseArgDocType(t *testing.T) {
+	tests := []struct {
+		input, expected string
+	}{
+		{"(Optional)", ""},
+		{"(Required) ", ""},
+		{"(Optional, Number)", "Number"},
+		{"(Required, String) ", "String"},
+		{"(Optional, List of String)", "List of String"},
+		{"(Optional, Map)", "Map"},
+		{"(Optional, Map of List of Number)", "Map of List of Number"},
+		{"(Optional, **Deprecated**, Bool)", "Bool"},
+		{"(Optional, Forces new resource)", ""},
+		{"(String)", "String"},
+		{"", ""},
+	}
+	for _, test := range tests {
+		assert.Equal(t, test.expected, parseArgDocType(test.input), test.input)
+	}
+}
+
+func TestParseArgDocTypes(t *testing.T) {
+	markdown := "# test_widget\n\nManages a widget.\n\n## Argument Reference\n\n" +
+		"* `count` - (Optional, Number) The number of widgets.\n" +
+		"* `names` - (Required, List of String) The names of the widgets.\n" +
+		"* `tags` - (Optional, Map) The tags of the widgets.\n" +
+		"* `size` - (Optional) The size of the widgets.\n" +
+		"* `settings` - (Optional) The settings of the widgets. Documented below.\n\n" +
+		"The `settings` block supports:\n\n" +
+		"* `shade` - (Optional, String) The shade of the widgets.\n"
+
+	g, err := NewGenerator(GeneratorOptions{
+		Package:      "test",
+		Version:      "0.0.1",
+		Language:     "nodejs",
+		ProviderInfo: tfbridge.ProviderInfo{Name: "test"},
+		Sink: diag.DefaultSink(io.Discard, io.Discard, diag.FormatOptions{
+			Color: colors.Never,
+		}),
+	})
+	assert.NoError(t, err)
+
+	doc, err := parseTFMarkdown(g, nil, ResourceDocs, markdown, "widget.html.markdown", "test", "test_widget")
+	assert.NoError(t, err)
+
+	assert.Equal(t, "Number", doc.Arguments["count"].docType)
+	assert.Equal(t, "The number of widgets.", doc.Arguments["count"].description)
+	assert.Equal(t, "List of String", doc.Arguments["names"].docType)
+	assert.Equal(t, "Map", doc.Arguments["tags"].docType)
+	assert.Equal(t, "", doc.Arguments["size"].docType)
+	assert.Equal(t, "String", doc.Arguments["shade"].docType)
+}
+
+func TestParseMultipleNestedBlocks(t *testing.T) {
+	markdown := `# test_firewall
+
+Manages a firewall.
+
+## Argument Reference
+
+* ` + "`name`" + ` - (Required) The name of the firewall.
+* ` + "`ingress`" + ` - (Optional) The ingress rules. Documented below.
+* ` + "`egress`" + ` - (Optional) The egress rules. Documented below.
+
+The ` + "`ingress`" + ` and ` + "`egress`" + ` blocks support:
+
+* ` + "`port`" + ` - (Required) The port of the rule.
+  Ports must be between 1 and 65535.
+* ` + "`protocol`" + ` - (Optional) The protocol of the rule.
+
+The ` + "`ingress`" + `, ` + "`egress`" + `, and ` + "`logging`" + ` blocks support:
+
+* ` + "`enabled`" + ` - (Optional) Whether the block is enabled.
+`
+
+	ruleSchema := &schema.Resource{Schema: map[string]*schema.Schema{
+		"port":     {Type: schema.TypeInt, Required: true},
+		"protocol": {Type: schema.TypeString, Optional: true},
+		"enabled":  {Type: schema.TypeBool, Optional: true},
+	}}
+	g, err := NewGenerator(GeneratorOptions{
+		Package:  "test",
+		Version:  "0.0.1",
+		Language: "nodejs",
+		ProviderInfo: tfbridge.ProviderInfo{
+			Name: "test",
+			P: shimv1.NewProvider(&schema.Provider{
+				ResourcesMap: map[string]*schema.Resource{
+					"test_firewall": {Schema: map[string]*schema.Schema{
+						"name":    {Type: schema.TypeString, Required: true},
+						"ingress": {Type: schema.TypeList, Optional: true, Elem: ruleSchema},
+						"egress":  {Type: schema.TypeList, Optional: true, Elem: ruleSchema},
+					}},
+				},
+			}),
+		},
+		Sink: diag.DefaultSink(io.Discard, io.Discard, diag.FormatOptions{
+			Color: colors.Never,
+		}),
+	})
+	assert.NoError(t, err)
+
+	doc, err := parseTFMarkdown(g, nil, ResourceDocs, markdown, "firewall.html.markdown", "test", "test_firewall")
+	assert.NoError(t, err)
+
+	expected := map[string]string{
+		"port":     "The port of the rule.\nPorts must be between 1 and 65535.",
+		"protocol": "The protocol of the rule.",
+		"enabled":  "Whether the block is enabled.",
+	}
+	assert.Equal(t, expected, doc.Arguments["ingress"].arguments)
+	assert.Equal(t, expected, doc.Arguments["egress"].arguments)
+
+	// The logging block does not exist in the schema, so its arguments are not recorded.
+	assert.NotContains(t, doc.Arguments, "logging")
+}
+
+func TestOverlayArgsToAttributes(t *testing.T) {
+	source := entityDocs{
+		Arguments: map[string]*argumentDocs{
+			"overwrite_me": {
+				description: "overwritten_desc",
+			},
+			"source_only": {
+				description: "source_only_desc",
+			},
+		},
+	}
+
+	dest := entityDocs{
+		Attributes: map[string]string{
+			"overwrite_me": "original_desc",
+			"dest_only":    "dest_only_desc",
+		},
+	}
+
+	expected := entityDocs{
+		Attributes: map[string]string{
+			"overwrite_me": "overwritten_desc",
+			"source_only":  "source_only_desc",
+			"dest_only":    "dest_only_desc",
+		},
+	}
+
+	overlayArgsToAttributes(source, dest, false)
+
+	assert.Equal(t, expected, dest)
+}
+
+func TestOverlayArgsToAttributesDedup(t *testing.T) {
+	source := entityDocs{
+		Arguments: map[string]*argumentDocs{
+			"name": {
+				description: "The name of the widget, which must be unique within the account.",
+				arguments: map[string]string{
+					"shade": "The shade of the widget.",
+				},
+			},
+			"size": {
+				description: "The size of the widget.",
+			},
+		},
+	}
+
+	dest := entityDocs{
+		Arguments: map[string]*argumentDocs{
+			"name": {
+				description: "The name of the widget, which must be unique within the account.",
+			},
+			"shade": {
+				description: "The shade of the widget.",
+				isNested:    true,
+			},
+			"size": {
+				description: "The requested size of the widget.",
+			},
+		},
+		Attributes: map[string]string{},
+	}
+
+	overlayArgsToAttributes(source, dest, true)
+
+	// Identical descriptions refer to the argument, while differing ones are copied.
+	assert.Equal(t, map[string]string{
+		"name":  argumentDescriptionRef("name"),
+		"shade": argumentDescriptionRef("shade"),
+		"size":  "The size of the widget.",
+	}, dest.Attributes)
+
+	// References are resolved when the descriptions are read.
+	assert.Equal(t, "The name of the widget, which must be unique within the account.",
+		dest.attributeDescription("name"))
+	assert.Equal(t, "The shade of the widget.", dest.attributeDescription("shade"))
+	assert.Equal(t, "The size of the widget.", dest.attributeDescription("size"))
+	assert.Equal(t, "", dest.attributeDescription("missing"))
+
+	// Without dedup, descriptions are copied.
+	dest.Attributes = map[string]string{}
+	overlayArgsToAttributes(source, dest, false)
+	assert.Equal(t, "The name of the widget, which must be unique within the account.", dest.Attributes["name"])
+}
+
+func TestOverlayArgsToArgs(t *testing.T) {
+	source := entityDocs{
+		Arguments: map[string]*argumentDocs{
+			"overwrite_me": {
+				description: "overwritten_desc",
+				arguments: map[string]string{
+					"nested_source_only":  "nested_source_only_desc",
+					"nested_overwrite_me": "nested_overwrite_me_overwritten_desc",
+				},
+			},
+			"source_only": {
+				description: "source_only_desc",
+				arguments:   map[string]string{},
+			},
+		},
+	}
+
+	dest := entityDocs{
+		Arguments: map[string]*argumentDocs{
+			"overwrite_me": {
+				description: "original_desc",
+				arguments: map[string]string{
+					"nested_dest_only":    "should not appear",
+					"nested_overwrite_me": "nested_overwrite_me original desc",
+				},
+			},
+			"dest_only": {
+				description: "dest_only_desc",
+				arguments:   map[string]string{},
+			},
+		},
+	}
+
+	expected := entityDocs{
+		Arguments: map[string]*argumentDocs{
+			"overwrite_me": {
+				description: "overwritten_desc",
+				arguments: map[string]string{
+					"nested_source_only":  "nested_source_only_desc",
+					"nested_overwrite_me": "nested_overwrite_me_overwritten_desc",
+				},
+			},
+			"source_only": {
+				description: "source_only_desc",
+				arguments:   map[string]string{},
+			},
+			"dest_only": {
+				description: "dest_only_desc",
+				arguments:   map[string]string{},
+			},
+		},
+	}
+
+	overlayArgsToArgs(source, dest)
+
+	assert.Equal(t, expected, dest)
+}
+
+func TestOverlayArgsFillMissing(t *testing.T) {
+	source := entityDocs{
+		Arguments: map[string]*argumentDocs{
+			"filled": {
+				description: "upstream_desc",
+				arguments: map[string]string{
+					"nested_filled": "nested_upstream_desc",
+					"nested_empty":  "nested_empty_upstream_desc",
+					"nested_new":    "nested_new_upstream_desc",
+				},
+			},
+			"empty": {
+				description: "empty_upstream_desc",
+			},
+			"source_only": {
+				description: "source_only_desc",
+				arguments: map[string]string{
+					"nested_source_only": "nested_source_only_desc",
+				},
+			},
+		},
+	}
+
+	dest := entityDocs{
+		Arguments: map[string]*argumentDocs{
+			"filled": {
+				description: "local_desc",
+				arguments: map[string]string{
+					"nested_filled": "nested_local_desc",
+					"nested_empty":  "",
+				},
+			},
+			"empty": {
+				description: "",
+			},
+			"dest_only": {
+				description: "dest_only_desc",
+			},
+		},
+	}
+
+	expected := entityDocs{
+		Arguments: map[string]*argumentDocs{
+			"filled": {
+				description: "local_desc",
+				arguments: map[string]string{
+					"nested_filled": "nested_local_desc",
+					"nested_empty":  "nested_empty_upstream_desc",
+					"nested_new":    "nested_new_upstream_desc",
+				},
+			},
+			"empty": {
+				description: "empty_upstream_desc",
+			},
+			"source_only": {
+				description: "source_only_desc",
+				arguments: map[string]string{
+					"nested_source_only": "nested_source_only_desc",
+				},
+			},
+			"dest_only": {
+				description: "dest_only_desc",
+			},
+		},
+	}
+
+	overlayArgsFillMissing(source, dest)
+
+	assert.Equal(t, expected, dest)
+}
+
+func TestParseArgNameFromHeader(t *testing.T) {
+	assert.Equal(t, "private_cluster_config", parseArgNameFromHeader("### `private_cluster_config` Configuration Block"))
+	assert.Equal(t, "result_configuration", parseArgNameFromHeader("#### result_configuration Argument Reference"))
+	assert.Equal(t, "settings.backup_configuration",
+		parseArgNameFromHeader("### Nested Schema for `settings.backup_configuration`"))
+	assert.Equal(t, "advanced_options", parseArgNameFromHeader("### Advanced Options"))
+}
+
+func TestParseValidValues(t *testing.T) {
+	tests := []struct {
+		desc     string
+		expected []string
+	}{
+		{"valid values are: `BLOCK`, `ALLOW`, or `COUNT`", []string{"BLOCK", "ALLOW", "COUNT"}},
+		{"The type of the rule. Valid values are `RATE_BASED` and `REGULAR`.", []string{"RATE_BASED", "REGULAR"}},
+		{"Possible values include `\"Standard\"`, `\"Premium\"`.", []string{"Standard", "Premium"}},
+		{"Must be one of `json`, `yaml` or `text`. Defaults to `json`.", []string{"json", "yaml", "text"}},
+		{"Allowed values: `true`", []string{"true"}},
+
+		// Code spans that are not introduced by a trigger phrase are not an enumeration.
+		{"The name of the bucket, e.g. `my-bucket` or `other-bucket`.", nil},
+		{"Set to `true` to enable the rule.", nil},
+		// "one of" must be followed by an actual enumeration.
+		{"One of the `settings` blocks documented below.", nil},
+		{"Exactly one of `foo` blocks.", nil},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, parseValidValues(tt.desc), tt.desc)
+	}
+}
+
+func TestParseValidValuesFromMarkdown(t *testing.T) {
+	markdown := `# waf_rule
+
+Provides a WAF rule.
+
+## Argument Reference
+
+* ` + "`name`" + ` - (Required) The name of the rule.
+* ` + "`action`" + ` - (Required) The action to take. Valid values are: ` + "`BLOCK`, `ALLOW`," + `
+  or ` + "`COUNT`" + `.
+`
+	g := &Generator{sink: diag.DefaultSink(io.Discard, io.Discard, diag.FormatOptions{Color: colors.Never})}
+	doc, err := parseTFMarkdown(g, nil, ResourceDocs, markdown, "waf_rule.html.markdown", "waf", "waf_rule")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"BLOCK", "ALLOW", "COUNT"}, doc.Arguments["action"].validValues)
+	assert.Nil(t, doc.Arguments["name"].validValues)
+}
+
+func TestExtractFrontMatter(t *testing.T) {
+	fields, rest, ok := extractFrontMatter("---\nsubcategory: \"Storage\"\npage_title: 'Google: storage_bucket'\n" +
+		"description: |-\n  Creates a bucket.\n---\n\n# storage_bucket\n")
+	assert.True(t, ok)
+	assert.Equal(t, map[string]string{"subcategory": "Storage", "page_title": "Google: storage_bucket"}, fields)
+	assert.Equal(t, "\n# storage_bucket\n", rest)
+
+	// A "---" that does not open the doc is not front matter.
+	markdown := "# storage_bucket\n\nCreates a bucket.\n\n---\n\nsubcategory: Storage\n---\n"
+	_, rest, ok = extractFrontMatter(markdown)
+	assert.False(t, ok)
+	assert.Equal(t, markdown, rest)
+
+	// Neither is an opening "---" whose block holds anything but YAML, e.g. a horizontal rule followed by prose.
+	markdown = "---\n\n# storage_bucket\n\nCreates a bucket.\n\n---\n"
+	_, rest, ok = extractFrontMatter(markdown)
+	assert.False(t, ok)
+	assert.Equal(t, markdown, rest)
+}
+
+func TestParseFrontMatter(t *testing.T) {
+	markdown := `---
+subcategory: "Cloud Storage"
+page_title: "Google: google_storage_bucket"
+---
+
+# google_storage_bucket
+
+Creates a new bucket in Google cloud storage service.
+
+---
+
+Buckets are global.
+
+## Argument Reference
+
+* ` + "`name`" + ` - (Required) The name of the bucket.
+`
+	g := &Generator{sink: diag.DefaultSink(io.Discard, io.Discard, diag.FormatOptions{Color: colors.Never})}
+	doc, err := parseTFMarkdown(g, nil, ResourceDocs, markdown, "storage_bucket.html.markdown", "google",
+		"google_storage_bucket")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"subcategory": "Cloud Storage",
+		"page_title":  "Google: google_storage_bucket",
+	}, doc.FrontMatter)
+	assert.Equal(t, "Creates a new bucket in Google cloud storage service.\n\n---\n\nBuckets are global.",
+		doc.Description)
+	assert.Contains(t, doc.Arguments, "name")
+	assert.Empty(t, g.warnings)
+}
+
+func TestParseLeadingDefault(t *testing.T) {
+	tests := []struct {
+		desc     string
+		expected string
+	}{
+		{"Defaults to the region of the provider. The region in which to create the bucket.",
+			"Defaults to the region of the provider."},
+		{"Defaults to the region of the provider.", "Defaults to the region of the provider."},
+		{"Defaults to the provider's project", "Defaults to the provider's project"},
+		{"defaults to `us-east-1.amazonaws.com`. The endpoint to use.", "defaults to `us-east-1.amazonaws.com`."},
+		{"Defaults to a random name, e.g. `bucket-1234`. The name of the bucket.",
+			"Defaults to a random name, e.g. `bucket-1234`."},
+		{"Defaults to the provider region.\nThe region to use.", "Defaults to the provider region."},
+
+		// A default that does not open the description is not split out.
+		{"The region in which to create the bucket. Defaults to the region of the provider.", ""},
+		{"The name of the bucket.", ""},
+		{"Defaultsto nothing.", ""},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, parseLeadingDefault(tt.desc), tt.desc)
+	}
+}
+
+func TestParseLeadingDefaultFromMarkdown(t *testing.T) {
+	markdown := `# storage_bucket
+
+Provides a bucket.
+
+## Argument Reference
+
+* ` + "`name`" + ` - (Required) The name of the bucket. Defaults to a random name.
+* ` + "`region`" + ` - (Optional) Defaults to the region of the provider. The region in which to create the
+  bucket.
+`
+	g := &Generator{sink: diag.DefaultSink(io.Discard, io.Discard, diag.FormatOptions{Color: colors.Never})}
+	doc, err := parseTFMarkdown(g, nil, ResourceDocs, markdown, "storage_bucket.html.markdown", "storage",
+		"storage_bucket")
+	assert.NoError(t, err)
+
+	region := doc.Arguments["region"]
+	assert.Equal(t, "Defaults to the region of the provider.", region.defaultDescription)
+	assert.Equal(t, "Defaults to the region of the provider. The region in which to create the\nbucket.",
+		region.description)
+	assert.Empty(t, doc.Arguments["name"].defaultDescription)
+	assert.Equal(t, "The name of the bucket. Defaults to a random name.", doc.Arguments["name"].description)
+}
+
+func TestFieldRenamesInDocs(t *testing.T) {
+	info := &tfbridge.ResourceInfo{
+		Fields: map[string]*tfbridge.SchemaInfo{
+			"bucket": {Name: "bucketName"},
+			"website": {
+				Elem: &tfbridge.SchemaInfo{
+					Fields: map[string]*tfbridge.SchemaInfo{
+						"index_document": {Name: "indexPage"},
+					},
+				},
+			},
+		},
+	}
+	assert.Equal(t, map[string]string{"bucket": "bucketName", "index_document": "indexPage"}, getFieldRenames(info))
+	assert.Nil(t, getFieldRenames(nil))
+
+	markdown := `# aws_s3_bucket
+
+Provides a bucket. The ` + "`bucket`" + ` argument names the bucket.
+
+## Argument Reference
+
+* ` + "`bucket`" + ` - (Optional) The name of the bucket. Conflicts with ` + "`bucket_prefix`" + `.
+* ` + "`bucket_prefix`" + ` - (Optional) Creates a unique name beginning with the prefix. Conflicts with ` +
+		"`bucket`" + `.
+* ` + "`website`" + ` - (Optional) A website object. Requires ` + "`index_document`" + ` to be set.
+`
+
+	for _, tt := range []struct {
+		language Language
+		expected string
+	}{
+		{NodeJS, "Creates a unique name beginning with the prefix. Conflicts with `bucketName`."},
+		{Python, "Creates a unique name beginning with the prefix. Conflicts with `bucket_name`."},
+	} {
+		g := &Generator{
+			language: tt.language,
+			sink:     diag.DefaultSink(io.Discard, io.Discard, diag.FormatOptions{Color: colors.Never}),
+		}
+		doc, err := parseTFMarkdown(g, info, ResourceDocs, markdown, "s3_bucket.html.markdown", "aws", "aws_s3_bucket")
+		assert.NoError(t, err)
+
+		// The docs are still keyed by the Terraform names.
+		assert.Equal(t, tt.expected, doc.Arguments["bucket_prefix"].description)
+		assert.Contains(t, doc.Arguments, "bucket")
+		if tt.language == NodeJS {
+			assert.Contains(t, doc.Description, "Provides a bucket. The `bucketName` argument names the bucket.")
+			assert.Equal(t, "A website object. Requires `indexPage` to be set.", doc.Arguments["website"].description)
+			assert.Equal(t, "The name of the bucket. Conflicts with `bucketPrefix`.",
+				doc.Arguments["bucket"].description)
+		}
+	}
+}
+
+func TestArgumentReferencesInDocs(t *testing.T) {
+	info := &tfbridge.ResourceInfo{
+		Fields: map[string]*tfbridge.SchemaInfo{
+			"launch_specification": {Name: "launchSpecs"},
+		},
+	}
+
+	markdown := `# aws_spot_fleet_request
+
+Provides a Spot Fleet Request resource.
+
+## Argument Reference
+
+* ` + "`launch_specification`" + ` - (Optional) Used to define the launch configuration of the spot-fleet request.
+* ` + "`launch_template_config`" + ` - (Optional) Launch template configuration block. Conflicts with ` +
+		"`launch_specification`" + `. At least one of ` + "`launch_specification`" + ` or ` +
+		"`launch_template_config`" + ` is required.
+* ` + "`allocation_strategy`" + ` - (Optional) Indicates how to allocate the target capacity. The default is ` +
+		"`lowest_price`" + `.
+* ` + "`instance_pools_to_use_count`" + ` - (Optional) Valid only when ` + "`allocation_strategy`" + ` is set to ` +
+		"`lowest_price`" + `.
+`
+
+	g := &Generator{
+		language: NodeJS,
+		sink:     diag.DefaultSink(io.Discard, io.Discard, diag.FormatOptions{Color: colors.Never}),
+	}
+	doc, err := parseTFMarkdown(g, info, ResourceDocs, markdown, "spot_fleet_request.html.markdown", "aws",
+		"aws_spot_fleet_request")
+	assert.NoError(t, err)
+
+	// References to sibling arguments are rewritten to their Pulumi names, including renamed ones.
+	assert.Equal(t, "Launch template configuration block. Conflicts with `launchSpecs`. At least one of `launchSpecs` "+
+		"or `launchTemplateConfig` is required.", doc.Arguments["launch_template_config"].description)
+
+	// Quoted names that aren't arguments are left alone.
+	assert.Equal(t, "Indicates how to allocate the target capacity. The default is `lowest_price`.",
+		doc.Arguments["allocation_strategy"].description)
+	assert.Equal(t, "Valid only when `allocationStrategy` is set to `lowest_price`.",
+		doc.Arguments["instance_pools_to_use_count"].description)
+}
+
+func TestCleanupDoc_WithElided(t *testing.T) {
+	g, err := NewGenerator(GeneratorOptions{
+		Package:      "test",
+		Version:      "0.0.1",
+		Language:     "nodejs",
+		ProviderInfo: tfbridge.ProviderInfo{Name: "test"},
+		Sink: diag.DefaultSink(io.Discard, io.Discard, diag.FormatOptions{
+			Color: colors.Never,
+		}),
+	})
+	assert.NoError(t, err)
+
+	doc := entityDocs{
+		Description: "Manages a widget with Terraform.",
+		Arguments: map[string]*argumentDocs{
+			"name": {description: "The name of the widget."},
+			"config": {
+				description: "See the Terraform docs.",
+				arguments: map[string]string{
+					"size":  "The size of the widget.",
+					"color": "Any color supported by Terraform.",
+				},
+			},
+		},
+		Attributes: map[string]string{
+			"id":  "The ID of the widget.",
+			"arn": "The ARN, as reported by terraform.",
+		},
+	}
+
+	// Without a replacement function, elided descriptions are dropped.
+	actual, elided := cleanupDoc("test_widget", g, doc, nil, nil, nil)
+	assert.True(t, elided)
+	assert.Equal(t, "", actual.Description)
+	assert.Equal(t, "The name of the widget.", actual.Arguments["name"].description)
+	assert.Equal(t, "", actual.Arguments["config"].description)
+	assert.Equal(t, "The size of the widget.", actual.Arguments["config"].arguments["size"])
+	assert.Equal(t, "", actual.Arguments["config"].arguments["color"])
+	assert.Equal(t, "The ID of the widget.", actual.Attributes["id"])
+	assert.Equal(t, "", actual.Attributes["arn"])
+
+	// With a replacement function, elided descriptions are replaced according to their paths.
+	var paths []string
+	replacement := func(path string) string {
+		paths = append(paths, path)
+		return "See upstream documentation for " + path + "."
+	}
+	actual, elided = cleanupDoc("test_widget", g, doc, nil, nil, replacement)
+	assert.True(t, elided)
+	assert.Equal(t, "See upstream documentation for test_widget.", actual.Description)
+	assert.Equal(t, "The name of the widget.", actual.Arguments["name"].description)
+	assert.Equal(t, "See upstream documentation for test_widget.config.", actual.Arguments["config"].description)
+	assert.Equal(t, "The size of the widget.", actual.Arguments["config"].arguments["size"])
+	assert.Equal(t, "See upstream documentation for test_widget.config.color.",
+		actual.Arguments["config"].arguments["color"])
+	assert.Equal(t, "The ID of the widget.", actual.Attributes["id"])
+	assert.Equal(t, "See upstream documentation for test_widget.arn.", actual.Attributes["arn"])
+	assert.ElementsMatch(t, []string{
+		"test_widget", "test_widget.config", "test_widget.config.color", "test_widget.arn",
+	}, paths)
+}
+
+func TestCleanupDocElidedDescriptionKeepsExamples(t *testing.T) {
+	g, err := NewGenerator(GeneratorOptions{
+		Package:      "test",
+		Version:      "0.0.1",
+		Language:     "nodejs",
+		ProviderInfo: tfbridge.ProviderInfo{Name: "test"},
+		Sink: diag.DefaultSink(io.Discard, io.Discard, diag.FormatOptions{
+			Color: colors.Never,
+		}),
+	})
+	assert.NoError(t, err)
+
+	// Only the description proper mentions Terraform, so the examples are kept, including any text that they share
+	// with the description.
+	doc := entityDocs{
+		Description: "Manages a widget with Terraform.\n\n## Example Usage\n\n```hcl\n# Manages a widget\n" +
+			"resource \"test_widget\" \"a\" {}\n```",
+	}
+	actual, elided := cleanupDoc("test_widget", g, doc, nil, nil, nil)
+	assert.False(t, elided)
+	assert.Equal(t, "## Example Usage\n\n```hcl\n# Manages a widget\nresource \"test_widget\" \"a\" {}\n```",
+		actual.Description)
+}
+
+func TestCleanupDocWarnings(t *testing.T) {
+	g, err := NewGenerator(GeneratorOptions{
+		Package:      "test",
+		Version:      "0.0.1",
+		Language:     "nodejs",
+		ProviderInfo: tfbridge.ProviderInfo{Name: "test"},
+		Sink: diag.DefaultSink(io.Discard, io.Discard, diag.FormatOptions{
+			Color: colors.Never,
+		}),
+	})
+	assert.NoError(t, err)
+	assert.Empty(t, g.Warnings())
+
+	doc := entityDocs{
+		Description: "Manages a widget.",
+		Arguments: map[string]*argumentDocs{
+			"name": {description: "The name of the widget."},
+			"config": {
+				description: "The config of the widget.",
+				arguments: map[string]string{
+					"color": "Any color supported by Terraform.",
+				},
+			},
+		},
+		Attributes: map[string]string{
+			"arn": "The ARN, as reported by terraform.",
+		},
+	}
+
+	_, elided := cleanupDoc("test_widget", g, doc, nil, nil, nil)
+	assert.True(t, elided)
+
+	warnings := g.Warnings()
+	sort.Slice(warnings, func(i, j int) bool { return warnings[i].Path < warnings[j].Path })
+	if !assert.Len(t, warnings, 2) {
+		return
+	}
+	assert.Equal(t, "test_widget", warnings[0].Entity)
+	assert.Equal(t, "arn", warnings[0].Path)
+	assert.Equal(t, diag.Warning, warnings[0].Severity)
+	assert.Equal(t, "Found <elided> in docs for attribute [arn] in [test_widget]. The attribute's description will "+
+		"be dropped in the Pulumi provider.", warnings[0].Message)
+	assert.Equal(t, "test_widget", warnings[1].Entity)
+	assert.Equal(t, "config.color", warnings[1].Path)
+	assert.Equal(t, diag.Warning, warnings[1].Severity)
+
+	// Modifying the returned warnings does not affect those accumulated by the generator.
+	warnings[0].Message = "modified"
+	assert.NotEqual(t, "modified", g.Warnings()[0].Message)
+}
+
+func TestCollapseDuplicateExamples(t *testing.T) {
+	markdown := strings.ReplaceAll(`Manages a widget.
+
+## Example Usage
+
+### Basic
+
+~~~hcl
+resource "test_widget" "example" {
+  name = "example"
+}
+~~~
+
+### Basic Again
+
+~~~hcl
+resource "test_widget" "example" {
+  name = "example"
+}
+~~~
+
+### Commented
+
+~~~hcl
+# A widget with a comment.
+resource "test_widget" "example" {
+  name = "example"
+}
+~~~
+`, "~~~", "```")
+
+	runTest := func(collapse bool, expectedTitles []string) {
+		g, err := NewGenerator(GeneratorOptions{
+			Package:      "test",
+			Version:      "0.0.1",
+			Language:     "nodejs",
+			ProviderInfo: tfbridge.ProviderInfo{Name: "test"},
+			Sink: diag.DefaultSink(io.Discard, io.Discard, diag.FormatOptions{
+				Color: colors.Never,
+			}),
+			CollapseDuplicateExamples: collapse,
+		})
+		assert.NoError(t, err)
+
+		doc, err := parseTFMarkdown(g, nil, ResourceDocs, markdown, "widget.html.markdown", "test", "test_widget")
+		assert.NoError(t, err)
+
+		var titles []string
+		for _, line := range strings.Split(doc.Description, "\n") {
+			if strings.HasPrefix(line, "### ") {
+				titles = append(titles, strings.TrimPrefix(line, "### "))
+			}
+		}
+		assert.Equal(t, expectedTitles, titles)
+	}
+
+	// Examples that differ only in their comments are still distinct.
+	runTest(true, []string{"Basic", "Commented"})
+	runTest(false, []string{"Basic", "Basic Again", "Commented"})
+}
+
+func TestDeeplyNestedArguments(t *testing.T) {
+	arguments := map[string]*argumentDocs{
+		"name": {description: "The name."},
+		"settings": {
+			description: "The settings.",
+			arguments:   map[string]string{"color": "The color."},
+		},
+		"settings.backup.schedule": {
+			arguments: map[string]string{"cron": "The schedule."},
+		},
+	}
+
+	assert.Empty(t, deeplyNestedArguments(arguments, 4))
+	assert.Equal(t, []string{"settings.backup.schedule.cron"}, deeplyNestedArguments(arguments, 3))
+	assert.Equal(t, []string{"settings.backup.schedule", "settings.backup.schedule.cron", "settings.color"},
+		deeplyNestedArguments(arguments, 1))
+}
+
+func TestMaxArgumentNestingDepthWarning(t *testing.T) {
+	markdown := readTestFile(t, "mini.md")
+
+	parseWarnings := func(limit int) string {
+		var stderr bytes.Buffer
+		g, err := NewGenerator(GeneratorOptions{
+			Package:      "test",
+			Version:      "0.0.1",
+			Language:     "nodejs",
+			ProviderInfo: tfbridge.ProviderInfo{Name: "test"},
+			Sink: diag.DefaultSink(io.Discard, &stderr, diag.FormatOptions{
+				Color: colors.Never,
+			}),
+			MaxArgumentNestingDepth: limit,
+		})
+		assert.NoError(t, err)
+
+		_, err = parseTFMarkdown(g, nil, ResourceDocs, markdown, "dashboard.html.markdown", "test", "test_dashboard")
+		assert.NoError(t, err)
+		return stderr.String()
+	}
+
+	// The deepest arguments in the tree are nested six levels deep.
+	warnings := parseWarnings(5)
+	assert.Contains(t, warnings, `"widget.group_definition.widget.change_definition.custom_link.label"`)
+	assert.Contains(t, warnings, `"widget.group_definition.widget.change_definition.request.alias"`)
+	assert.NotContains(t, warnings, `"widget.group_definition.widget.change_definition.live_span"`)
+
+	assert.NotContains(t, parseWarnings(6), "levels deep")
+	assert.NotContains(t, parseWarnings(0), "levels deep")
+}
+
+func TestParseProviderMarkdown(t *testing.T) {
+	markdown := `---
+layout: "widgets"
+page_title: "Provider: Widgets"
+description: |-
+  The Widgets provider is used to interact with widgets.
+---
+
+# Widgets Provider
+
+The Widgets provider is used to interact with the many widgets supported by Widget Co.
+Use the navigation to the left to read about the available resources.
+
+## Authentication
+
+Credentials can be provided via the ` + "`WIDGETS_TOKEN`" + ` environment variable.
+
+## Argument Reference
+
+The following arguments are supported:
+
+* ` + "`api_token`" + ` - (Optional) The API token used to authenticate with Widget Co.
+* ` + "`region`" + ` - (Required) The region in which to manage widgets.
+* ` + "`retry`" + ` - (Optional) A ` + "`retry`" + ` block as defined below.
+
+The ` + "`retry`" + ` block supports the following:
+
+* ` + "`max_attempts`" + ` - (Optional) The maximum number of attempts.
+`
+
+	g, err := NewGenerator(GeneratorOptions{
+		Package:      "widgets",
+		Version:      "0.0.1",
+		Language:     "nodejs",
+		ProviderInfo: tfbridge.ProviderInfo{Name: "widgets"},
+		Sink: diag.DefaultSink(io.Discard, io.Discard, diag.FormatOptions{
+			Color: colors.Never,
+		}),
+	})
+	assert.NoError(t, err)
+
+	docs, err := parseProviderMarkdown(g, markdown, "index.html.markdown")
+	assert.NoError(t, err)
+
+	assert.Contains(t, docs.Description, "The Widgets provider is used to interact with the many widgets")
+	assert.Contains(t, docs.Description, "## Authentication")
+	assert.NotContains(t, docs.Description, "page_title")
+	assert.NotContains(t, docs.Description, "# Widgets Provider")
+
+	assert.Equal(t, "The API token used to authenticate with Widget Co.", docs.Arguments["api_token"].description)
+	assert.Equal(t, "The region in which to manage widgets.", docs.Arguments["region"].description)
+	assert.Equal(t, "The maximum number of attempts.", docs.Arguments["retry"].arguments["max_attempts"])
+}
+
+func TestGetProviderDocsFromRepo(t *testing.T) {
+	// Point the generator at a checkout of the provider that documents its configuration in its index docs.
+	repo := t.TempDir()
+	assert.NoError(t, os.MkdirAll(filepath.Join(repo, "docs", "resources"), 0700))
+	index := filepath.Join(repo, "docs", "index.md")
+	assert.NoError(t, os.WriteFile(index, []byte("---\npage_title: \"Provider: Gadgets\"\n---\n\n# Gadgets Provider\n\nManages gadgets.\n\n"+
+		"## Argument Reference\n\n* `region` - (Optional) The region to manage gadgets in.\n"), 0600))
+	repoPaths.Store("github.com/gadgets/terraform-provider-gadgets", repo)
+	defer repoPaths.Delete("github.com/gadgets/terraform-provider-gadgets")
+
+	g, err := NewGenerator(GeneratorOptions{
+		Package:      "gadgets",
+		Version:      "0.0.1",
+		Language:     "nodejs",
+		ProviderInfo: tfbridge.ProviderInfo{Name: "gadgets", GitHubOrg: "gadgets"},
+		Sink: diag.DefaultSink(io.Discard, io.Discard, diag.FormatOptions{
+			Color: colors.Never,
+		}),
+	})
+	assert.NoError(t, err)
+
+	docs := g.getProviderDocs()
+	assert.Contains(t, docs.Description, "Manages gadgets.")
+	assert.NotContains(t, docs.Description, "# Gadgets Provider")
+	if assert.Contains(t, docs.Arguments, "region") {
+		assert.Equal(t, "The region to manage gadgets in.", docs.Arguments["region"].description)
+	}
+
+	// The docs are cached so that the index is only parsed once.
+	assert.NoError(t, os.Remove(index))
+	assert.Equal(t, docs, g.getProviderDocs())
+	assert.Empty(t, g.Warnings())
+}
+
+func TestMultipleEntityMarkdown(t *testing.T) {
+	markdown := `---
+subcategory: "Widgets"
+---
+
+Widgets and gadgets are documented together.
+
+# Resource: widgets_widget
+
+Provides a widget.
+
+## Example Usage
+
+` + "```hcl" + `
+# Resource: widgets_commented
+resource "widgets_widget" "example" {}
+` + "```" + `
+
+## Argument Reference
+
+* ` + "`name`" + ` - (Required) The name of the widget.
+
+# Resource: widgets\_gadget
+
+Provides a gadget.
+
+## Argument Reference
+
+* ` + "`size`" + ` - (Optional) The size of the gadget.
+
+## Attributes Reference
+
+* ` + "`arn`" + ` - The ARN of the gadget.
+`
+
+	entities := splitMarkdownEntities(markdown)
+	assert.Len(t, entities, 2)
+	assert.Contains(t, entities["widgets_widget"], "Widgets and gadgets are documented together.")
+	assert.NotContains(t, entities["widgets_widget"], "Provides a gadget.")
+	assert.True(t, strings.HasPrefix(entities["widgets_gadget"], "---\nsubcategory: \"Widgets\"\n---\n# Resource:"))
+	assert.NotContains(t, entities["widgets_gadget"], "Widgets and gadgets are documented together.")
+
+	assert.Nil(t, splitMarkdownEntities("# Resource: widgets_widget\n\nProvides a widget.\n"))
+
+	g, err := NewGenerator(GeneratorOptions{
+		Package:  "widgets",
+		Version:  "0.0.1",
+		Language: "nodejs",
+		ProviderInfo: tfbridge.ProviderInfo{
+			Name: "widgets",
+		},
+		Sink: diag.DefaultSink(io.Discard, io.Discard, diag.FormatOptions{
+			Color: colors.Never,
+		}),
+	})
+	assert.NoError(t, err)
+
+	docs := &tfbridge.DocInfo{Markdown: []byte(markdown)}
+	widget, err := getDocsForProvider(g, "", "widgets", "widgets", ResourceDocs, "widgets_widget",
+		&tfbridge.ResourceInfo{Docs: docs}, "", "")
+	assert.NoError(t, err)
+	gadget, err := getDocsForProvider(g, "", "widgets", "widgets", ResourceDocs, "widgets_gadget",
+		&tfbridge.ResourceInfo{Docs: docs}, "", "")
+	assert.NoError(t, err)
+
+	assert.Contains(t, widget.Description, "Provides a widget.")
+	assert.NotContains(t, widget.Description, "Provides a gadget.")
+	assert.Contains(t, widget.Arguments, "name")
+	assert.NotContains(t, widget.Arguments, "size")
+
+	assert.Contains(t, gadget.Description, "Provides a gadget.")
+	assert.NotContains(t, gadget.Description, "Provides a widget.")
+	assert.Contains(t, gadget.Arguments, "size")
+	assert.NotContains(t, gadget.Arguments, "name")
+	assert.Equal(t, "The ARN of the gadget.", gadget.Attributes["arn"])
+}
+
+func TestDocsRenderingIsDeterministic(t *testing.T) {
+	markdown := "# test_widget\n\nManages a widget.\n\n## Argument Reference\n\n" +
+		"* `name` - (Required) The name of the widget, as known to terraform.\n" +
+		"* `size` - (Optional) Any size supported by terraform.\n" +
+		"* `front` - (Optional) The front of the widget. Documented below.\n" +
+		"* `back` - (Optional) The back of the widget. Documented below.\n\n" +
+		"The `front` block supports:\n\n" +
+		"* `label` - (Optional) The label of the front, as shown by terraform.\n\n" +
+		"The `back` block supports:\n\n" +
+		"* `label` - (Optional) The label of the back, as shown by terraform.\n\n" +
+		"## Attributes Reference\n\n" +
+		"* `arn` - The ARN, as reported by terraform.\n" +
+		"* `id` - The ID, as reported by terraform.\n"
+
+	render := func() (string, []GenerationWarning) {
+		g, err := NewGenerator(GeneratorOptions{
+			Package:      "test",
+			Version:      "0.0.1",
+			Language:     "nodejs",
+			ProviderInfo: tfbridge.ProviderInfo{Name: "test"},
+			Sink: diag.DefaultSink(io.Discard, io.Discard, diag.FormatOptions{
+				Color: colors.Never,
+			}),
+		})
+		assert.NoError(t, err)
+
+		doc, err := parseTFMarkdown(g, nil, ResourceDocs, markdown, "", "test", "test_widget")
+		assert.NoError(t, err)
+		overlayArgsToAttributes(doc, doc, false)
+
+		keys := flattenKeys(doc)
+		paths := make([]string, 0, len(keys))
+		for path := range keys {
+			paths = append(paths, path)
+		}
+		sort.Strings(paths)
+
+		var b strings.Builder
+		for _, path := range paths {
+			b.WriteString(path + ": " + keys[path] + "\n")
+		}
+		return b.String(), g.Warnings()
+	}
+
+	expected, expectedWarnings := render()
+	assert.NotEmpty(t, expectedWarnings)
+	for i := 0; i < 10; i++ {
+		actual, warnings := render()
+		assert.Equal(t, expected, actual)
+		assert.Equal(t, expectedWarnings, warnings)
+	}
+}
+
+func TestNormalizeH1Sections(t *testing.T) {
+	// Only the H1 headings of well-known sections are rewritten in docs that otherwise use H2 headings.
+	mixed := "# test_widget\n\nManages a widget.\n\n# Example Usage\n\n```hcl\n# A comment\n" +
+		"resource \"test_widget\" \"a\" {}\n```\n\n## Argument Reference\n\n# Not a section\n"
+	assert.Equal(t, "# test_widget\n\nManages a widget.\n\n## Example Usage\n\n```hcl\n# A comment\n"+
+		"resource \"test_widget\" \"a\" {}\n```\n\n## Argument Reference\n\n# Not a section\n",
+		normalizeH1Sections(mixed))
+
+	// Docs that use H1 headings for everything have their other headings rewritten as subsections.
+	flat := "# test_widget\n\nManages a widget.\n\n# Example Usage\n\n# Basic\n\n```hcl\n# A comment\n```\n\n" +
+		"# Argument Reference\n"
+	assert.Equal(t, "# test_widget\n\nManages a widget.\n\n## Example Usage\n\n### Basic\n\n```hcl\n# A comment\n```\n\n"+
+		"## Argument Reference\n", normalizeH1Sections(flat))
+}
+
+func TestParseH1ExampleUsage(t *testing.T) {
+	markdown := "# test_widget\n\nProvides a widget.\n\n# Example Usage\n\n```hcl\n# A widget.\n" +
+		"data \"test_widget\" \"a\" {\n  name = \"a\"\n}\n```\n\n" +
+		"# Argument Reference\n\n* `name` - (Required) The name of the widget.\n\n" +
+		"# Attributes Reference\n\n* `id` - The ID of the widget.\n"
+
+	g, err := NewGenerator(GeneratorOptions{
+		Package:      "test",
+		Version:      "0.0.1",
+		Language:     "nodejs",
+		ProviderInfo: tfbridge.ProviderInfo{Name: "test"},
+		Sink: diag.DefaultSink(io.Discard, io.Discard, diag.FormatOptions{
+			Color: colors.Never,
+		}),
+	})
+	assert.NoError(t, err)
+
+	doc, err := parseTFMarkdown(g, nil, DataSourceDocs, markdown, "widget.html.markdown", "test", "test_widget")
+	assert.NoError(t, err)
+
+	assert.Equal(t, "## Example Usage\n\n```hcl\n# A widget.\ndata \"test_widget\" \"a\" {\n  name = \"a\"\n}\n```",
+		extractExamples(doc.Description))
+	assert.Equal(t, "The name of the widget.", doc.Arguments["name"].description)
+	assert.Equal(t, "The ID of the widget.", doc.Attributes["id"])
 }
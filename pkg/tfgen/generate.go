@@ -15,13 +15,16 @@
 package tfgen
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"path"
 	"path/filepath"
 	"sort"
 	"strings"
+	"time"
 	"unicode"
 	"unicode/utf8"
 
@@ -69,7 +72,76 @@ type Generator struct {
 	skipExamples     bool
 	coverageTracker  *CoverageTracker
 
+	// providerDocs caches the docs parsed from the provider's index markdown. See getProviderDocs.
+	providerDocs *providerDocs
+
+	// collapseDuplicateExamples drops examples whose code is identical to that of an earlier example.
+	collapseDuplicateExamples bool
+
+	// maxArgumentNestingDepth, if positive, is the deepest argument nesting expected in the upstream docs.
+	maxArgumentNestingDepth int
+
+	// elidedReplacement, if not nil, returns the replacement for a description that contains an <elided> reference.
+	elidedReplacement func(path string) string
+
+	// convertInlineHTML converts common inline HTML in descriptions to markdown.
+	convertInlineHTML bool
+
+	// escapeMarkdown escapes the markdown special characters in descriptions outside of code and links.
+	escapeMarkdown bool
+
+	// maxDescriptionLength, if positive, is the length beyond which property descriptions are truncated.
+	maxDescriptionLength int
+
+	// docsCache, if not nil, caches parsed docs keyed by the hash of their markdown.
+	docsCache DocsCache
+
+	// hclConverter converts the HCL of examples. See HCLConverter.
+	hclConverter HCLConverter
+
+	// exampleConversionTimeout, if positive, bounds the time spent converting an example to each language.
+	exampleConversionTimeout time.Duration
+
+	// conversionAbandoned is set once an example conversion times out. The abandoned conversion may still be using the
+	// package cache, plugin host, and provider info source above, none of which is safe for concurrent use, so every
+	// later conversion is skipped. See convert.
+	conversionAbandoned bool
+
+	// acronyms lists the words that keep their spelling when names referenced in docs are camelized.
+	acronyms []string
+
+	// exampleLanguages, if not empty, restricts the languages to which examples are converted.
+	exampleLanguages []string
+
+	// sharedFooterLinks holds the footer links defined across the provider's doc set. It is loaded on first use; see
+	// loadSharedFooterLinks.
+	sharedFooterLinks map[string]string
+
+	// onlyTokens, if not nil, restricts gathering to the resources and data sources with these Pulumi tokens. See
+	// RegenerateSchema.
+	onlyTokens map[string]bool
+
 	convertedCode map[string][]byte
+
+	// docsQuery caches the docs returned by DocsForToken.
+	docsQuery docsQuery
+
+	// warnings accumulates the warnings raised during generation. See Warnings.
+	warnings []GenerationWarning
+}
+
+// GenerationWarning is a warning or error raised during generation, e.g. about upstream docs that could not be parsed
+// or cleaned up.
+type GenerationWarning struct {
+	// Entity is the Terraform name of the resource or data source that the warning concerns, if any.
+	Entity string
+	// Path is the dotted path of the property within the entity that the warning concerns, if any, e.g.
+	// "website.index_document".
+	Path string
+	// Message is the text of the warning.
+	Message string
+	// Severity is the severity of the warning, i.e. diag.Warning or diag.Error.
+	Severity diag.Severity
 }
 
 type Language string
@@ -309,7 +381,10 @@ type propertyType struct {
 	asset      *tfbridge.AssetTranslation
 }
 
-func makePropertyType(objectName string, sch shim.Schema, info *tfbridge.SchemaInfo, out bool,
+// makePropertyType returns the type of the property at the given dotted path, e.g. "settings.backup_configuration".
+// The elements of a list, set, or map share the path of the collection, as do the single objects that represent
+// MaxItems: 1 blocks.
+func makePropertyType(objectPath string, sch shim.Schema, info *tfbridge.SchemaInfo, out bool,
 	entityDocs entityDocs) *propertyType {
 
 	t := &propertyType{}
@@ -352,9 +427,9 @@ func makePropertyType(objectName string, sch shim.Schema, info *tfbridge.SchemaI
 
 	switch elem := sch.Elem().(type) {
 	case shim.Schema:
-		t.element = makePropertyType(objectName, elem, elemInfo, out, entityDocs)
+		t.element = makePropertyType(objectPath, elem, elemInfo, out, entityDocs)
 	case shim.Resource:
-		t.element = makeObjectPropertyType(objectName, elem, elemInfo, out, entityDocs)
+		t.element = makeObjectPropertyType(objectPath, elem, elemInfo, out, entityDocs)
 	}
 
 	switch t.kind {
@@ -373,7 +448,7 @@ func makePropertyType(objectName string, sch shim.Schema, info *tfbridge.SchemaI
 	return t
 }
 
-func makeObjectPropertyType(objectName string, res shim.Resource, info *tfbridge.SchemaInfo, out bool,
+func makeObjectPropertyType(objectPath string, res shim.Resource, info *tfbridge.SchemaInfo, out bool,
 	entityDocs entityDocs) *propertyType {
 
 	t := &propertyType{
@@ -403,9 +478,10 @@ func makeObjectPropertyType(objectName string, res shim.Resource, info *tfbridge
 		// TODO: Figure out why counting whether this description came from the attributes seems wrong.
 		// With AWS, counting this takes the takes number of arg descriptions from attribs from about 170 to about 1400.
 		// This seems wrong, so we ignore the second return value here for now.
-		doc, _ := getNestedDescriptionFromParsedDocs(entityDocs, objectName, key)
+		doc, _ := getNestedDescriptionFromParsedDocs(entityDocs, objectPath, key)
 
-		if v := propertyVariable(key, propertySchema, propertyInfo, doc, "", out, entityDocs); v != nil {
+		if v := nestedPropertyVariable(objectPath, key, propertySchema, propertyInfo, doc, "", out,
+			entityDocs); v != nil {
 			t.properties = append(t.properties, v)
 		}
 	}
@@ -480,6 +556,9 @@ type variable struct {
 	schema shim.Schema
 	info   *tfbridge.SchemaInfo
 
+	// docDeprecationMessage is the deprecation message parsed from the upstream docs, if any.
+	docDeprecationMessage string
+
 	typ *propertyType
 }
 
@@ -495,7 +574,7 @@ func (v *variable) deprecationMessage() string {
 		return v.info.DeprecationMessage
 	}
 
-	return ""
+	return v.docDeprecationMessage
 }
 
 func (v *variable) forceNew() bool {
@@ -623,20 +702,76 @@ func GenerateSchema(info tfbridge.ProviderInfo, sink diag.Sink) (pschema.Package
 	return genPulumiSchema(pack, g.pkg, g.version, g.info)
 }
 
+// GeneratorOptions configures a Generator. The serializable subset of the options may be loaded from JSON; see
+// LoadGeneratorOptions and GeneratorOptionsJSONSchema.
 type GeneratorOptions struct {
-	Package            string
-	Version            string
-	Language           Language
-	ProviderInfo       tfbridge.ProviderInfo
-	Root               afero.Fs
-	ProviderInfoSource il.ProviderInfoSource
-	PluginHost         plugin.Host
-	TerraformVersion   string
-	Sink               diag.Sink
-	Debug              bool
-	SkipDocs           bool
-	SkipExamples       bool
-	CoverageTracker    *CoverageTracker
+	Package            string                `json:"package,omitempty"`
+	Version            string                `json:"version,omitempty"`
+	Language           Language              `json:"language,omitempty"`
+	ProviderInfo       tfbridge.ProviderInfo `json:"-"`
+	Root               afero.Fs              `json:"-"`
+	ProviderInfoSource il.ProviderInfoSource `json:"-"`
+	PluginHost         plugin.Host           `json:"-"`
+	TerraformVersion   string                `json:"terraformVersion,omitempty"`
+	Sink               diag.Sink             `json:"-"`
+	Debug              bool                  `json:"debug,omitempty"`
+	SkipDocs           bool                  `json:"skipDocs,omitempty"`
+	SkipExamples       bool                  `json:"skipExamples,omitempty"`
+	CoverageTracker    *CoverageTracker      `json:"-"`
+
+	// CollapseDuplicateExamples drops any example subsection whose code blocks are identical to those of an earlier
+	// example for the same resource or data source.
+	CollapseDuplicateExamples bool `json:"collapseDuplicateExamples,omitempty"`
+
+	// MaxArgumentNestingDepth, if positive, is the deepest level of argument nesting expected in the upstream docs. A
+	// warning is emitted for each documented argument that is nested more deeply, as this usually indicates that
+	// sibling arguments were incorrectly parsed as nested ones.
+	MaxArgumentNestingDepth int `json:"maxArgumentNestingDepth,omitempty"`
+
+	// ElidedReplacement, if not nil, is called with the path of each description that contains an <elided>
+	// reference, e.g. "aws_s3_bucket.website", and returns the text to use in its place. If nil, such descriptions
+	// are dropped.
+	ElidedReplacement func(path string) string `json:"-"`
+
+	// ConvertInlineHTML converts common inline HTML in descriptions to markdown, e.g. "<code>x</code>" to "`x`", and
+	// strips formatting tags that have no markdown equivalent.
+	ConvertInlineHTML bool `json:"convertInlineHTML,omitempty"`
+
+	// EscapeMarkdown escapes the markdown special characters "*", "_", "[", and "]" in descriptions, so that text such
+	// as "arn:aws:s3:::*" is not rendered as formatting when descriptions are embedded in generated docs. Code spans,
+	// code blocks, links, list markers, strong emphasis, and characters that are already escaped are left alone.
+	EscapeMarkdown bool `json:"escapeMarkdown,omitempty"`
+
+	// MaxDescriptionLength, if positive, is the maximum length in bytes of the description of each argument and
+	// attribute of a resource or data source. Longer descriptions are truncated at a word boundary and end with an
+	// ellipsis and a link to the entity's full upstream docs. Truncation is applied as descriptions are rendered into
+	// the schema, so the parsed docs, e.g. those returned by DocsForToken, retain the full text.
+	MaxDescriptionLength int `json:"maxDescriptionLength,omitempty"`
+
+	// DocsCache, if not nil, caches the docs parsed from upstream markdown so that unchanged docs are not re-parsed.
+	// See NewInMemoryDocsCache.
+	DocsCache DocsCache `json:"-"`
+
+	// HCLConverter, if not nil, converts the HCL of examples in place of the default converter. See
+	// DefaultHCLConverter.
+	HCLConverter HCLConverter `json:"-"`
+
+	// ExampleConversionTimeout, if positive, bounds the time spent converting each example to each language. An
+	// example whose conversion takes longer is dropped with a warning, so that a single pathological example cannot
+	// stall generation. As the timed-out conversion cannot be cancelled, every later example is dropped as well. If
+	// zero, conversions are not bounded. In the JSON form of the options, the timeout is a duration string, e.g. "30s".
+	ExampleConversionTimeout Duration `json:"exampleConversionTimeout,omitempty"`
+
+	// Acronyms lists words, e.g. "ARN" or "URL", that keep their given spelling when snake_case names referenced in
+	// docs are camelized, so that `db_arn` is rendered as `dbARN` rather than `dbArn`. An acronym that begins a name
+	// is left in lowercase, e.g. `arn_prefix` is rendered as `arnPrefix`. Words are matched case-insensitively.
+	Acronyms []string `json:"acronyms,omitempty"`
+
+	// ExampleLanguages, if not empty, restricts the languages to which examples are converted and in which they are
+	// emitted, e.g. ["python", "go"], which is useful when generating the docs of a single-language SDK. Languages are
+	// named by their code fence hints, e.g. "typescript" or "csharp". Languages that the target language does not
+	// convert examples to are ignored. If empty, examples are converted to every language of the target.
+	ExampleLanguages []string `json:"exampleLanguages,omitempty"`
 }
 
 // NewGenerator returns a code-generator for the given language runtime and package info.
@@ -688,6 +823,11 @@ func NewGenerator(opts GeneratorOptions) (*Generator, error) {
 		pluginHost = ctx.Host
 	}
 
+	hclConverter := opts.HCLConverter
+	if hclConverter == nil {
+		hclConverter = DefaultHCLConverter()
+	}
+
 	infoSources := append([]il.ProviderInfoSource{}, opts.ProviderInfoSource, il.PluginProviderInfoSource)
 	infoSource := il.NewCachingProviderInfoSource(il.NewMultiProviderInfoSource(infoSources...))
 
@@ -716,17 +856,51 @@ func NewGenerator(opts GeneratorOptions) (*Generator, error) {
 		skipDocs:         opts.SkipDocs,
 		skipExamples:     opts.SkipExamples,
 		coverageTracker:  opts.CoverageTracker,
+
+		collapseDuplicateExamples: opts.CollapseDuplicateExamples,
+		maxArgumentNestingDepth:   opts.MaxArgumentNestingDepth,
+		elidedReplacement:         opts.ElidedReplacement,
+		convertInlineHTML:         opts.ConvertInlineHTML,
+		escapeMarkdown:            opts.EscapeMarkdown,
+		maxDescriptionLength:      opts.MaxDescriptionLength,
+		docsCache:                 opts.DocsCache,
+		hclConverter:              hclConverter,
+		exampleConversionTimeout:  time.Duration(opts.ExampleConversionTimeout),
+		acronyms:                  opts.Acronyms,
+		exampleLanguages:          opts.ExampleLanguages,
 	}, nil
 }
 
+// Warnings returns the warnings and errors raised so far, in the order in which they were raised. Each is also reported
+// to the generator's sink as it is raised.
+func (g *Generator) Warnings() []GenerationWarning {
+	return append([]GenerationWarning(nil), g.warnings...)
+}
+
 func (g *Generator) error(f string, args ...interface{}) {
+	g.recordWarning("", "", diag.Error, f, args...)
 	g.sink.Errorf(diag.Message("", f), args...)
 }
 
 func (g *Generator) warn(f string, args ...interface{}) {
+	g.warnFor("", "", f, args...)
+}
+
+// warnFor raises a warning that concerns the given entity and, if path is not empty, the property at path within it.
+func (g *Generator) warnFor(entity, path string, f string, args ...interface{}) {
+	g.recordWarning(entity, path, diag.Warning, f, args...)
 	g.sink.Warningf(diag.Message("", f), args...)
 }
 
+func (g *Generator) recordWarning(entity, path string, severity diag.Severity, f string, args ...interface{}) {
+	g.warnings = append(g.warnings, GenerationWarning{
+		Entity:   entity,
+		Path:     path,
+		Message:  fmt.Sprintf(f, args...),
+		Severity: severity,
+	})
+}
+
 func (g *Generator) debug(f string, args ...interface{}) {
 	g.sink.Debugf(diag.Message("", f), args...)
 }
@@ -839,6 +1013,43 @@ func (g *Generator) Generate() error {
 	return nil
 }
 
+// WriteSchema serializes the intermediate Pulumi schema for the provider to w as indented JSON. Unlike Generate, no
+// SDK is emitted and no examples are converted, so the output is suitable for diffing schema changes. The version is
+// omitted and object keys are sorted so that the output is stable across runs.
+func (g *Generator) WriteSchema(w io.Writer) error {
+	pack, err := g.gatherPackage()
+	if err != nil {
+		return errors.Wrapf(err, "failed to gather package metadata")
+	}
+
+	pulumiPackageSpec, err := genPulumiSchema(pack, g.pkg, g.version, g.info)
+	if err != nil {
+		return errors.Wrapf(err, "failed to create Pulumi schema")
+	}
+	pulumiPackageSpec.Version = ""
+
+	// Round-trip the schema through a generic value so that every object, including those with statically ordered
+	// fields, is emitted with its keys in sorted order.
+	raw, err := json.Marshal(pulumiPackageSpec)
+	if err != nil {
+		return errors.Wrapf(err, "failed to marshal schema")
+	}
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.UseNumber()
+	var canonical interface{}
+	if err = dec.Decode(&canonical); err != nil {
+		return errors.Wrapf(err, "failed to canonicalize schema")
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "    ")
+	enc.SetEscapeHTML(false)
+	if err = enc.Encode(canonical); err != nil {
+		return errors.Wrapf(err, "failed to write schema")
+	}
+	return nil
+}
+
 // gatherPackage creates a package plus module structure for the entire set of members of this package.
 func (g *Generator) gatherPackage() (*pkg, error) {
 	// First, gather up the entire package/module structure.  This includes gathering config entries, resources,
@@ -893,6 +1104,9 @@ func (g *Generator) gatherConfig() *module {
 	}
 	config := newModule(configMod)
 
+	// Collect documentation information from the provider's index docs.
+	docs := entityDocs{Arguments: g.getProviderDocs().Arguments}
+
 	// Sort the config variables to ensure they are emitted in a deterministic order.
 	custom := g.info.Config
 	var cfgkeys []string
@@ -906,7 +1120,8 @@ func (g *Generator) gatherConfig() *module {
 	for _, key := range cfgkeys {
 		// Generate a name and type to use for this key.
 		sch := cfg.Get(key)
-		prop := propertyVariable(key, sch, custom[key], "", sch.Description(), true /*out*/, entityDocs{})
+		doc, _ := getDescriptionFromParsedDocs(docs, key)
+		prop := propertyVariable(key, sch, custom[key], doc, sch.Description(), true /*out*/, docs)
 		if prop != nil {
 			prop.config = true
 			config.addMember(prop)
@@ -965,7 +1180,7 @@ func (g *Generator) gatherResources() (moduleMap, error) {
 	var reserr error
 	seen := make(map[string]bool)
 	for _, r := range stableResources(resources) {
-		info := g.info.Resources[r]
+		info := g.info.GetResource(r)
 		if info == nil {
 			if ignoreMappingError(g.info.IgnoreMappings, r) {
 				g.debug("TF resource %q not found in provider map", r)
@@ -981,9 +1196,19 @@ func (g *Generator) gatherResources() (moduleMap, error) {
 			continue
 		}
 		seen[r] = true
+		if g.onlyTokens != nil && !g.onlyTokens[string(info.Tok)] {
+			continue
+		}
 
-		module, res, err := g.gatherResource(r, resources.Get(r), info, false)
-		if err != nil {
+		var module string
+		var res *resourceType
+		panicValue, err := catchEntityPanic(func() (err error) {
+			module, res, err = g.gatherResource(r, resources.Get(r), info, false)
+			return err
+		})
+		if panicValue != nil {
+			g.warnFor(r, "", "panic generating %s; it will be omitted from the package: %v", info.Tok, panicValue)
+		} else if err != nil {
 			// Keep track of the error, but keep going, so we can expose more at once.
 			reserr = multierror.Append(reserr, err)
 		} else {
@@ -1023,6 +1248,17 @@ func (g *Generator) gatherResources() (moduleMap, error) {
 	return modules, nil
 }
 
+// catchEntityPanic calls gather, returning the value of any panic that it raises instead of propagating it, so that
+// the entity being gathered can be omitted while the remaining entities are still gathered.
+func catchEntityPanic(gather func() error) (panicValue interface{}, err error) {
+	defer func() {
+		if v := recover(); v != nil {
+			panicValue, err = v, nil
+		}
+	}()
+	return nil, gather()
+}
+
 // gatherResource returns the module name and one or more module members to represent the given resource.
 func (g *Generator) gatherResource(rawname string,
 	schema shim.Resource, info *tfbridge.ResourceInfo, isProvider bool) (string, *resourceType, error) {
@@ -1038,7 +1274,7 @@ func (g *Generator) gatherResource(rawname string,
 		if err != nil {
 			return "", nil, err
 		}
-		entityDocs = pd
+		entityDocs = g.checkImportDocs(rawname, info, g.truncateDescriptions(pd, ResourceDocs, rawname))
 	} else {
 		entityDocs.Description = fmt.Sprintf(
 			"The provider type for the %s package. By default, resources use package-wide configuration\n"+
@@ -1046,6 +1282,8 @@ func (g *Generator) gatherResource(rawname string,
 				"construction to achieve fine-grained programmatic control over provider settings. See the\n"+
 				"[documentation](https://www.pulumi.com/docs/reference/programming-model/#providers) for more information.",
 			g.info.Name)
+
+		entityDocs.Arguments = g.getProviderDocs().Arguments
 	}
 
 	// Create an empty module and associated resource type.
@@ -1062,6 +1300,7 @@ func (g *Generator) gatherResource(rawname string,
 		// TODO[pulumi/pulumi#397]: represent sensitive types using a Secret<T> type.
 		doc, foundInAttributes := getDescriptionFromParsedDocs(entityDocs, key)
 		rawdoc := propschema.Description()
+		docDeprecationMessage := getDeprecationFromParsedDocs(entityDocs, key)
 
 		propinfo := info.Fields[key]
 
@@ -1072,6 +1311,7 @@ func (g *Generator) gatherResource(rawname string,
 			// from the input in that the types may differ.
 			outprop := propertyVariable(key, propschema, propinfo, doc, rawdoc, true /*out*/, entityDocs)
 			if outprop != nil {
+				outprop.docDeprecationMessage = docDeprecationMessage
 				res.outprops = append(res.outprops, outprop)
 			}
 		}
@@ -1086,6 +1326,7 @@ func (g *Generator) gatherResource(rawname string,
 
 			inprop := propertyVariable(key, propschema, propinfo, doc, rawdoc, false /*out*/, entityDocs)
 			if inprop != nil {
+				inprop.docDeprecationMessage = docDeprecationMessage
 				res.inprops = append(res.inprops, inprop)
 				if !inprop.optional() {
 					res.reqprops[name] = true
@@ -1096,6 +1337,7 @@ func (g *Generator) gatherResource(rawname string,
 		// Make a state variable.  This is always optional and simply lets callers perform lookups.
 		stateVar := propertyVariable(key, propschema, propinfo, doc, rawdoc, false /*out*/, entityDocs)
 		stateVar.opt = true
+		stateVar.docDeprecationMessage = docDeprecationMessage
 		stateVars = append(stateVars, stateVar)
 	}
 
@@ -1153,7 +1395,7 @@ func (g *Generator) gatherDataSources() (moduleMap, error) {
 	var dserr error
 	seen := make(map[string]bool)
 	for _, ds := range stableResources(sources) {
-		dsinfo := g.info.DataSources[ds]
+		dsinfo := g.info.GetDataSource(ds)
 		if dsinfo == nil {
 			if ignoreMappingError(g.info.IgnoreMappings, ds) {
 				g.debug("TF data source %q not found in provider map", ds)
@@ -1169,9 +1411,19 @@ func (g *Generator) gatherDataSources() (moduleMap, error) {
 			continue
 		}
 		seen[ds] = true
+		if g.onlyTokens != nil && !g.onlyTokens[string(dsinfo.Tok)] {
+			continue
+		}
 
-		module, fun, err := g.gatherDataSource(ds, sources.Get(ds), dsinfo)
-		if err != nil {
+		var module string
+		var fun *resourceFunc
+		panicValue, err := catchEntityPanic(func() (err error) {
+			module, fun, err = g.gatherDataSource(ds, sources.Get(ds), dsinfo)
+			return err
+		})
+		if panicValue != nil {
+			g.warnFor(ds, "", "panic generating %s; it will be omitted from the package: %v", dsinfo.Tok, panicValue)
+		} else if err != nil {
 			// Keep track of the error, but keep going, so we can expose more at once.
 			dserr = multierror.Append(dserr, err)
 		} else {
@@ -1225,6 +1477,7 @@ func (g *Generator) gatherDataSource(rawname string,
 	if err != nil {
 		return "", nil, err
 	}
+	entityDocs = g.truncateDescriptions(entityDocs, DataSourceDocs, rawname)
 
 	// Build up the function information.
 	fun := &resourceFunc{
@@ -1255,6 +1508,7 @@ func (g *Generator) gatherDataSource(rawname string,
 			}
 
 			argvar := propertyVariable(arg, sch, cust, doc, "", false /*out*/, entityDocs)
+			argvar.docDeprecationMessage = getDeprecationFromParsedDocs(entityDocs, arg)
 			fun.args = append(fun.args, argvar)
 			if !argvar.optional() {
 				fun.reqargs[argvar.name] = true
@@ -1264,7 +1518,7 @@ func (g *Generator) gatherDataSource(rawname string,
 		// Also remember properties for the resulting return data structure.
 		// Emit documentation for the property if available
 		fun.rets = append(fun.rets,
-			propertyVariable(arg, sch, cust, entityDocs.Attributes[arg], "", true /*out*/, entityDocs))
+			propertyVariable(arg, sch, cust, entityDocs.attributeDescription(arg), "", true /*out*/, entityDocs))
 	}
 
 	// If the data source's schema doesn't expose an id property, make one up since we'd like to expose it for data
@@ -1392,6 +1646,17 @@ func propertyName(key string, sch shim.Schema, custom *tfbridge.SchemaInfo) stri
 // propertyVariable creates a new property, with the Pulumi name, out of the given components.
 func propertyVariable(key string, sch shim.Schema, info *tfbridge.SchemaInfo,
 	doc string, rawdoc string, out bool, entityDocs entityDocs) *variable {
+	return nestedPropertyVariable("", key, sch, info, doc, rawdoc, out, entityDocs)
+}
+
+// nestedPropertyVariable creates a new property for the given key of the nested block at the given dotted path, or of
+// the entity itself if the path is empty.
+func nestedPropertyVariable(parentPath, key string, sch shim.Schema, info *tfbridge.SchemaInfo,
+	doc string, rawdoc string, out bool, entityDocs entityDocs) *variable {
+	path := strings.ToLower(key)
+	if parentPath != "" {
+		path = parentPath + "." + path
+	}
 	if name := propertyName(key, sch, info); name != "" {
 		return &variable{
 			name:   name,
@@ -1400,7 +1665,7 @@ func propertyVariable(key string, sch shim.Schema, info *tfbridge.SchemaInfo,
 			rawdoc: rawdoc,
 			schema: sch,
 			info:   info,
-			typ:    makePropertyType(strings.ToLower(key), sch, info, out, entityDocs),
+			typ:    makePropertyType(path, sch, info, out, entityDocs),
 		}
 	}
 	return nil
@@ -1552,17 +1817,40 @@ func getDescriptionFromParsedDocs(entityDocs entityDocs, arg string) (string, bo
 	return getNestedDescriptionFromParsedDocs(entityDocs, "", arg)
 }
 
-// getNestedDescriptionFromParsedDocs extracts the nested argument description for the given arg, or the
-// top-level argument description or attribute description if there is none.
-// If the description is taken from an attribute, the second return value is true.
-func getNestedDescriptionFromParsedDocs(entityDocs entityDocs, objectName string, arg string) (string, bool) {
-	if res := entityDocs.Arguments[objectName]; res != nil && res.arguments != nil && res.arguments[arg] != "" {
-		return res.arguments[arg], false
-	} else if res := entityDocs.Arguments[arg]; res != nil && res.description != "" {
+// getDeprecationFromParsedDocs extracts the deprecation message for the given top-level arg, if the docs mark it as
+// deprecated.
+func getDeprecationFromParsedDocs(entityDocs entityDocs, arg string) string {
+	if res := entityDocs.Arguments[arg]; res != nil && !res.isNested {
+		return res.deprecationMessage
+	}
+	return ""
+}
+
+// getNestedDescriptionFromParsedDocs extracts the nested argument description for the given arg of the nested block at
+// the given dotted path, or the top-level argument description or (nested) attribute description if there is none.
+// Docs may record the arguments of a deeply nested block under its full path, e.g. "settings.backup_configuration", or
+// under a shorter suffix of it, e.g. "backup_configuration", so each suffix is consulted from the longest to the
+// shortest. If the description is taken from an attribute, the second return value is true.
+func getNestedDescriptionFromParsedDocs(entityDocs entityDocs, objectPath string, arg string) (string, bool) {
+	objectNames := pathSuffixes(objectPath)
+	for _, objectName := range objectNames {
+		if res := entityDocs.Arguments[objectName]; res != nil && res.arguments != nil && res.arguments[arg] != "" {
+			return res.arguments[arg], false
+		}
+	}
+	if res := entityDocs.Arguments[arg]; res != nil && res.description != "" {
 		return res.description, false
 	}
 
-	attribute := entityDocs.Attributes[arg]
+	attribute := ""
+	for _, objectName := range objectNames {
+		if attribute = entityDocs.NestedAttributes[objectName][arg]; attribute != "" {
+			break
+		}
+	}
+	if attribute == "" {
+		attribute = entityDocs.attributeDescription(arg)
+	}
 
 	if attribute != "" {
 		// We return a description in the upstream attributes if none is found  in the upstream arguments. This condition
@@ -1586,6 +1874,20 @@ func getNestedDescriptionFromParsedDocs(entityDocs entityDocs, objectName string
 	return "", false
 }
 
+// pathSuffixes returns the suffixes of the given dotted path from the longest to the shortest, e.g. "a.b.c", "b.c", and
+// "c" for "a.b.c".
+func pathSuffixes(path string) []string {
+	if path == "" {
+		return []string{""}
+	}
+	suffixes := []string{path}
+	for i := strings.Index(path, "."); i != -1; i = strings.Index(path, ".") {
+		path = path[i+1:]
+		suffixes = append(suffixes, path)
+	}
+	return suffixes
+}
+
 // cleanDir removes all existing files from a directory except those in the exclusions list.
 // Note: The exclusions currently don't function recursively, so you cannot exclude a single file
 // in a subdirectory, only entire subdirectories. This function will need improvements to be able to
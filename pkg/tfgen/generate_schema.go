@@ -489,6 +489,7 @@ func (g *schemaGenerator) genResourceType(mod string, res *resourceType) pschema
 		description = g.genDocComment(res.doc)
 	}
 	if !res.IsProvider() {
+		spec.DeprecationMessage = res.entityDocs.DeprecationMessage
 		if res.info.DeprecationMessage != "" {
 			spec.DeprecationMessage = res.info.DeprecationMessage
 		}
@@ -560,6 +561,7 @@ func (g *schemaGenerator) genDatasourceFunc(mod string, fun *resourceFunc) psche
 	if fun.doc != "" {
 		description = g.genDocComment(fun.doc)
 	}
+	spec.DeprecationMessage = fun.entityDocs.DeprecationMessage
 	if fun.info.DeprecationMessage != "" {
 		spec.DeprecationMessage = fun.info.DeprecationMessage
 	}
@@ -1,11 +1,19 @@
 package tfgen
 
 import (
+	"bytes"
+	"encoding/json"
+	"io"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/diag"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/diag/colors"
+	"github.com/spf13/afero"
 
 	"github.com/pulumi/pulumi-terraform-bridge/v3/pkg/tfbridge"
+	shim "github.com/pulumi/pulumi-terraform-bridge/v3/pkg/tfshim"
+	shimschema "github.com/pulumi/pulumi-terraform-bridge/v3/pkg/tfshim/schema"
 	shimv1 "github.com/pulumi/pulumi-terraform-bridge/v3/pkg/tfshim/sdk-v1"
 	"github.com/stretchr/testify/assert"
 )
@@ -76,3 +84,314 @@ func Test_ForceNew(t *testing.T) {
 		})
 	}
 }
+
+func TestWriteSchema(t *testing.T) {
+	info := tfbridge.ProviderInfo{
+		P: shimv1.NewProvider(&schema.Provider{
+			Schema: map[string]*schema.Schema{
+				"region": {Type: schema.TypeString, Optional: true},
+			},
+			ResourcesMap: map[string]*schema.Resource{
+				"tiny_widget": {
+					Schema: map[string]*schema.Schema{
+						"widget_name": {Type: schema.TypeString, Required: true},
+						"size":        {Type: schema.TypeInt, Optional: true},
+					},
+				},
+			},
+		}),
+		Name:    "tiny",
+		Version: "1.2.3",
+		Resources: map[string]*tfbridge.ResourceInfo{
+			"tiny_widget": {Tok: "tiny:index/widget:Widget"},
+		},
+	}
+
+	g, err := NewGenerator(GeneratorOptions{
+		Package:      info.Name,
+		Version:      info.Version,
+		Language:     Schema,
+		ProviderInfo: info,
+		Root:         afero.NewMemMapFs(),
+		Sink: diag.DefaultSink(io.Discard, io.Discard, diag.FormatOptions{
+			Color: colors.Never,
+		}),
+		SkipDocs:     true,
+		SkipExamples: true,
+	})
+	assert.NoError(t, err)
+
+	var first, second bytes.Buffer
+	assert.NoError(t, g.WriteSchema(&first))
+	assert.NoError(t, g.WriteSchema(&second))
+	assert.Equal(t, first.String(), second.String())
+
+	var spec struct {
+		Name      string `json:"name"`
+		Version   string `json:"version"`
+		Resources map[string]struct {
+			InputProperties map[string]interface{} `json:"inputProperties"`
+			RequiredInputs  []string               `json:"requiredInputs"`
+		} `json:"resources"`
+	}
+	assert.NoError(t, json.Unmarshal(first.Bytes(), &spec))
+	assert.Equal(t, "tiny", spec.Name)
+	assert.Equal(t, "", spec.Version)
+
+	widget, ok := spec.Resources["tiny:index/widget:Widget"]
+	assert.True(t, ok)
+	assert.Contains(t, widget.InputProperties, "widgetName")
+	assert.Contains(t, widget.InputProperties, "size")
+	assert.Equal(t, []string{"widgetName"}, widget.RequiredInputs)
+}
+
+func TestTokenStrategy(t *testing.T) {
+	widget := &schema.Resource{Schema: map[string]*schema.Schema{
+		"size": {Type: schema.TypeInt, Optional: true},
+	}}
+	info := tfbridge.ProviderInfo{
+		P: shimv1.NewProvider(&schema.Provider{
+			ResourcesMap: map[string]*schema.Resource{
+				"tiny_foo_widget": widget,
+				"tiny_foo_gadget": widget,
+			},
+			DataSourcesMap: map[string]*schema.Resource{
+				"tiny_foo_widget": widget,
+			},
+		}),
+		Name: "tiny",
+		Resources: map[string]*tfbridge.ResourceInfo{
+			"tiny_foo_gadget": {Tok: "tiny:index/gadget:Gadget"},
+		},
+		TokenStrategy: func(tfToken string) (string, bool) {
+			switch tfToken {
+			case "tiny_foo_widget":
+				return "tiny:foo/widget:Widget", true
+			case "tiny_foo_gadget":
+				return "tiny:foo/gadget:Gadget", true
+			}
+			return "", false
+		},
+	}
+
+	g, err := NewGenerator(GeneratorOptions{
+		Package:      info.Name,
+		Language:     Schema,
+		ProviderInfo: info,
+		Root:         afero.NewMemMapFs(),
+		Sink: diag.DefaultSink(io.Discard, io.Discard, diag.FormatOptions{
+			Color: colors.Never,
+		}),
+		SkipDocs:     true,
+		SkipExamples: true,
+	})
+	assert.NoError(t, err)
+
+	spec, err := g.gatherSchema(nil)
+	assert.NoError(t, err)
+	assert.Contains(t, spec.Resources, "tiny:foo/widget:Widget")
+	assert.Contains(t, spec.Resources, "tiny:index/gadget:Gadget")
+	assert.NotContains(t, spec.Resources, "tiny:foo/gadget:Gadget")
+	assert.Contains(t, spec.Functions, "tiny:foo/widget:Widget")
+}
+
+func TestNestedPropertyDescriptions(t *testing.T) {
+	valueBlock := func(maxItems int) *schema.Schema {
+		return &schema.Schema{
+			Type:     schema.TypeList,
+			Optional: true,
+			MaxItems: maxItems,
+			Elem: &schema.Resource{Schema: map[string]*schema.Schema{
+				"value": {Type: schema.TypeString, Optional: true},
+			}},
+		}
+	}
+	rule := &schema.Schema{
+		Type:     schema.TypeList,
+		Optional: true,
+		Elem: &schema.Resource{Schema: map[string]*schema.Schema{
+			"name":      {Type: schema.TypeString, Optional: true},
+			"condition": valueBlock(1),
+		}},
+	}
+
+	// The docs record the arguments of the deeply nested block under its full path, and the top-level copy of `value`
+	// is that of the `action` block.
+	docs := entityDocs{
+		Arguments: map[string]*argumentDocs{
+			"rule":           {arguments: map[string]string{"name": "The name of the rule."}},
+			"rule.condition": {arguments: map[string]string{"value": "The value to match."}},
+			"action":         {arguments: map[string]string{"value": "The value of the action."}},
+			"name":           {description: "The name of the rule.", isNested: true},
+			"value":          {description: "The value of the action.", isNested: true},
+		},
+	}
+
+	// A list-nested block is a list of objects.
+	ruleVar := propertyVariable("rule", shimv1.NewSchema(rule), nil, "", "", false, docs)
+	if !assert.NotNil(t, ruleVar) || !assert.Equal(t, typeKind(kindList), ruleVar.typ.kind) {
+		return
+	}
+	ruleType := ruleVar.typ.element
+	assert.Equal(t, typeKind(kindObject), ruleType.kind)
+	properties := map[string]*variable{}
+	for _, p := range ruleType.properties {
+		properties[p.name] = p
+	}
+	assert.Equal(t, "The name of the rule.", properties["name"].doc)
+
+	// A MaxItems: 1 block is flattened to a single object whose properties keep the path of the block.
+	conditionType := properties["condition"].typ
+	assert.Equal(t, typeKind(kindObject), conditionType.kind)
+	if assert.Len(t, conditionType.properties, 1) {
+		assert.Equal(t, "The value to match.", conditionType.properties[0].doc)
+	}
+
+	actionVar := propertyVariable("action", shimv1.NewSchema(valueBlock(1)), nil, "", "", false, docs)
+	if assert.Equal(t, typeKind(kindObject), actionVar.typ.kind) && assert.Len(t, actionVar.typ.properties, 1) {
+		assert.Equal(t, "The value of the action.", actionVar.typ.properties[0].doc)
+	}
+}
+
+func TestPathSuffixes(t *testing.T) {
+	assert.Equal(t, []string{""}, pathSuffixes(""))
+	assert.Equal(t, []string{"a"}, pathSuffixes("a"))
+	assert.Equal(t, []string{"a.b.c", "b.c", "c"}, pathSuffixes("a.b.c"))
+}
+
+func TestPanicInDocsParsing(t *testing.T) {
+	defer func(parse func(*Generator, tfbridge.ResourceOrDataSourceInfo, DocKind, string, string, string,
+		string) (entityDocs, error)) {
+		parseTFMarkdownFunc = parse
+	}(parseTFMarkdownFunc)
+	parseTFMarkdownFunc = func(g *Generator, info tfbridge.ResourceOrDataSourceInfo, kind DocKind,
+		markdown, markdownFileName, resourcePrefix, rawname string) (entityDocs, error) {
+		if rawname == "tiny_gadget" {
+			panic("bad markdown")
+		}
+		return parseTFMarkdown(g, info, kind, markdown, markdownFileName, resourcePrefix, rawname)
+	}
+
+	resource := &schema.Resource{Schema: map[string]*schema.Schema{
+		"size": {Type: schema.TypeInt, Optional: true},
+	}}
+	docs := func(name string) *tfbridge.DocInfo {
+		return &tfbridge.DocInfo{Markdown: []byte("# " + name + "\n\nProvides a thing.\n\n## Argument Reference\n\n" +
+			"* `size` - (Optional) The size of the thing.\n")}
+	}
+	info := tfbridge.ProviderInfo{
+		P: shimv1.NewProvider(&schema.Provider{
+			ResourcesMap: map[string]*schema.Resource{
+				"tiny_widget": resource,
+				"tiny_gadget": resource,
+			},
+		}),
+		Name: "tiny",
+		Resources: map[string]*tfbridge.ResourceInfo{
+			"tiny_widget": {Tok: "tiny:index/widget:Widget", Docs: docs("tiny_widget")},
+			"tiny_gadget": {Tok: "tiny:index/gadget:Gadget", Docs: docs("tiny_gadget")},
+		},
+	}
+
+	g, err := NewGenerator(GeneratorOptions{
+		Package:      info.Name,
+		Language:     Schema,
+		ProviderInfo: info,
+		Root:         afero.NewMemMapFs(),
+		Sink: diag.DefaultSink(io.Discard, io.Discard, diag.FormatOptions{
+			Color: colors.Never,
+		}),
+		SkipExamples: true,
+	})
+	assert.NoError(t, err)
+
+	spec, err := g.gatherSchema(nil)
+	assert.NoError(t, err)
+	assert.Contains(t, spec.Resources["tiny:index/widget:Widget"].Description, "Provides a thing.")
+	assert.Equal(t, "The size of the thing.\n",
+		spec.Resources["tiny:index/widget:Widget"].InputProperties["size"].Description)
+	assert.Contains(t, spec.Resources, "tiny:index/gadget:Gadget")
+	assert.NotContains(t, spec.Resources["tiny:index/gadget:Gadget"].Description, "Provides a thing.")
+
+	warnings := g.Warnings()
+	if assert.Len(t, warnings, 1) {
+		assert.Equal(t, "tiny_gadget", warnings[0].Entity)
+		assert.Contains(t, warnings[0].Message, "tiny:index/gadget:Gadget")
+		assert.Contains(t, warnings[0].Message, "bad markdown")
+	}
+}
+
+// panickingResource is a resource whose schema cannot be read once it is armed.
+type panickingResource struct {
+	shim.Resource
+	armed *bool
+}
+
+func (r panickingResource) Schema() shim.SchemaMap {
+	if *r.armed {
+		panic("bad schema")
+	}
+	return shimschema.SchemaMap{}
+}
+
+func TestCatchEntityPanic(t *testing.T) {
+	resource := shimv1.NewResource(&schema.Resource{Schema: map[string]*schema.Schema{
+		"size": {Type: schema.TypeInt, Optional: true},
+	}})
+	armed := false
+	docs := func(name string) *tfbridge.DocInfo {
+		return &tfbridge.DocInfo{Markdown: []byte("# " + name + "\n\nProvides a thing.\n")}
+	}
+	info := tfbridge.ProviderInfo{
+		P: (&shimschema.Provider{
+			Schema: shimschema.SchemaMap{},
+			ResourcesMap: shimschema.ResourceMap{
+				"tiny_widget": resource,
+				"tiny_gadget": panickingResource{armed: &armed},
+			},
+			DataSourcesMap: shimschema.ResourceMap{
+				"tiny_widget": resource,
+				"tiny_gadget": panickingResource{armed: &armed},
+			},
+		}).Shim(),
+		Name: "tiny",
+		Resources: map[string]*tfbridge.ResourceInfo{
+			"tiny_widget": {Tok: "tiny:index/widget:Widget", Docs: docs("tiny_widget")},
+			"tiny_gadget": {Tok: "tiny:index/gadget:Gadget", Docs: docs("tiny_gadget")},
+		},
+		DataSources: map[string]*tfbridge.DataSourceInfo{
+			"tiny_widget": {Tok: "tiny:index/getWidget:getWidget", Docs: docs("tiny_widget")},
+			"tiny_gadget": {Tok: "tiny:index/getGadget:getGadget", Docs: docs("tiny_gadget")},
+		},
+	}
+
+	g, err := NewGenerator(GeneratorOptions{
+		Package:      info.Name,
+		Language:     Schema,
+		ProviderInfo: info,
+		Root:         afero.NewMemMapFs(),
+		Sink: diag.DefaultSink(io.Discard, io.Discard, diag.FormatOptions{
+			Color: colors.Never,
+		}),
+		SkipExamples: true,
+	})
+	assert.NoError(t, err)
+	armed = true
+
+	// The entities that panic are omitted with a warning, while the remaining entities are still generated.
+	spec, err := g.gatherSchema(nil)
+	assert.NoError(t, err)
+	assert.Contains(t, spec.Resources, "tiny:index/widget:Widget")
+	assert.NotContains(t, spec.Resources, "tiny:index/gadget:Gadget")
+	assert.Contains(t, spec.Functions, "tiny:index/getWidget:getWidget")
+	assert.NotContains(t, spec.Functions, "tiny:index/getGadget:getGadget")
+
+	warnings := g.Warnings()
+	if assert.Len(t, warnings, 2) {
+		assert.Equal(t, "tiny_gadget", warnings[0].Entity)
+		assert.Contains(t, warnings[0].Message, "panic generating tiny:index/gadget:Gadget")
+		assert.Contains(t, warnings[0].Message, "bad schema")
+		assert.Equal(t, "tiny_gadget", warnings[1].Entity)
+		assert.Contains(t, warnings[1].Message, "panic generating tiny:index/getGadget:getGadget")
+	}
+}
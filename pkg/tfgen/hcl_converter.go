@@ -0,0 +1,40 @@
+// Copyright 2016-2022, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tfgen
+
+import (
+	"github.com/pulumi/pulumi-terraform-bridge/v3/pkg/tf2pulumi/convert"
+)
+
+// HCLConverter converts the Terraform HCL of examples into PCL and, from there, into the languages of the generated
+// docs. Supplying a custom HCLConverter through GeneratorOptions allows the conversion to be customized, e.g. with
+// extra function mappings.
+type HCLConverter interface {
+	// Convert converts the Terraform configuration in opts.Root into opts.TargetLanguage, returning the converted files
+	// keyed by name. The options are those with which the default converter would be called.
+	Convert(opts convert.Options) (map[string][]byte, convert.Diagnostics, error)
+}
+
+// DefaultHCLConverter returns the HCLConverter that is used when GeneratorOptions does not supply one. It converts
+// examples with tf2pulumi.
+func DefaultHCLConverter() HCLConverter {
+	return defaultHCLConverter{}
+}
+
+type defaultHCLConverter struct{}
+
+func (defaultHCLConverter) Convert(opts convert.Options) (map[string][]byte, convert.Diagnostics, error) {
+	return convert.Convert(opts)
+}
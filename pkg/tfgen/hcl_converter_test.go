@@ -0,0 +1,159 @@
+// Copyright 2016-2022, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tfgen
+
+import (
+	"errors"
+	"io"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/common/diag"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/diag/colors"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi-terraform-bridge/v3/pkg/tf2pulumi/convert"
+	"github.com/pulumi/pulumi-terraform-bridge/v3/pkg/tfbridge"
+)
+
+// fakeHCLConverter records the HCL that it is asked to convert and converts it to a fixed program.
+type fakeHCLConverter struct {
+	inputs    []string
+	languages []string
+}
+
+func (c *fakeHCLConverter) Convert(opts convert.Options) (map[string][]byte, convert.Diagnostics, error) {
+	files, err := afero.ReadDir(opts.Root, "/")
+	if err != nil {
+		return nil, convert.Diagnostics{}, err
+	}
+	for _, f := range files {
+		contents, err := afero.ReadFile(opts.Root, "/"+f.Name())
+		if err != nil {
+			return nil, convert.Diagnostics{}, err
+		}
+		c.inputs = append(c.inputs, string(contents))
+	}
+	c.languages = append(c.languages, opts.TargetLanguage)
+	return map[string][]byte{"index.ts": []byte("const widget = new widgets.Widget(\"a\", {});\n")},
+		convert.Diagnostics{}, nil
+}
+
+func TestHCLConverter(t *testing.T) {
+	converter := &fakeHCLConverter{}
+	g, err := NewGenerator(GeneratorOptions{
+		Package:      "widgets",
+		Version:      "0.0.1",
+		Language:     "nodejs",
+		ProviderInfo: tfbridge.ProviderInfo{Name: "widgets"},
+		Sink: diag.DefaultSink(io.Discard, io.Discard, diag.FormatOptions{
+			Color: colors.Never,
+		}),
+		HCLConverter: converter,
+	})
+	assert.NoError(t, err)
+
+	hcl := "resource \"widgets_widget\" \"a\" {}\n"
+	actual, err := g.convertHCL(hcl, "widgets:index/widget:Widget", "", []string{convert.LanguageTypescript})
+	assert.NoError(t, err)
+	assert.Equal(t, "```typescript\nconst widget = new widgets.Widget(\"a\", {});\n```", actual)
+	assert.Equal(t, []string{hcl}, converter.inputs)
+	assert.Equal(t, []string{convert.LanguageTypescript}, converter.languages)
+}
+
+func TestDefaultHCLConverter(t *testing.T) {
+	g, err := NewGenerator(GeneratorOptions{
+		Package:      "widgets",
+		Version:      "0.0.1",
+		Language:     "nodejs",
+		ProviderInfo: tfbridge.ProviderInfo{Name: "widgets"},
+		Sink: diag.DefaultSink(io.Discard, io.Discard, diag.FormatOptions{
+			Color: colors.Never,
+		}),
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, DefaultHCLConverter(), g.hclConverter)
+}
+
+// hangingHCLConverter never returns from a conversion.
+type hangingHCLConverter struct {
+	// calls counts the conversions that have started.
+	calls int32
+}
+
+func (c *hangingHCLConverter) Convert(opts convert.Options) (map[string][]byte, convert.Diagnostics, error) {
+	atomic.AddInt32(&c.calls, 1)
+	select {}
+}
+
+// failingHCLConverter fails every conversion.
+type failingHCLConverter struct{}
+
+func (failingHCLConverter) Convert(opts convert.Options) (map[string][]byte, convert.Diagnostics, error) {
+	return nil, convert.Diagnostics{}, errors.New("bad example")
+}
+
+func TestExampleConversionTimeout(t *testing.T) {
+	newGenerator := func(converter HCLConverter) *Generator {
+		g, err := NewGenerator(GeneratorOptions{
+			Package:      "widgets",
+			Version:      "0.0.1",
+			Language:     "nodejs",
+			ProviderInfo: tfbridge.ProviderInfo{Name: "widgets"},
+			Sink: diag.DefaultSink(io.Discard, io.Discard, diag.FormatOptions{
+				Color: colors.Never,
+			}),
+			HCLConverter:             converter,
+			ExampleConversionTimeout: Duration(10 * time.Millisecond),
+		})
+		assert.NoError(t, err)
+		return g
+	}
+	hcl := "resource \"widgets_widget\" \"a\" {}\n"
+
+	hanging := &hangingHCLConverter{}
+	g := newGenerator(hanging)
+	actual, err := g.convertHCL(hcl, "widgets:index/widget:Widget", "Basic", []string{convert.LanguageTypescript})
+	assert.Equal(t, "", actual)
+	assert.True(t, isConversionTimeout(err))
+	if warnings := g.Warnings(); assert.Len(t, warnings, 1) {
+		assert.Contains(t, warnings[0].Message, "timed out converting HCL example 'Basic'")
+		assert.Contains(t, warnings[0].Message, "after 10ms")
+	}
+
+	// The timed-out conversion never finishes, so later conversions are skipped without waiting for it and without
+	// further warnings.
+	start := time.Now()
+	for _, path := range []string{"widgets:index/gadget:Gadget", "widgets:index/gizmo:Gizmo"} {
+		actual, err = g.convertHCL(hcl, path, "Basic", []string{convert.LanguageTypescript})
+		assert.Equal(t, "", actual)
+		assert.True(t, isConversionTimeout(err))
+		assert.Contains(t, err.Error(), "conversion skipped")
+	}
+	assert.Less(t, time.Since(start), 10*time.Millisecond)
+	assert.Len(t, g.Warnings(), 1)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&hanging.calls))
+
+	// Genuine failures are not reported as timeouts.
+	g = newGenerator(failingHCLConverter{})
+	_, err = g.convertHCL(hcl, "widgets:index/widget:Widget", "Basic", []string{convert.LanguageTypescript})
+	assert.Error(t, err)
+	assert.False(t, isConversionTimeout(err))
+	if warnings := g.Warnings(); assert.Len(t, warnings, 1) {
+		assert.Contains(t, warnings[0].Message, "unable to convert HCL example 'Basic'")
+	}
+}
@@ -0,0 +1,119 @@
+// Copyright 2016-2022, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tfgen
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/pulumi/pulumi-terraform-bridge/v3/pkg/tfbridge"
+)
+
+// importIDPlaceholderRegexp matches a component of an import ID that stands for the value of a field rather than a
+// literal, e.g. `<name>`, `{{name}}`, `{name}`, `${name}`, or `[name]`.
+var importIDPlaceholderRegexp = regexp.MustCompile(
+	`^(?:<([^<>]+)>|\{\{\s*([^{}]+?)\s*\}\}|\$?\{([^{}]+)\}|\[([^\[\]]+)\])$`)
+
+// importIDSeparators are the separators that join the components of a composite import ID, in order of precedence.
+var importIDSeparators = []string{"/", ","}
+
+// importIDComponent is a single component of a documented import ID.
+type importIDComponent struct {
+	// text is the component as documented.
+	text string
+	// field is the normalized name of the field that the component stands for, or the empty string if the component
+	// is a literal.
+	field string
+}
+
+// decomposeImportID splits a documented import ID into its components. A composite ID is split by the first of the
+// importIDSeparators that it contains, e.g. "<project>/<name>" into "<project>" and "<name>"; any other ID is a single
+// component.
+func decomposeImportID(id string) []importIDComponent {
+	id = strings.Trim(strings.TrimSpace(id), "\"'")
+	if id == "" {
+		return nil
+	}
+
+	parts := []string{id}
+	for _, sep := range importIDSeparators {
+		if strings.Contains(id, sep) {
+			parts = strings.Split(id, sep)
+			break
+		}
+	}
+
+	components := make([]importIDComponent, len(parts))
+	for i, part := range parts {
+		components[i].text = part
+		if m := importIDPlaceholderRegexp.FindStringSubmatch(strings.TrimSpace(part)); m != nil {
+			components[i].field = normalizeImportIDField(m[1] + m[2] + m[3] + m[4])
+		}
+	}
+	return components
+}
+
+// normalizeImportIDField normalizes the name of the field that a placeholder stands for so that it can be compared to
+// the name of a Terraform field, e.g. "project_id" for "Project ID" or "project-id".
+func normalizeImportIDField(name string) string {
+	name = strings.ToLower(strings.TrimSpace(name))
+	return strings.NewReplacer(" ", "_", "-", "_").Replace(name)
+}
+
+// checkImportDocs cross-checks the import ID that the docs of the given resource document against the resource's ID
+// fields, if it has any, and warns if the two diverge. Only the components of the documented ID that are placeholders
+// are compared, in order, with the ID fields: literal components, e.g. "projects" in
+// "projects/{{project}}/instances/{{name}}", cannot diverge. If the docs do not document how to import the resource,
+// a `pulumi import` example is generated from the ID fields instead, assuming that a composite ID joins its fields
+// with "/".
+func (g *Generator) checkImportDocs(rawname string, info *tfbridge.ResourceInfo, doc entityDocs) entityDocs {
+	if info == nil || len(info.IDFields) == 0 {
+		return doc
+	}
+
+	if doc.Import == "" {
+		placeholders := make([]string, len(info.IDFields))
+		for i, field := range info.IDFields {
+			placeholders[i] = "<" + field + ">"
+		}
+		importCommand := fmt.Sprintf("$ pulumi import %s example %s", info.GetTok(), strings.Join(placeholders, "/"))
+		doc.Import = fmt.Sprintf("## Import\n\n%s", strings.Join(importCommandDetails(importCommand), " "))
+		return doc
+	}
+
+	var fields []string
+	for _, c := range decomposeImportID(doc.ImportID) {
+		if c.field != "" {
+			fields = append(fields, c.field)
+		}
+	}
+	if len(fields) == 0 {
+		return doc
+	}
+
+	if len(fields) != len(info.IDFields) {
+		g.warnFor(rawname, "", "The documented import ID %q of %v has %d components, but the resource has %d ID "+
+			"fields (%v)", doc.ImportID, rawname, len(fields), len(info.IDFields), strings.Join(info.IDFields, ", "))
+		return doc
+	}
+	for i, field := range fields {
+		if field != info.IDFields[i] {
+			g.warnFor(rawname, "", "Component %d of the documented import ID %q of %v is %q, but the resource's ID "+
+				"field at that position is %q", i+1, doc.ImportID, rawname, field, info.IDFields[i])
+		}
+	}
+	return doc
+}
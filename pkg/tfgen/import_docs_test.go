@@ -0,0 +1,136 @@
+// Copyright 2016-2022, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tfgen
+
+import (
+	"io"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/diag"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/diag/colors"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi-terraform-bridge/v3/pkg/tfbridge"
+	shimv1 "github.com/pulumi/pulumi-terraform-bridge/v3/pkg/tfshim/sdk-v1"
+)
+
+func TestDecomposeImportID(t *testing.T) {
+	tests := []struct {
+		id       string
+		expected []importIDComponent
+	}{
+		{"i-12345678", []importIDComponent{{text: "i-12345678"}}},
+		{"<name>", []importIDComponent{{text: "<name>", field: "name"}}},
+		{"{{project}}/{{name}}", []importIDComponent{
+			{text: "{{project}}", field: "project"},
+			{text: "{{name}}", field: "name"},
+		}},
+		{"projects/{{project}}/instances/{{name}}", []importIDComponent{
+			{text: "projects"},
+			{text: "{{project}}", field: "project"},
+			{text: "instances"},
+			{text: "{{name}}", field: "name"},
+		}},
+		{"<Project ID>,<zone-name>", []importIDComponent{
+			{text: "<Project ID>", field: "project_id"},
+			{text: "<zone-name>", field: "zone_name"},
+		}},
+		{"${bucket}/[key]", []importIDComponent{
+			{text: "${bucket}", field: "bucket"},
+			{text: "[key]", field: "key"},
+		}},
+		{"", nil},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, decomposeImportID(tt.id), tt.id)
+	}
+}
+
+func TestCheckImportDocs(t *testing.T) {
+	resource := &schema.Resource{Schema: map[string]*schema.Schema{
+		"project": {Type: schema.TypeString, Required: true},
+		"zone":    {Type: schema.TypeString, Required: true},
+		"name":    {Type: schema.TypeString, Required: true},
+	}}
+	docs := func(name, id string) *tfbridge.DocInfo {
+		markdown := "# " + name + "\n\nProvides a thing.\n"
+		if id != "" {
+			markdown += "\n## Import\n\nThings can be imported using the ID, e.g.\n\n```\n$ terraform import " +
+				name + ".example " + id + "\n```\n"
+		}
+		return &tfbridge.DocInfo{Markdown: []byte(markdown)}
+	}
+	info := tfbridge.ProviderInfo{
+		P: shimv1.NewProvider(&schema.Provider{
+			ResourcesMap: map[string]*schema.Resource{
+				"tiny_widget":    resource,
+				"tiny_gadget":    resource,
+				"tiny_gizmo":     resource,
+				"tiny_doohickey": resource,
+				"tiny_sprocket":  resource,
+			},
+		}),
+		Name: "tiny",
+		Resources: map[string]*tfbridge.ResourceInfo{
+			// The documented ID matches the ID fields.
+			"tiny_widget": {Tok: "tiny:index/widget:Widget", IDFields: []string{"project", "name"},
+				Docs: docs("tiny_widget", "projects/{{project}}/widgets/{{name}}")},
+			// The second component of the documented ID diverges from the ID fields.
+			"tiny_gadget": {Tok: "tiny:index/gadget:Gadget", IDFields: []string{"project", "name"},
+				Docs: docs("tiny_gadget", "<project>,<zone>")},
+			// The documented ID has more components than the resource has ID fields.
+			"tiny_gizmo": {Tok: "tiny:index/gizmo:Gizmo", IDFields: []string{"name"},
+				Docs: docs("tiny_gizmo", "{{project}}/{{name}}")},
+			// The docs do not document how to import the resource.
+			"tiny_doohickey": {Tok: "tiny:index/doohickey:Doohickey", IDFields: []string{"zone", "name"},
+				Docs: docs("tiny_doohickey", "")},
+			// A literal ID cannot be checked.
+			"tiny_sprocket": {Tok: "tiny:index/sprocket:Sprocket", IDFields: []string{"name"},
+				Docs: docs("tiny_sprocket", "my-project/my-sprocket")},
+		},
+	}
+
+	g, err := NewGenerator(GeneratorOptions{
+		Package:      info.Name,
+		Language:     Schema,
+		ProviderInfo: info,
+		Root:         afero.NewMemMapFs(),
+		Sink: diag.DefaultSink(io.Discard, io.Discard, diag.FormatOptions{
+			Color: colors.Never,
+		}),
+		SkipExamples: true,
+	})
+	assert.NoError(t, err)
+
+	spec, err := g.gatherSchema(nil)
+	assert.NoError(t, err)
+	assert.Contains(t, spec.Resources["tiny:index/widget:Widget"].Description,
+		"$ pulumi import tiny:index/widget:Widget example projects/{{project}}/widgets/{{name}}")
+	assert.Contains(t, spec.Resources["tiny:index/doohickey:Doohickey"].Description,
+		"$ pulumi import tiny:index/doohickey:Doohickey example <zone>/<name>")
+
+	var messages []string
+	for _, w := range g.Warnings() {
+		messages = append(messages, w.Entity+": "+w.Message)
+	}
+	assert.ElementsMatch(t, []string{
+		`tiny_gadget: Component 2 of the documented import ID "<project>,<zone>" of tiny_gadget is "zone", but the ` +
+			`resource's ID field at that position is "name"`,
+		`tiny_gizmo: The documented import ID "{{project}}/{{name}}" of tiny_gizmo has 2 components, but the ` +
+			`resource has 1 ID fields (name)`,
+	}, messages)
+}
@@ -0,0 +1,201 @@
+// Copyright 2016-2022, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tfgen
+
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	pschema "github.com/pulumi/pulumi/pkg/v3/codegen/schema"
+)
+
+// RegenerateSchema regenerates the resources and functions with the given Pulumi tokens and merges them into prev, a
+// schema previously produced for the same provider. Every other resource and function in prev is left untouched, and
+// only the docs of the changed entities are fetched and parsed.
+//
+// A changed token may also name a type in prev. Whenever a type is changed, either directly or because a regenerated
+// entity produced a different definition for it, every resource and function in prev that references the type, even
+// transitively, is regenerated as well.
+func (g *Generator) RegenerateSchema(prev pschema.PackageSpec, changed []string) (pschema.PackageSpec, error) {
+	result := prev
+	result.Resources = copyResourceSpecs(prev.Resources)
+	result.Functions = copyFunctionSpecs(prev.Functions)
+	result.Types = copyTypeSpecs(prev.Types)
+
+	done := map[string]bool{}
+	pending := map[string]bool{}
+	var changedTypes []string
+	for _, tok := range changed {
+		if _, isType := prev.Types[tok]; isType {
+			changedTypes = append(changedTypes, tok)
+		} else {
+			pending[tok] = true
+		}
+	}
+	for _, tok := range schemaReferrers(prev, changedTypes) {
+		pending[tok] = true
+	}
+
+	for len(pending) > 0 {
+		spec, err := g.gatherSchema(pending)
+		if err != nil {
+			return pschema.PackageSpec{}, err
+		}
+
+		// Replace the regenerated entities. An entity that is no longer generated has been removed.
+		for tok := range pending {
+			done[tok] = true
+			delete(result.Resources, tok)
+			delete(result.Functions, tok)
+			if r, ok := spec.Resources[tok]; ok {
+				result.Resources[tok] = r
+			}
+			if f, ok := spec.Functions[tok]; ok {
+				result.Functions[tok] = f
+			}
+		}
+
+		// Merge in the types used by the regenerated entities, remembering those whose definitions changed.
+		changedTypes = changedTypes[:0]
+		for _, tok := range sortedKeys(spec.Types) {
+			if old, ok := result.Types[tok]; ok && reflect.DeepEqual(old, spec.Types[tok]) {
+				continue
+			}
+			result.Types[tok] = spec.Types[tok]
+			changedTypes = append(changedTypes, tok)
+		}
+
+		pending = map[string]bool{}
+		for _, tok := range schemaReferrers(prev, changedTypes) {
+			if !done[tok] {
+				pending[tok] = true
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// gatherSchema generates the Pulumi schema for only the resources and functions with the given tokens.
+func (g *Generator) gatherSchema(tokens map[string]bool) (pschema.PackageSpec, error) {
+	g.onlyTokens = tokens
+	defer func() { g.onlyTokens = nil }()
+
+	pack, err := g.gatherPackage()
+	if err != nil {
+		return pschema.PackageSpec{}, errors.Wrapf(err, "failed to gather package metadata")
+	}
+	spec, err := genPulumiSchema(pack, g.pkg, g.version, g.info)
+	if err != nil {
+		return pschema.PackageSpec{}, errors.Wrapf(err, "failed to create Pulumi schema")
+	}
+	return spec, nil
+}
+
+// schemaReferrers returns the tokens of the resources and functions in spec that reference any of the given types,
+// either directly or through other types.
+func schemaReferrers(spec pschema.PackageSpec, types []string) []string {
+	if len(types) == 0 {
+		return nil
+	}
+
+	marshaled := func(v interface{}) string {
+		bytes, err := json.Marshal(v)
+		if err != nil {
+			return ""
+		}
+		return string(bytes)
+	}
+
+	// Compute the set of types that transitively reference the given types.
+	referenced := map[string]bool{}
+	var refs []string
+	addType := func(tok string) {
+		if !referenced[tok] {
+			referenced[tok] = true
+			refs = append(refs, `"#/types/`+tok+`"`)
+		}
+	}
+	for _, tok := range types {
+		addType(tok)
+	}
+	references := func(v interface{}) bool {
+		text := marshaled(v)
+		for _, ref := range refs {
+			if strings.Contains(text, ref) {
+				return true
+			}
+		}
+		return false
+	}
+	for grew := true; grew; {
+		grew = false
+		for _, tok := range sortedKeys(spec.Types) {
+			if !referenced[tok] && references(spec.Types[tok]) {
+				addType(tok)
+				grew = true
+			}
+		}
+	}
+
+	var referrers []string
+	for _, tok := range sortedKeys(spec.Resources) {
+		if references(spec.Resources[tok]) {
+			referrers = append(referrers, tok)
+		}
+	}
+	for _, tok := range sortedKeys(spec.Functions) {
+		if references(spec.Functions[tok]) {
+			referrers = append(referrers, tok)
+		}
+	}
+	return referrers
+}
+
+func sortedKeys(m interface{}) []string {
+	var keys []string
+	for _, k := range reflect.ValueOf(m).MapKeys() {
+		keys = append(keys, k.String())
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func copyResourceSpecs(m map[string]pschema.ResourceSpec) map[string]pschema.ResourceSpec {
+	result := make(map[string]pschema.ResourceSpec, len(m))
+	for k, v := range m {
+		result[k] = v
+	}
+	return result
+}
+
+func copyFunctionSpecs(m map[string]pschema.FunctionSpec) map[string]pschema.FunctionSpec {
+	result := make(map[string]pschema.FunctionSpec, len(m))
+	for k, v := range m {
+		result[k] = v
+	}
+	return result
+}
+
+func copyTypeSpecs(m map[string]pschema.ComplexTypeSpec) map[string]pschema.ComplexTypeSpec {
+	result := make(map[string]pschema.ComplexTypeSpec, len(m))
+	for k, v := range m {
+		result[k] = v
+	}
+	return result
+}
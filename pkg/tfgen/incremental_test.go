@@ -0,0 +1,117 @@
+// Copyright 2016-2022, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tfgen
+
+import (
+	"io"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/diag"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/diag/colors"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi-terraform-bridge/v3/pkg/tfbridge"
+	shimv1 "github.com/pulumi/pulumi-terraform-bridge/v3/pkg/tfshim/sdk-v1"
+)
+
+func newIncrementalTestGenerator(t *testing.T, withShade bool) *Generator {
+	settings := map[string]*schema.Schema{
+		"color": {Type: schema.TypeString, Optional: true},
+	}
+	sizes := map[string]*schema.Schema{}
+	if withShade {
+		settings["shade"] = &schema.Schema{Type: schema.TypeString, Optional: true}
+		sizes["size"] = &schema.Schema{Type: schema.TypeInt, Optional: true}
+	}
+	withSettings := func(fields map[string]*schema.Schema) map[string]*schema.Schema {
+		fields["settings"] = &schema.Schema{
+			Type:     schema.TypeList,
+			Optional: true,
+			MaxItems: 1,
+			Elem:     &schema.Resource{Schema: settings},
+		}
+		return fields
+	}
+	withSizes := func(fields map[string]*schema.Schema) map[string]*schema.Schema {
+		for k, v := range sizes {
+			fields[k] = v
+		}
+		return fields
+	}
+	sharedSettings := map[string]*tfbridge.SchemaInfo{
+		"settings": {Elem: &tfbridge.SchemaInfo{NestedType: "Settings"}},
+	}
+
+	info := tfbridge.ProviderInfo{
+		P: shimv1.NewProvider(&schema.Provider{
+			ResourcesMap: map[string]*schema.Resource{
+				"tiny_widget": {Schema: withSettings(map[string]*schema.Schema{})},
+				"tiny_gadget": {Schema: withSizes(withSettings(map[string]*schema.Schema{}))},
+				"tiny_sprocket": {Schema: withSizes(map[string]*schema.Schema{
+					"label": {Type: schema.TypeString, Optional: true},
+				})},
+			},
+		}),
+		Name: "tiny",
+		Resources: map[string]*tfbridge.ResourceInfo{
+			"tiny_widget":   {Tok: "tiny:index/widget:Widget", Fields: sharedSettings},
+			"tiny_gadget":   {Tok: "tiny:index/gadget:Gadget", Fields: sharedSettings},
+			"tiny_sprocket": {Tok: "tiny:index/sprocket:Sprocket"},
+		},
+	}
+
+	g, err := NewGenerator(GeneratorOptions{
+		Package:      info.Name,
+		Language:     Schema,
+		ProviderInfo: info,
+		Root:         afero.NewMemMapFs(),
+		Sink: diag.DefaultSink(io.Discard, io.Discard, diag.FormatOptions{
+			Color: colors.Never,
+		}),
+		SkipDocs:     true,
+		SkipExamples: true,
+	})
+	assert.NoError(t, err)
+	return g
+}
+
+func TestRegenerateSchema(t *testing.T) {
+	prev, err := newIncrementalTestGenerator(t, false).gatherSchema(nil)
+	assert.NoError(t, err)
+	assert.NotContains(t, prev.Types["tiny:index/Settings:Settings"].Properties, "shade")
+
+	// Only the widget is marked as changed, but the upstream provider has also added fields to every resource.
+	g := newIncrementalTestGenerator(t, true)
+	next, err := g.RegenerateSchema(prev, []string{"tiny:index/widget:Widget"})
+	assert.NoError(t, err)
+
+	// The widget's shared nested type has changed.
+	assert.Contains(t, next.Types["tiny:index/Settings:Settings"].Properties, "shade")
+	assert.NotContains(t, prev.Types["tiny:index/Settings:Settings"].Properties, "shade")
+
+	// The gadget references the shared type, so it must be regenerated as well.
+	assert.Contains(t, next.Resources["tiny:index/gadget:Gadget"].InputProperties, "size")
+
+	// The sprocket is left untouched.
+	assert.NotContains(t, next.Resources["tiny:index/sprocket:Sprocket"].InputProperties, "size")
+	assert.Equal(t, prev.Resources["tiny:index/sprocket:Sprocket"], next.Resources["tiny:index/sprocket:Sprocket"])
+
+	// Regenerating the sprocket alone picks up its new field.
+	next, err = g.RegenerateSchema(next, []string{"tiny:index/sprocket:Sprocket"})
+	assert.NoError(t, err)
+	assert.Contains(t, next.Resources["tiny:index/sprocket:Sprocket"].InputProperties, "size")
+}
@@ -0,0 +1,251 @@
+// Copyright 2016-2022, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tfgen
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/common/diag"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/diag/colors"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/util/contract"
+	"github.com/spf13/afero"
+
+	"github.com/pulumi/pulumi-terraform-bridge/v3/pkg/tfbridge"
+	shim "github.com/pulumi/pulumi-terraform-bridge/v3/pkg/tfshim"
+)
+
+// LintIssueKind describes the kind of mismatch reported by LintDocs.
+type LintIssueKind string
+
+const (
+	// LintUnknownArgument indicates an argument that is documented but does not exist in the schema.
+	LintUnknownArgument LintIssueKind = "unknown-argument"
+	// LintUndocumentedField indicates a schema field that is not documented.
+	LintUndocumentedField LintIssueKind = "undocumented-field"
+	// LintCaseMismatch indicates an argument that is documented under a name that only matches a schema field when
+	// case is ignored. This is usually a typo in the upstream docs.
+	LintCaseMismatch LintIssueKind = "case-mismatch"
+	// LintDocsError indicates that the docs for an entity could not be read.
+	LintDocsError LintIssueKind = "docs-error"
+)
+
+// LintIssue is a single mismatch between the docs for a resource or data source and its Terraform schema.
+type LintIssue struct {
+	// Kind is the kind of issue.
+	Kind LintIssueKind
+	// DocKind indicates whether the issue pertains to a resource or a data source.
+	DocKind DocKind
+	// Token is the Terraform token of the resource or data source.
+	Token string
+	// Field is the dotted path of the field in question, e.g. `settings.color`. Fields that are only known from the
+	// docs are reported by their documented name.
+	Field string
+	// Message holds additional detail, if any.
+	Message string
+}
+
+func (i LintIssue) String() string {
+	switch i.Kind {
+	case LintUnknownArgument:
+		return fmt.Sprintf("%v %v: argument %q is documented but does not exist in the schema", i.DocKind, i.Token,
+			i.Field)
+	case LintUndocumentedField:
+		return fmt.Sprintf("%v %v: field %q is not documented", i.DocKind, i.Token, i.Field)
+	case LintCaseMismatch:
+		return fmt.Sprintf("%v %v: argument %q only matches schema field %v when ignoring case", i.DocKind, i.Token,
+			i.Field, i.Message)
+	default:
+		return fmt.Sprintf("%v %v: %v", i.DocKind, i.Token, i.Message)
+	}
+}
+
+// LintDocs cross-references the upstream docs of each resource and data source in the given provider against its
+// Terraform schema. It reports arguments that are documented but absent from the schema and schema fields that are
+// not documented. Documented arguments that only match a schema field when ignoring case are reported separately, as
+// they usually point to a typo in the upstream docs. Entities without docs are skipped.
+//
+// Because the upstream docs often record nested arguments by their own name rather than by their full path, a
+// documented name is matched against every field in the schema that carries that name, regardless of its depth.
+func LintDocs(info tfbridge.ProviderInfo) []LintIssue {
+	g, err := NewGenerator(GeneratorOptions{
+		Package:      info.Name,
+		Language:     Schema,
+		ProviderInfo: info,
+		Root:         afero.NewMemMapFs(),
+		Sink: diag.DefaultSink(io.Discard, io.Discard, diag.FormatOptions{
+			Color: colors.Never,
+		}),
+	})
+	contract.AssertNoError(err)
+
+	var issues []LintIssue
+	if resources := info.P.ResourcesMap(); resources != nil {
+		resources.Range(func(rawname string, res shim.Resource) bool {
+			var resInfo tfbridge.ResourceOrDataSourceInfo
+			if ri := info.GetResource(rawname); ri != nil {
+				resInfo = ri
+			}
+			issues = append(issues, g.lintEntityDocs(ResourceDocs, rawname, res, resInfo)...)
+			return true
+		})
+	}
+	if dataSources := info.P.DataSourcesMap(); dataSources != nil {
+		dataSources.Range(func(rawname string, ds shim.Resource) bool {
+			var dsInfo tfbridge.ResourceOrDataSourceInfo
+			if di := info.GetDataSource(rawname); di != nil {
+				dsInfo = di
+			}
+			issues = append(issues, g.lintEntityDocs(DataSourceDocs, rawname, ds, dsInfo)...)
+			return true
+		})
+	}
+
+	sort.Slice(issues, func(i, j int) bool {
+		a, b := issues[i], issues[j]
+		if a.DocKind != b.DocKind {
+			return a.DocKind > b.DocKind
+		}
+		if a.Token != b.Token {
+			return a.Token < b.Token
+		}
+		if a.Kind != b.Kind {
+			return a.Kind < b.Kind
+		}
+		return a.Field < b.Field
+	})
+	return issues
+}
+
+// lintEntityDocs checks the docs of a single resource or data source against its schema.
+func (g *Generator) lintEntityDocs(kind DocKind, rawname string, res shim.Resource,
+	info tfbridge.ResourceOrDataSourceInfo) []LintIssue {
+
+	doc, err := getDocsForProvider(g, g.info.GetGitHubOrg(), g.info.Name, g.info.GetResourcePrefix(), kind, rawname,
+		info, g.info.GetProviderModuleVersion(), g.info.GetGitHubHost())
+	if err != nil {
+		return []LintIssue{{Kind: LintDocsError, DocKind: kind, Token: rawname, Message: err.Error()}}
+	}
+	if len(doc.Arguments) == 0 && len(doc.Attributes) == 0 {
+		return nil
+	}
+
+	fields := map[string][]string{}
+	collectLintFields("", res.Schema(), fields)
+
+	var issues []LintIssue
+	lintArgument := func(field, name string) {
+		if len(findMatchingFields(fields, name, false)) != 0 {
+			return
+		}
+		issue := LintIssue{Kind: LintUnknownArgument, DocKind: kind, Token: rawname, Field: field}
+		if matches := findMatchingFields(fields, name, true); len(matches) != 0 {
+			sort.Strings(matches)
+			issue.Kind, issue.Message = LintCaseMismatch, fmt.Sprintf("%q", strings.Join(matches, `", "`))
+		}
+		issues = append(issues, issue)
+	}
+	for name, arg := range doc.Arguments {
+		lintArgument(name, name)
+		for nested := range arg.arguments {
+			if _, recorded := doc.Arguments[nested]; recorded {
+				// Reported as a top-level argument above.
+				continue
+			}
+			lintArgument(name+"."+nested, nested)
+		}
+	}
+
+	for _, paths := range fields {
+		for _, path := range paths {
+			if !isFieldDocumented(doc, path) {
+				issues = append(issues, LintIssue{Kind: LintUndocumentedField, DocKind: kind, Token: rawname, Field: path})
+			}
+		}
+	}
+	return issues
+}
+
+// collectLintFields records the dotted path of every field in the given schema, at any depth, keyed by the field's
+// own name.
+func collectLintFields(prefix string, sch shim.SchemaMap, fields map[string][]string) {
+	if sch == nil {
+		return
+	}
+	sch.Range(func(name string, s shim.Schema) bool {
+		path := prefix + name
+		fields[name] = append(fields[name], path)
+		if elem, ok := s.Elem().(shim.Resource); ok {
+			collectLintFields(path+".", elem.Schema(), fields)
+		}
+		return true
+	})
+}
+
+// findMatchingFields returns the paths of all schema fields whose name matches the given documented name. Documented
+// names that are written as paths, e.g. `settings[0].color` or `settings.color`, are matched by their last element.
+// If ignoreCase is true, names are compared case-insensitively.
+func findMatchingFields(fields map[string][]string, name string, ignoreCase bool) []string {
+	name = lintIndexRegexp.ReplaceAllString(name, "")
+	if i := strings.LastIndex(name, "."); i != -1 {
+		name = name[i+1:]
+	}
+	if !ignoreCase {
+		return fields[name]
+	}
+
+	var matches []string
+	for field, paths := range fields {
+		if strings.EqualFold(field, name) {
+			matches = append(matches, paths...)
+		}
+	}
+	return matches
+}
+
+var lintIndexRegexp = regexp.MustCompile(`\[[^\]]*\]`)
+
+// isFieldDocumented returns true if the docs describe the schema field with the given dotted path, either as an
+// argument or as an attribute.
+func isFieldDocumented(doc entityDocs, path string) bool {
+	name, parent := path, ""
+	if i := strings.LastIndex(path, "."); i != -1 {
+		name, parent = path[i+1:], path[:i]
+		if j := strings.LastIndex(parent, "."); j != -1 {
+			parent = parent[j+1:]
+		}
+	}
+
+	if _, ok := doc.Arguments[name]; ok {
+		return true
+	}
+	if _, ok := doc.Attributes[name]; ok {
+		return true
+	}
+	if parent != "" {
+		if arg, ok := doc.Arguments[parent]; ok {
+			if _, ok := arg.arguments[name]; ok {
+				return true
+			}
+		}
+		if _, ok := doc.NestedAttributes[parent][name]; ok {
+			return true
+		}
+	}
+	return false
+}
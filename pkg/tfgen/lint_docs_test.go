@@ -0,0 +1,169 @@
+// Copyright 2016-2022, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tfgen
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi-terraform-bridge/v3/pkg/tfbridge"
+	shimv1 "github.com/pulumi/pulumi-terraform-bridge/v3/pkg/tfshim/sdk-v1"
+)
+
+func TestLintDocs(t *testing.T) {
+	markdown := `# widgets_widget
+
+Provides a widget.
+
+## Argument Reference
+
+* ` + "`name`" + ` - (Required) The name of the widget.
+* ` + "`colour`" + ` - (Optional) The colour of the widget.
+* ` + "`settings`" + ` - (Optional) The widget's settings. Documented below.
+
+The ` + "`settings`" + ` block supports:
+
+* ` + "`shade`" + ` - (Optional) The shade of the widget.
+
+## Attributes Reference
+
+* ` + "`arn`" + ` - The ARN of the widget.
+`
+
+	info := tfbridge.ProviderInfo{
+		Name: "widgets",
+		P: shimv1.NewProvider(&schema.Provider{
+			ResourcesMap: map[string]*schema.Resource{
+				"widgets_widget": {Schema: map[string]*schema.Schema{
+					"name": {Type: schema.TypeString, Required: true},
+					"size": {Type: schema.TypeInt, Optional: true},
+					"arn":  {Type: schema.TypeString, Computed: true},
+					"settings": {
+						Type:     schema.TypeList,
+						Optional: true,
+						MaxItems: 1,
+						Elem: &schema.Resource{Schema: map[string]*schema.Schema{
+							"shade": {Type: schema.TypeString, Optional: true},
+							"gloss": {Type: schema.TypeBool, Optional: true},
+						}},
+					},
+				}},
+				// Resources without docs are skipped.
+				"widgets_gadget": {Schema: map[string]*schema.Schema{
+					"name": {Type: schema.TypeString, Required: true},
+				}},
+			},
+		}),
+		Resources: map[string]*tfbridge.ResourceInfo{
+			"widgets_widget": {
+				Tok:  "widgets:index/widget:Widget",
+				Docs: &tfbridge.DocInfo{Markdown: []byte(markdown)},
+			},
+			"widgets_gadget": {
+				Tok:  "widgets:index/gadget:Gadget",
+				Docs: &tfbridge.DocInfo{Markdown: []byte("# widgets_gadget\n\nProvides a gadget.\n")},
+			},
+		},
+	}
+
+	issues := LintDocs(info)
+	assert.Equal(t, []LintIssue{
+		{Kind: LintUndocumentedField, DocKind: ResourceDocs, Token: "widgets_widget", Field: "settings.gloss"},
+		{Kind: LintUndocumentedField, DocKind: ResourceDocs, Token: "widgets_widget", Field: "size"},
+		{Kind: LintUnknownArgument, DocKind: ResourceDocs, Token: "widgets_widget", Field: "colour"},
+	}, issues)
+	assert.Equal(t, `resource widgets_widget: argument "colour" is documented but does not exist in the schema`,
+		issues[2].String())
+}
+
+func TestFindMatchingFields(t *testing.T) {
+	fields := map[string][]string{}
+	collectLintFields("", shimv1.NewSchemaMap(map[string]*schema.Schema{
+		"name": {Type: schema.TypeString, Optional: true},
+		"settings": {
+			Type:     schema.TypeList,
+			Optional: true,
+			Elem: &schema.Resource{Schema: map[string]*schema.Schema{
+				"name": {Type: schema.TypeString, Optional: true},
+			}},
+		},
+	}), fields)
+
+	assert.ElementsMatch(t, []string{"name", "settings.name"}, findMatchingFields(fields, "name", false))
+	assert.ElementsMatch(t, []string{"name", "settings.name"}, findMatchingFields(fields, "settings[0].name", false))
+	assert.Equal(t, []string{"settings"}, findMatchingFields(fields, "settings", false))
+	assert.Empty(t, findMatchingFields(fields, "missing", false))
+	assert.Empty(t, findMatchingFields(fields, "Name", false))
+	assert.ElementsMatch(t, []string{"name", "settings.name"}, findMatchingFields(fields, "Name", true))
+	assert.ElementsMatch(t, []string{"name", "settings.name"}, findMatchingFields(fields, "settings[0].NAME", true))
+	assert.Empty(t, findMatchingFields(fields, "missing", true))
+}
+
+func TestLintDocsCaseMismatch(t *testing.T) {
+	markdown := `# widgets_widget
+
+Provides a widget.
+
+## Argument Reference
+
+* ` + "`name`" + ` - (Required) The name of the widget.
+* ` + "`displayName`" + ` - (Optional) The display name of the widget.
+* ` + "`settings`" + ` - (Optional) The widget's settings. Documented below.
+
+The ` + "`settings`" + ` block supports:
+
+* ` + "`Shade`" + ` - (Optional) The shade of the widget.
+`
+
+	info := tfbridge.ProviderInfo{
+		Name: "widgets",
+		P: shimv1.NewProvider(&schema.Provider{
+			ResourcesMap: map[string]*schema.Resource{
+				"widgets_widget": {Schema: map[string]*schema.Schema{
+					"name":        {Type: schema.TypeString, Required: true},
+					"displayname": {Type: schema.TypeString, Optional: true},
+					"settings": {
+						Type:     schema.TypeList,
+						Optional: true,
+						MaxItems: 1,
+						Elem: &schema.Resource{Schema: map[string]*schema.Schema{
+							"shade": {Type: schema.TypeString, Optional: true},
+						}},
+					},
+				}},
+			},
+		}),
+		Resources: map[string]*tfbridge.ResourceInfo{
+			"widgets_widget": {
+				Tok:  "widgets:index/widget:Widget",
+				Docs: &tfbridge.DocInfo{Markdown: []byte(markdown)},
+			},
+		},
+	}
+
+	issues := LintDocs(info)
+	assert.Equal(t, []LintIssue{
+		{Kind: LintCaseMismatch, DocKind: ResourceDocs, Token: "widgets_widget", Field: "Shade",
+			Message: `"settings.shade"`},
+		{Kind: LintCaseMismatch, DocKind: ResourceDocs, Token: "widgets_widget", Field: "displayName",
+			Message: `"displayname"`},
+		{Kind: LintUndocumentedField, DocKind: ResourceDocs, Token: "widgets_widget", Field: "displayname"},
+		{Kind: LintUndocumentedField, DocKind: ResourceDocs, Token: "widgets_widget", Field: "settings.shade"},
+	}, issues)
+	assert.Equal(t, `resource widgets_widget: argument "displayName" only matches schema field "displayname" `+
+		`when ignoring case`, issues[1].String())
+}
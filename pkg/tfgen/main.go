@@ -52,6 +52,8 @@ func newTFGenCmd(pkg string, version string, prov tfbridge.ProviderInfo) *cobra.
 	var debug bool
 	var skipDocs bool
 	var skipExamples bool
+	var collapseDuplicateExamples bool
+	var maxArgumentNestingDepth int
 	cmd := &cobra.Command{
 		Use:   os.Args[0] + " <LANGUAGE>",
 		Args:  cmdutil.SpecificArgs([]string{"language"}),
@@ -135,6 +137,9 @@ func newTFGenCmd(pkg string, version string, prov tfbridge.ProviderInfo) *cobra.
 				SkipDocs:        skipDocs,
 				SkipExamples:    skipExamples,
 				CoverageTracker: coverageTracker,
+
+				CollapseDuplicateExamples: collapseDuplicateExamples,
+				MaxArgumentNestingDepth:   maxArgumentNestingDepth,
 			})
 			if err != nil {
 				return err
@@ -178,6 +183,12 @@ func newTFGenCmd(pkg string, version string, prov tfbridge.ProviderInfo) *cobra.
 		&skipDocs, "skip-docs", false, "Do not convert docs from TF Markdown")
 	cmd.PersistentFlags().BoolVar(
 		&skipExamples, "skip-examples", false, "Do not convert examples from HCL")
+	cmd.PersistentFlags().BoolVar(
+		&collapseDuplicateExamples, "collapse-duplicate-examples", false,
+		"Drop examples whose code is identical to that of an earlier example")
+	cmd.PersistentFlags().IntVar(
+		&maxArgumentNestingDepth, "max-argument-nesting-depth", 0,
+		"Warn about documented arguments nested more than this many levels deep (0 disables the warning)")
 
 	cmd.PersistentFlags().StringVar(
 		&overlaysDir, "overlays", "",
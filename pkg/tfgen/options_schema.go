@@ -0,0 +1,126 @@
+// Copyright 2016-2022, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tfgen
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/util/contract"
+)
+
+// durationPattern matches the duration strings accepted by time.ParseDuration, e.g. "30s" or "1m30s".
+const durationPattern = `^(0|([0-9]+(\.[0-9]*)?(ns|us|µs|ms|s|m|h))+)$`
+
+// Duration is a time.Duration whose JSON form is a duration string as accepted by time.ParseDuration, e.g. "30s",
+// rather than a count of nanoseconds.
+type Duration time.Duration
+
+// MarshalJSON implements json.Marshaler.
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(time.Duration(d).String())
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (d *Duration) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return errors.Errorf("expected a duration string, e.g. \"30s\", not %s", data)
+	}
+	v, err := time.ParseDuration(s)
+	if err != nil {
+		return err
+	}
+	*d = Duration(v)
+	return nil
+}
+
+// GeneratorOptionsJSONSchema returns a JSON Schema that describes the serializable subset of GeneratorOptions, i.e. the
+// options that may be loaded with LoadGeneratorOptions. Options that hold functions, interfaces, or provider metadata
+// are omitted.
+func GeneratorOptionsJSONSchema() ([]byte, error) {
+	properties := map[string]interface{}{}
+
+	t := reflect.TypeOf(GeneratorOptions{})
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name, ok := jsonFieldName(field)
+		if !ok {
+			continue
+		}
+
+		var property map[string]interface{}
+		switch {
+		case field.Type == reflect.TypeOf(Language("")):
+			property = map[string]interface{}{
+				"type": "string",
+				"enum": []Language{Golang, NodeJS, Python, CSharp, Schema, PCL},
+			}
+		case field.Type == reflect.TypeOf(Duration(0)):
+			property = map[string]interface{}{"type": "string", "pattern": durationPattern}
+		case field.Type.Kind() == reflect.String:
+			property = map[string]interface{}{"type": "string"}
+		case field.Type.Kind() == reflect.Bool:
+			property = map[string]interface{}{"type": "boolean"}
+		case field.Type.Kind() == reflect.Int:
+			property = map[string]interface{}{"type": "integer"}
+		case field.Type == reflect.TypeOf([]string(nil)):
+			property = map[string]interface{}{"type": "array", "items": map[string]interface{}{"type": "string"}}
+		default:
+			contract.Failf("unexpected type %v for serializable option %v", field.Type, field.Name)
+		}
+		properties[name] = property
+	}
+
+	schema := map[string]interface{}{
+		"$schema":              "http://json-schema.org/draft-07/schema#",
+		"title":                "GeneratorOptions",
+		"type":                 "object",
+		"properties":           properties,
+		"additionalProperties": false,
+	}
+	return json.MarshalIndent(schema, "", "    ")
+}
+
+// jsonFieldName returns the name of the given field in the JSON form of GeneratorOptions. Fields that are tagged `-`
+// are not serializable.
+func jsonFieldName(field reflect.StructField) (string, bool) {
+	tag := field.Tag.Get("json")
+	if tag == "-" {
+		return "", false
+	}
+	if name := strings.Split(tag, ",")[0]; name != "" {
+		return name, true
+	}
+	return field.Name, true
+}
+
+// LoadGeneratorOptions constructs GeneratorOptions from their JSON form, as described by GeneratorOptionsJSONSchema.
+// Options that are not serializable are left unset; the caller may fill them in before passing the options to
+// NewGenerator.
+func LoadGeneratorOptions(data []byte) (GeneratorOptions, error) {
+	var opts GeneratorOptions
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&opts); err != nil {
+		return GeneratorOptions{}, errors.Wrap(err, "failed to load generator options")
+	}
+	return opts, nil
+}
@@ -0,0 +1,103 @@
+// Copyright 2016-2022, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tfgen
+
+import (
+	"encoding/json"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadGeneratorOptions(t *testing.T) {
+	opts, err := LoadGeneratorOptions([]byte(`{
+		"package": "widgets",
+		"version": "1.2.3",
+		"language": "nodejs",
+		"terraformVersion": "12",
+		"debug": true,
+		"skipDocs": true,
+		"skipExamples": true,
+		"collapseDuplicateExamples": true,
+		"maxArgumentNestingDepth": 3,
+		"convertInlineHTML": true,
+		"exampleConversionTimeout": "1m30s"
+	}`))
+	assert.NoError(t, err)
+	assert.Equal(t, GeneratorOptions{
+		Package:                   "widgets",
+		Version:                   "1.2.3",
+		Language:                  NodeJS,
+		TerraformVersion:          "12",
+		Debug:                     true,
+		SkipDocs:                  true,
+		SkipExamples:              true,
+		CollapseDuplicateExamples: true,
+		MaxArgumentNestingDepth:   3,
+		ConvertInlineHTML:         true,
+		ExampleConversionTimeout:  Duration(90 * time.Second),
+	}, opts)
+
+	// The options round-trip through their JSON form.
+	data, err := json.Marshal(opts)
+	assert.NoError(t, err)
+	roundTripped, err := LoadGeneratorOptions(data)
+	assert.NoError(t, err)
+	assert.Equal(t, opts, roundTripped)
+
+	_, err = LoadGeneratorOptions([]byte(`{"package": "widgets", "sink": {}}`))
+	assert.Error(t, err)
+
+	// Timeouts are duration strings rather than counts of nanoseconds.
+	_, err = LoadGeneratorOptions([]byte(`{"exampleConversionTimeout": 30}`))
+	assert.Error(t, err)
+	_, err = LoadGeneratorOptions([]byte(`{"exampleConversionTimeout": "30 seconds"}`))
+	assert.Error(t, err)
+}
+
+func TestGeneratorOptionsJSONSchema(t *testing.T) {
+	data, err := GeneratorOptionsJSONSchema()
+	assert.NoError(t, err)
+
+	var schema struct {
+		Type       string                            `json:"type"`
+		Properties map[string]map[string]interface{} `json:"properties"`
+	}
+	assert.NoError(t, json.Unmarshal(data, &schema))
+	assert.Equal(t, "object", schema.Type)
+
+	names := make([]string, 0, len(schema.Properties))
+	for name := range schema.Properties {
+		names = append(names, name)
+	}
+	assert.ElementsMatch(t, []string{
+		"package", "version", "language", "terraformVersion", "debug", "skipDocs", "skipExamples",
+		"collapseDuplicateExamples", "maxArgumentNestingDepth", "convertInlineHTML", "escapeMarkdown",
+		"maxDescriptionLength", "exampleConversionTimeout", "acronyms", "exampleLanguages",
+	}, names)
+
+	assert.Equal(t, "boolean", schema.Properties["skipDocs"]["type"])
+	assert.Equal(t, "integer", schema.Properties["maxArgumentNestingDepth"]["type"])
+	assert.Equal(t, "string", schema.Properties["exampleConversionTimeout"]["type"])
+	pattern := regexp.MustCompile(schema.Properties["exampleConversionTimeout"]["pattern"].(string))
+	for _, d := range []string{"0", "30s", "1m30s", "1.5h", "250ms"} {
+		assert.True(t, pattern.MatchString(d), d)
+	}
+	assert.False(t, pattern.MatchString("30"))
+	assert.Equal(t, []interface{}{"go", "nodejs", "python", "dotnet", "schema", "pulumi"},
+		schema.Properties["language"]["enum"])
+}
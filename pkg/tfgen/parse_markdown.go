@@ -53,6 +53,9 @@ type nestedSchema struct {
 	optional []parameter
 	required []parameter
 	readonly []parameter
+
+	// Whether the schema documents attributes rather than arguments, i.e. it is linked from a read-only parameter.
+	isAttribute bool
 }
 
 func (ns *nestedSchema) allParameters() []parameter {
@@ -63,6 +66,9 @@ type parameter struct {
 	name     string
 	desc     string
 	typeDecl string
+
+	// The anchor of the nested schema that the description links to, if any, e.g. "nestedblock--settings".
+	linkID string
 }
 
 type paramFlags int
@@ -94,7 +100,11 @@ func parseTopLevelSchemaIntoDocs(
 
 	for _, ns := range topLevelSchema.nestedSchemata {
 		nestedSchema := ns // this stops implicit memory addressing
-		parseNestedSchemaIntoDocs(accumulatedDocs, &nestedSchema, warn)
+		if nestedSchema.isAttribute {
+			parseNestedAttributesIntoDocs(accumulatedDocs, &nestedSchema)
+		} else {
+			parseNestedSchemaIntoDocs(accumulatedDocs, &nestedSchema, warn)
+		}
 	}
 }
 
@@ -145,11 +155,76 @@ func parseTopLevelSchema(node *bf.Node, consumeNode func(node *bf.Node)) (*topLe
 	}
 
 	tls.nestedSchemata = nested
+	resolveNestedSchemata(tls)
 
 	consumeNode(node)
 	return tls, nil
 }
 
+// resolveNestedSchemata attaches each nested schema to the parameter whose "(see below for nested schema)" link points
+// to the schema's anchor, rather than to the name in the schema's header, which may be abbreviated. Links point to
+// anchors for nested blocks, e.g. "#nestedblock--settings", and for nested attributes, e.g. "#nestedatt--settings",
+// alike. Schemata that are linked from read-only parameters, or that are nested within such schemata, document
+// attributes rather than arguments. Schemata that no parameter links to keep the name in their header.
+func resolveNestedSchemata(tls *topLevelSchema) {
+	type link struct {
+		path        string
+		isAttribute bool
+	}
+	links := map[string]link{}
+	addLinks := func(parent string, params []parameter, isAttribute bool) {
+		for _, param := range params {
+			if param.linkID == "" {
+				continue
+			}
+			path := param.name
+			if parent != "" {
+				path = parent + "." + param.name
+			}
+			links[param.linkID] = link{path: path, isAttribute: isAttribute}
+		}
+	}
+	addLinks("", tls.optional, false)
+	addLinks("", tls.required, false)
+	addLinks("", tls.readonly, true)
+
+	resolved := make([]bool, len(tls.nestedSchemata))
+	for {
+		progress := false
+		for i := range tls.nestedSchemata {
+			ns := &tls.nestedSchemata[i]
+			if resolved[i] || ns.linkID == nil {
+				continue
+			}
+			if l, ok := links[*ns.linkID]; ok {
+				ns.longName, ns.isAttribute = l.path, l.isAttribute
+				addLinks(ns.longName, ns.optional, ns.isAttribute)
+				addLinks(ns.longName, ns.required, ns.isAttribute)
+				addLinks(ns.longName, ns.readonly, true)
+				resolved[i], progress = true, true
+			}
+		}
+		if progress {
+			continue
+		}
+
+		// None of the remaining schemata is linked from a resolved one, so fall back to the header of the first of
+		// them, whose parameters may in turn link to the others.
+		i := 0
+		for i < len(resolved) && resolved[i] {
+			i++
+		}
+		if i == len(resolved) {
+			return
+		}
+		ns := &tls.nestedSchemata[i]
+		addLinks(ns.longName, ns.optional, false)
+		addLinks(ns.longName, ns.required, false)
+		addLinks(ns.longName, ns.readonly, true)
+		resolved[i] = true
+	}
+}
+
 func parseNestedSchemaIntoDocs(
 	accumulatedDocs *entityDocs,
 	nestedSchema *nestedSchema,
@@ -158,7 +233,17 @@ func parseNestedSchemaIntoDocs(
 	args, _ := accumulatedDocs.getOrCreateArgumentDocs(nestedSchema.longName)
 	args.isNested = true
 
-	for _, param := range nestedSchema.allParameters() {
+	isRequired := map[string]bool{}
+	for _, param := range nestedSchema.required {
+		isRequired[param.name] = true
+	}
+
+	// The parameters in the "Read-Only:" group of the schema are attributes of the block rather than arguments.
+	if len(nestedSchema.readonly) != 0 {
+		recordNestedAttributes(accumulatedDocs, nestedSchema.longName, nestedSchema.readonly)
+	}
+
+	for _, param := range append(nestedSchema.optional, nestedSchema.required...) {
 		oldDesc, hasAlready := args.arguments[param.name]
 		if hasAlready && oldDesc != param.desc {
 			warn("Description conflict for param %s from %s; candidates are `%s` and `%s`",
@@ -177,10 +262,32 @@ func parseNestedSchemaIntoDocs(
 				param.desc)
 		}
 		paramArgs.isNested = true
+		paramArgs.isRequired = isRequired[param.name]
 		paramArgs.description = param.desc
 	}
 }
 
+// parseNestedAttributesIntoDocs records the parameters of a nested schema that documents attributes as the nested
+// attributes of the schema's block.
+func parseNestedAttributesIntoDocs(accumulatedDocs *entityDocs, nestedSchema *nestedSchema) {
+	recordNestedAttributes(accumulatedDocs, nestedSchema.longName, nestedSchema.allParameters())
+}
+
+// recordNestedAttributes records the given parameters as nested attributes of the block at the given path.
+func recordNestedAttributes(accumulatedDocs *entityDocs, path string, params []parameter) {
+	if accumulatedDocs.NestedAttributes == nil {
+		accumulatedDocs.NestedAttributes = make(map[string]map[string]string)
+	}
+	attrs := accumulatedDocs.NestedAttributes[path]
+	if attrs == nil {
+		attrs = make(map[string]string)
+		accumulatedDocs.NestedAttributes[path] = attrs
+	}
+	for _, param := range params {
+		attrs[param.name] = param.desc
+	}
+}
+
 func parseNestedSchema(node *bf.Node, consumeNode func(node *bf.Node)) (*nestedSchema, error) {
 	if consumeNode == nil {
 		consumeNode = func(node *bf.Node) {}
@@ -376,10 +483,23 @@ func parseParameter(node *bf.Node) (*parameter, error) {
 	if err != nil {
 		return nil, err
 	}
-	return parseParameterFromDescription(paramName, cleanDesc(paramDesc)), nil
+	param := parseParameterFromDescription(paramName, cleanDesc(paramDesc))
+	param.linkID = nestedSchemaLinkID(paramDesc)
+	return param, nil
 }
 
-var seeBelowPattern = regexp.MustCompile(`[(]see \[below for nested schema\][(][^)]*[)][)]`)
+var seeBelowPattern = regexp.MustCompile(`[(]see (?:\[below for nested schema\][(][^)]*[)]|below for nested schema)[)]`)
+
+var seeBelowLinkPattern = regexp.MustCompile(`[(]see \[below for nested schema\][(]#([^)]+)[)][)]`)
+
+// nestedSchemaLinkID returns the anchor of the nested schema that a description links to, e.g.
+// "nestedatt--settings" for "(see [below for nested schema](#nestedatt--settings))", or "" if there is none.
+func nestedSchemaLinkID(desc string) string {
+	if matches := seeBelowLinkPattern.FindStringSubmatch(desc); matches != nil {
+		return matches[1]
+	}
+	return ""
+}
 
 func cleanDesc(desc string) string {
 	desc = seeBelowPattern.ReplaceAllString(desc, "")
@@ -0,0 +1,332 @@
+// Copyright 2016-2022, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tfgen
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// ExampleValidation records the result of converting a single HCL example code block to each target language.
+type ExampleValidation struct {
+	// Path is the path of the entity the example belongs to in the Pulumi schema, e.g. "#/resources/aws:s3/bucket:Bucket".
+	Path string
+	// Title is the title of the example subsection, if any.
+	Title string
+	// HCL is the source of the example.
+	HCL string
+	// Languages maps each target language to whether the example converted to it without errors.
+	Languages map[string]bool
+}
+
+// Failed returns true if the example failed to convert to at least one target language.
+func (v ExampleValidation) Failed() bool {
+	for _, ok := range v.Languages {
+		if !ok {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidateExamples converts every HCL code block in the "Example Usage" section of each resource and function to each
+// of the generator's target languages and reports the result for each example. Unlike Generate, nothing is emitted.
+// The results are sorted by path so that they can be compared across runs.
+func (g *Generator) ValidateExamples() ([]ExampleValidation, error) {
+	descriptions, schema, err := g.gatherExampleDescriptions()
+	if err != nil {
+		return nil, err
+	}
+	defer g.useProviderSchema(schema)()
+
+	paths := make([]string, 0, len(descriptions))
+	for path := range descriptions {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	var results []ExampleValidation
+	for _, path := range paths {
+		results = append(results, g.validateExamples(path, descriptions[path])...)
+	}
+	return results, nil
+}
+
+// GenerateExamplesOnly converts the "Example Usage" section of each resource and function to each of the generator's
+// target languages and returns the rendered examples keyed by the entity's Pulumi token. Entities without any
+// convertible examples are omitted. Unlike Generate, no SDK is emitted and no other docs are converted, so this is
+// suitable for tools that only preview examples.
+func (g *Generator) GenerateExamplesOnly() (map[string]string, error) {
+	descriptions, schema, err := g.gatherExampleDescriptions()
+	if err != nil {
+		return nil, err
+	}
+	defer g.useProviderSchema(schema)()
+
+	examples := map[string]string{}
+	for path, description := range descriptions {
+		if rendered := g.renderExamples(path, description); rendered != "" {
+			token := strings.TrimPrefix(strings.TrimPrefix(path, "#/resources/"), "#/functions/")
+			examples[token] = rendered
+		}
+	}
+	return examples, nil
+}
+
+// WriteExamples converts the "Example Usage" section of each resource or function with one of the given Pulumi tokens to
+// each of the generator's target languages and writes the examples in each language to their own file in the
+// generator's output filesystem, at the path that fileName returns for the token and the language, e.g.
+// "examples/aws/s3/bucket/python.md". Each file holds the code blocks that hclConversionsToString renders for the
+// language, preceded by the titles of their examples. No file is written for a language to which none of the examples
+// convert. The package is gathered once for all of the tokens. The paths of the written files are returned keyed by
+// token and language.
+func (g *Generator) WriteExamples(tokens []string,
+	fileName func(token, lang string) string) (map[string]map[string]string, error) {
+
+	descriptions, schema, err := g.gatherExampleDescriptions()
+	if err != nil {
+		return nil, err
+	}
+	defer g.useProviderSchema(schema)()
+
+	// Resolve every token before converting any examples, so that a bad token does not leave partial output behind.
+	paths := make([]string, len(tokens))
+	for i, token := range tokens {
+		if _, ok := descriptions["#/resources/"+token]; ok {
+			paths[i] = "#/resources/" + token
+		} else if _, ok := descriptions["#/functions/"+token]; ok {
+			paths[i] = "#/functions/" + token
+		} else {
+			return nil, errors.Errorf("no resource or function has the token %q", token)
+		}
+	}
+
+	files := map[string]map[string]string{}
+	for i, token := range tokens {
+		tokenFiles, err := g.writeExamples(token, paths[i], descriptions[paths[i]], fileName)
+		if err != nil {
+			return nil, err
+		}
+		files[token] = tokenFiles
+	}
+	return files, nil
+}
+
+// writeExamples writes the examples in the given description of the entity with the given token and path for
+// WriteExamples.
+func (g *Generator) writeExamples(token, path, description string,
+	fileName func(token, lang string) string) (map[string]string, error) {
+
+	examples := map[string][]string{}
+	for _, example := range exampleCodeBlocks(extractExamples(description)) {
+		hclConversions := g.convertExample(path, example.hcl)
+		for lang := range hclConversions {
+			code := hclConversionsToString(hclConversions, []string{lang})
+			if code == "" {
+				continue
+			}
+			if example.title != "" {
+				code = "### " + example.title + "\n\n" + code
+			}
+			examples[lang] = append(examples[lang], code)
+		}
+	}
+
+	files := map[string]string{}
+	for lang, blocks := range examples {
+		file := fileName(token, lang)
+		if err := emitFile(g.root, file, []byte(strings.Join(blocks, "\n\n")+"\n")); err != nil {
+			return nil, errors.Wrapf(err, "writing the %s examples of %s", lang, token)
+		}
+		files[lang] = file
+	}
+	return files, nil
+}
+
+// exampleCodeBlock is a single code block of an "Example Usage" section together with the title of its subsection.
+type exampleCodeBlock struct {
+	title string
+	hcl   string
+}
+
+// exampleCodeBlocks returns the code blocks of the given examples in the order in which they appear.
+func exampleCodeBlocks(examples string) []exampleCodeBlock {
+	var blocks []exampleCodeBlock
+	title, inCodeBlock, codeBlockStart := "", false, 0
+	lines := strings.Split(examples, "\n")
+	for i, line := range lines {
+		switch {
+		case inCodeBlock:
+			if !strings.HasPrefix(line, "```") {
+				continue
+			}
+			inCodeBlock = false
+			blocks = append(blocks, exampleCodeBlock{title: title, hcl: strings.Join(lines[codeBlockStart+1:i], "\n")})
+		case strings.HasPrefix(line, "```"):
+			inCodeBlock, codeBlockStart = true, i
+		case strings.HasPrefix(line, "### "):
+			title = strings.TrimSpace(strings.TrimPrefix(line, "### "))
+		}
+	}
+	return blocks
+}
+
+// convertExample converts the HCL of a single example to each target language, keyed by language. Languages to which
+// the example fails to convert are omitted.
+func (g *Generator) convertExample(path, hcl string) map[string]string {
+	if fixed, ok := fixHcl(hcl); ok {
+		hcl = fixed
+	}
+
+	hclConversions := map[string]string{}
+	for _, lang := range g.languagesToConvert() {
+		if out, err := g.convertHCLToString(hcl, path, lang); err == nil {
+			hclConversions[lang] = out
+		}
+	}
+	return hclConversions
+}
+
+// gatherExampleDescriptions returns the descriptions of each resource and function, including any supplemental
+// examples, keyed by the entity's path in the Pulumi schema, along with the serialized schema of the provider, against
+// which the examples are converted. See useProviderSchema.
+func (g *Generator) gatherExampleDescriptions() (map[string]string, []byte, error) {
+	pack, err := g.gatherPackage()
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "failed to gather package metadata")
+	}
+
+	spec, err := genPulumiSchema(pack, g.pkg, g.version, g.info)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "failed to create Pulumi schema")
+	}
+
+	schema, err := json.Marshal(spec)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "failed to marshal intermediate schema")
+	}
+
+	if err = addExtraHclExamplesToResources(g.info.ExtraResourceHclExamples, &spec); err != nil {
+		return nil, nil, err
+	}
+	if err = addExtraHclExamplesToFunctions(g.info.ExtraFunctionHclExamples, &spec); err != nil {
+		return nil, nil, err
+	}
+
+	descriptions := map[string]string{}
+	for token, resource := range spec.Resources {
+		descriptions["#/resources/"+token] = resource.Description
+	}
+	for token, function := range spec.Functions {
+		descriptions["#/functions/"+token] = function.Description
+	}
+	return descriptions, schema, nil
+}
+
+// useProviderSchema attaches the given serialized schema to the provider shim, so that examples are converted against
+// the provider's own schema as they are by Generate. The returned function restores the shim's previous schema.
+func (g *Generator) useProviderSchema(schema []byte) func() {
+	previous := g.providerShim.schema
+	g.providerShim.schema = schema
+	return func() { g.providerShim.schema = previous }
+}
+
+// validateExamples converts each code block in the examples of the given description.
+func (g *Generator) validateExamples(path, description string) []ExampleValidation {
+	var results []ExampleValidation
+	for _, example := range exampleCodeBlocks(extractExamples(description)) {
+		hcl := example.hcl
+		if fixed, ok := fixHcl(hcl); ok {
+			hcl = fixed
+		}
+
+		result := ExampleValidation{Path: path, Title: example.title, HCL: hcl, Languages: map[string]bool{}}
+		for _, lang := range g.languagesToConvert() {
+			out, err := g.convertHCLToString(hcl, path, lang)
+			// A conversion that produces no code is as good as a failure, since the example would be dropped.
+			result.Languages[lang] = err == nil && out != ""
+		}
+		results = append(results, result)
+	}
+	return results
+}
+
+// renderExamples replaces each HCL code block in the examples of the given description with its conversions to each
+// target language. Example subsections none of whose code blocks convert are dropped. If nothing converts, an empty
+// string is returned.
+func (g *Generator) renderExamples(path, description string) string {
+	examples := extractExamples(description)
+	if examples == "" {
+		return ""
+	}
+
+	var sections [][]string
+	for _, section := range splitGroupLines(examples, "## ") {
+		if len(section) > 0 {
+			sections = append(sections, section)
+		}
+	}
+
+	for _, section := range reformatExamples(sections) {
+		if section[0] != "## Example Usage" {
+			continue
+		}
+
+		lines, anyConverted := []string{section[0]}, false
+		for _, subsection := range groupLines(section[1:], "### ") {
+			rendered, hasExamples, converted := g.renderExampleSubsection(path, subsection)
+			if hasExamples && !converted {
+				continue
+			}
+			lines, anyConverted = append(lines, rendered...), anyConverted || converted
+		}
+		if !anyConverted {
+			return ""
+		}
+		return strings.TrimSpace(strings.Join(lines, "\n"))
+	}
+	return ""
+}
+
+// renderExampleSubsection replaces each HCL code block in the subsection with its conversions to each target language,
+// dropping those that fail to convert to every language. It also reports whether the subsection has any code blocks and
+// whether any of them converted.
+func (g *Generator) renderExampleSubsection(path string, subsection []string) ([]string, bool, bool) {
+	var lines []string
+	hasExamples, converted := false, false
+	inCodeBlock, codeBlockStart := false, 0
+	for i, line := range subsection {
+		switch {
+		case inCodeBlock:
+			if !strings.HasPrefix(line, "```") {
+				continue
+			}
+			inCodeBlock, hasExamples = false, true
+
+			hclConversions := g.convertExample(path, strings.Join(subsection[codeBlockStart+1:i], "\n"))
+			if code := hclConversionsToString(hclConversions, g.exampleLanguages); code != "" {
+				lines, converted = append(lines, code), true
+			}
+		case strings.HasPrefix(line, "```"):
+			inCodeBlock, codeBlockStart = true, i
+		default:
+			lines = append(lines, line)
+		}
+	}
+	return lines, hasExamples, converted
+}
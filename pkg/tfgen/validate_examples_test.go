@@ -0,0 +1,442 @@
+// Copyright 2016-2022, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tfgen
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/diag"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/diag/colors"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi-terraform-bridge/v3/pkg/tf2pulumi/convert"
+	"github.com/pulumi/pulumi-terraform-bridge/v3/pkg/tfbridge"
+	shimv1 "github.com/pulumi/pulumi-terraform-bridge/v3/pkg/tfshim/sdk-v1"
+)
+
+func TestValidateExamples(t *testing.T) {
+	info := tfbridge.ProviderInfo{
+		P: shimv1.NewProvider(&schema.Provider{
+			ResourcesMap: map[string]*schema.Resource{
+				"tiny_widget": {
+					Schema: map[string]*schema.Schema{
+						"widget_name": {Type: schema.TypeString, Optional: true},
+					},
+				},
+			},
+		}),
+		Name: "tiny",
+		Resources: map[string]*tfbridge.ResourceInfo{
+			"tiny_widget": {Tok: "tiny:index/widget:Widget"},
+		},
+		ExtraResourceHclExamples: []tfbridge.HclExampler{
+			tfbridge.InlineHclExample{
+				Token:    "tiny:index/widget:Widget",
+				Title:    "Convertible",
+				Contents: "output \"greeting\" {\n  value = \"hello\"\n}",
+			},
+			tfbridge.InlineHclExample{
+				Token:    "tiny:index/widget:Widget",
+				Title:    "Unconvertible",
+				Contents: "output \"greeting\" {\n  value = unknownfunc(\"x\")\n}",
+			},
+		},
+	}
+
+	g, err := NewGenerator(GeneratorOptions{
+		Package:      info.Name,
+		Language:     NodeJS,
+		ProviderInfo: info,
+		Root:         afero.NewMemMapFs(),
+		Sink: diag.DefaultSink(io.Discard, io.Discard, diag.FormatOptions{
+			Color: colors.Never,
+		}),
+		SkipDocs: true,
+	})
+	assert.NoError(t, err)
+
+	results, err := g.ValidateExamples()
+	assert.NoError(t, err)
+	if !assert.Len(t, results, 2) {
+		return
+	}
+
+	assert.Equal(t, "#/resources/tiny:index/widget:Widget", results[0].Path)
+	assert.Equal(t, "Convertible", results[0].Title)
+	assert.Equal(t, map[string]bool{"typescript": true}, results[0].Languages)
+	assert.False(t, results[0].Failed())
+
+	assert.Equal(t, "#/resources/tiny:index/widget:Widget", results[1].Path)
+	assert.Equal(t, "Unconvertible", results[1].Title)
+	assert.Equal(t, map[string]bool{"typescript": false}, results[1].Languages)
+	assert.True(t, results[1].Failed())
+
+	// The provider's schema is only attached to the provider shim while the examples are converted.
+	assert.Nil(t, g.providerShim.schema)
+}
+
+func TestArgumentExamples(t *testing.T) {
+	markdown := `# tiny_widget
+
+Manages a widget.
+
+## Argument Reference
+
+* ` + "`widget_name`" + ` - (Optional) The name of the widget.
+* ` + "`settings`" + ` - (Optional) The settings of the widget.
+
+The ` + "`settings`" + ` block supports:
+
+* ` + "`color`" + ` - (Optional) The color of the widget.
+`
+
+	info := tfbridge.ProviderInfo{
+		P: shimv1.NewProvider(&schema.Provider{
+			ResourcesMap: map[string]*schema.Resource{
+				"tiny_widget": {
+					Schema: map[string]*schema.Schema{
+						"widget_name": {Type: schema.TypeString, Optional: true},
+						"settings": {
+							Type:     schema.TypeList,
+							Optional: true,
+							MaxItems: 1,
+							Elem: &schema.Resource{Schema: map[string]*schema.Schema{
+								"color": {Type: schema.TypeString, Optional: true},
+							}},
+						},
+					},
+				},
+			},
+		}),
+		Name: "tiny",
+		Resources: map[string]*tfbridge.ResourceInfo{
+			"tiny_widget": {
+				Tok: "tiny:index/widget:Widget",
+				Docs: &tfbridge.DocInfo{
+					Markdown: []byte(markdown),
+					ArgumentExamples: map[string]string{
+						"widget_name":    "output \"name\" {\n  value = \"my-widget\"\n}",
+						"settings.color": "output \"color\" {\n  value = \"blue\"\n}",
+					},
+				},
+			},
+		},
+	}
+
+	g, err := NewGenerator(GeneratorOptions{
+		Package:      info.Name,
+		Language:     NodeJS,
+		ProviderInfo: info,
+		Root:         afero.NewMemMapFs(),
+		Sink: diag.DefaultSink(io.Discard, io.Discard, diag.FormatOptions{
+			Color: colors.Never,
+		}),
+	})
+	assert.NoError(t, err)
+
+	spec, err := g.gatherSchema(nil)
+	assert.NoError(t, err)
+	g.providerShim.schema, err = json.Marshal(spec)
+	assert.NoError(t, err)
+	spec = g.convertExamplesInSchema(spec)
+
+	widget := spec.Resources["tiny:index/widget:Widget"]
+	name := widget.InputProperties["widgetName"].Description
+	assert.Contains(t, name, "The name of the widget.")
+	assert.Contains(t, name, "```typescript\n")
+	assert.Contains(t, name, `export const name = "my-widget";`)
+	assert.NotContains(t, name, "```terraform")
+	assert.NotContains(t, widget.InputProperties["settings"].Description, "```")
+
+	color := spec.Types["tiny:index/WidgetSettings:WidgetSettings"].Properties["color"].Description
+	assert.Contains(t, color, "The color of the widget.")
+	assert.Contains(t, color, `export const color = "blue";`)
+}
+
+func TestConvertExamplesWithFenceLanguageAliases(t *testing.T) {
+	fence := "```"
+	example := func(title, lang, output string) string {
+		return "### " + title + "\n\n" + fence + lang + "\noutput \"" + output + "\" {\n  value = \"" + output +
+			"\"\n}\n" + fence + "\n\n"
+	}
+	markdown := "# tiny_widget\n\nManages a widget.\n\n## Example Usage\n\n" +
+		example("Short", "tf", "short") +
+		example("Upper", "HCL", "upper") +
+		example("Title", "Terraform", "title") +
+		example("Canonical", "hcl", "canonical")
+
+	info := tfbridge.ProviderInfo{
+		P: shimv1.NewProvider(&schema.Provider{
+			ResourcesMap: map[string]*schema.Resource{
+				"tiny_widget": {
+					Schema: map[string]*schema.Schema{
+						"widget_name": {Type: schema.TypeString, Optional: true},
+					},
+				},
+			},
+		}),
+		Name: "tiny",
+		Resources: map[string]*tfbridge.ResourceInfo{
+			"tiny_widget": {
+				Tok:  "tiny:index/widget:Widget",
+				Docs: &tfbridge.DocInfo{Markdown: []byte(markdown)},
+			},
+		},
+	}
+
+	g, err := NewGenerator(GeneratorOptions{
+		Package:      info.Name,
+		Language:     NodeJS,
+		ProviderInfo: info,
+		Root:         afero.NewMemMapFs(),
+		Sink: diag.DefaultSink(io.Discard, io.Discard, diag.FormatOptions{
+			Color: colors.Never,
+		}),
+	})
+	assert.NoError(t, err)
+
+	spec, err := g.gatherSchema(nil)
+	assert.NoError(t, err)
+	g.providerShim.schema, err = json.Marshal(spec)
+	assert.NoError(t, err)
+	spec = g.convertExamplesInSchema(spec)
+
+	description := spec.Resources["tiny:index/widget:Widget"].Description
+	for _, output := range []string{"short", "upper", "title", "canonical"} {
+		assert.Contains(t, description, "export const "+output+" = \""+output+"\";")
+	}
+	assert.NotContains(t, description, fence+"tf")
+	assert.NotContains(t, description, fence+"HCL")
+	assert.NotContains(t, description, fence+"Terraform")
+}
+
+func TestConvertExamplesWithMultipleCodeBlocks(t *testing.T) {
+	fence := "```"
+	// The second block contains an HCL comment that looks like a markdown header; it must not split the subsection.
+	markdown := "# tiny_widget\n\nManages a widget.\n\n## Example Usage\n\n### Two Blocks\n\n" +
+		"First, declare a greeting:\n\n" +
+		fence + "hcl\noutput \"first\" {\n  value = \"first\"\n}\n" + fence + "\n\n" +
+		"Then declare another:\n\n" +
+		fence + "hcl\n### A comment\noutput \"second\" {\n  value = \"second\"\n}\n" + fence + "\n"
+
+	info := tfbridge.ProviderInfo{
+		P: shimv1.NewProvider(&schema.Provider{
+			ResourcesMap: map[string]*schema.Resource{
+				"tiny_widget": {
+					Schema: map[string]*schema.Schema{
+						"widget_name": {Type: schema.TypeString, Optional: true},
+					},
+				},
+			},
+		}),
+		Name: "tiny",
+		Resources: map[string]*tfbridge.ResourceInfo{
+			"tiny_widget": {
+				Tok:  "tiny:index/widget:Widget",
+				Docs: &tfbridge.DocInfo{Markdown: []byte(markdown)},
+			},
+		},
+	}
+
+	g, err := NewGenerator(GeneratorOptions{
+		Package:      info.Name,
+		Language:     NodeJS,
+		ProviderInfo: info,
+		Root:         afero.NewMemMapFs(),
+		Sink: diag.DefaultSink(io.Discard, io.Discard, diag.FormatOptions{
+			Color: colors.Never,
+		}),
+	})
+	assert.NoError(t, err)
+
+	spec, err := g.gatherSchema(nil)
+	assert.NoError(t, err)
+	g.providerShim.schema, err = json.Marshal(spec)
+	assert.NoError(t, err)
+	spec = g.convertExamplesInSchema(spec)
+
+	description := spec.Resources["tiny:index/widget:Widget"].Description
+	first := strings.Index(description, `export const first = "first";`)
+	prose := strings.Index(description, "Then declare another:")
+	second := strings.Index(description, `export const second = "second";`)
+	assert.True(t, first >= 0, "first block was not converted:\n%s", description)
+	assert.True(t, second >= 0, "second block was not converted:\n%s", description)
+	assert.True(t, first < prose && prose < second, "prose is out of order:\n%s", description)
+	assert.Equal(t, 1, strings.Count(description, "{{% example %}}"))
+	assert.NotContains(t, description, fence+"hcl")
+}
+
+func TestGenerateExamplesOnly(t *testing.T) {
+	markdown := "# tiny_widget\n\nManages a widget.\n\n## Example Usage\n\n" +
+		"```hcl\noutput \"greeting\" {\n  value = \"hello\"\n}\n```\n\n" +
+		"## Example Usage - Unconvertible\n\n" +
+		"```hcl\noutput \"greeting\" {\n  value = unknownfunc(\"x\")\n}\n```\n\n" +
+		"## Argument Reference\n\n* `widget_name` - (Optional) The name of the widget.\n"
+
+	info := tfbridge.ProviderInfo{
+		P: shimv1.NewProvider(&schema.Provider{
+			ResourcesMap: map[string]*schema.Resource{
+				"tiny_widget": {
+					Schema: map[string]*schema.Schema{
+						"widget_name": {Type: schema.TypeString, Optional: true},
+					},
+				},
+				"tiny_gadget": {
+					Schema: map[string]*schema.Schema{
+						"gadget_name": {Type: schema.TypeString, Optional: true},
+					},
+				},
+			},
+		}),
+		Name: "tiny",
+		Resources: map[string]*tfbridge.ResourceInfo{
+			"tiny_widget": {
+				Tok:  "tiny:index/widget:Widget",
+				Docs: &tfbridge.DocInfo{Markdown: []byte(markdown)},
+			},
+			"tiny_gadget": {
+				Tok:  "tiny:index/gadget:Gadget",
+				Docs: &tfbridge.DocInfo{Markdown: []byte("# tiny_gadget\n\nManages a gadget.\n")},
+			},
+		},
+	}
+
+	g, err := NewGenerator(GeneratorOptions{
+		Package:      info.Name,
+		Language:     NodeJS,
+		ProviderInfo: info,
+		Root:         afero.NewMemMapFs(),
+		Sink: diag.DefaultSink(io.Discard, io.Discard, diag.FormatOptions{
+			Color: colors.Never,
+		}),
+	})
+	assert.NoError(t, err)
+
+	examples, err := g.GenerateExamplesOnly()
+	assert.NoError(t, err)
+	if !assert.Len(t, examples, 1) {
+		return
+	}
+
+	widget := examples["tiny:index/widget:Widget"]
+	assert.True(t, strings.HasPrefix(widget, "## Example Usage\n"), widget)
+	assert.Contains(t, widget, "```typescript\n")
+	assert.Contains(t, widget, `export const greeting = "hello";`)
+	assert.NotContains(t, widget, "### Unconvertible")
+	assert.NotContains(t, widget, "```hcl")
+	assert.NotContains(t, widget, "unknownfunc")
+	assert.NotContains(t, widget, "Argument Reference")
+}
+
+// languageHCLConverter converts HCL to a program that names the target language and quotes the first line of the HCL.
+type languageHCLConverter struct{}
+
+func (languageHCLConverter) Convert(opts convert.Options) (map[string][]byte, convert.Diagnostics, error) {
+	files, err := afero.ReadDir(opts.Root, "/")
+	if err != nil || len(files) != 1 {
+		return nil, convert.Diagnostics{}, err
+	}
+	contents, err := afero.ReadFile(opts.Root, "/"+files[0].Name())
+	if err != nil {
+		return nil, convert.Diagnostics{}, err
+	}
+	firstLine := strings.SplitN(string(contents), "\n", 2)[0]
+	return map[string][]byte{"program": []byte(fmt.Sprintf("// %s: %s\n", opts.TargetLanguage, firstLine))},
+		convert.Diagnostics{}, nil
+}
+
+func TestWriteExamples(t *testing.T) {
+	markdown := "# tiny_widget\n\nManages a widget.\n\n## Example Usage\n\n### Basic\n\n" +
+		"```hcl\nresource \"tiny_widget\" \"basic\" {}\n```\n\n### Named\n\n" +
+		"```hcl\nresource \"tiny_widget\" \"named\" {\n  widget_name = \"a\"\n}\n```\n"
+
+	info := tfbridge.ProviderInfo{
+		P: shimv1.NewProvider(&schema.Provider{
+			ResourcesMap: map[string]*schema.Resource{
+				"tiny_widget": {
+					Schema: map[string]*schema.Schema{
+						"widget_name": {Type: schema.TypeString, Optional: true},
+					},
+				},
+				"tiny_gadget": {
+					Schema: map[string]*schema.Schema{
+						"gadget_name": {Type: schema.TypeString, Optional: true},
+					},
+				},
+			},
+		}),
+		Name: "tiny",
+		Resources: map[string]*tfbridge.ResourceInfo{
+			"tiny_widget": {
+				Tok:  "tiny:index/widget:Widget",
+				Docs: &tfbridge.DocInfo{Markdown: []byte(markdown)},
+			},
+			"tiny_gadget": {
+				Tok:  "tiny:index/gadget:Gadget",
+				Docs: &tfbridge.DocInfo{Markdown: []byte("# tiny_gadget\n\nManages a gadget.\n")},
+			},
+		},
+	}
+
+	root := afero.NewMemMapFs()
+	g, err := NewGenerator(GeneratorOptions{
+		Package:      info.Name,
+		Language:     Schema,
+		ProviderInfo: info,
+		Root:         root,
+		Sink: diag.DefaultSink(io.Discard, io.Discard, diag.FormatOptions{
+			Color: colors.Never,
+		}),
+		HCLConverter:     languageHCLConverter{},
+		ExampleLanguages: []string{"typescript", "python"},
+	})
+	assert.NoError(t, err)
+
+	fileName := func(token, lang string) string {
+		return path.Join("examples", strings.ReplaceAll(token, ":", "/"), lang+".md")
+	}
+
+	files, err := g.WriteExamples([]string{"tiny:index/widget:Widget", "tiny:index/gadget:Gadget"}, fileName)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]map[string]string{
+		"tiny:index/widget:Widget": {
+			"typescript": "examples/tiny/index/widget/Widget/typescript.md",
+			"python":     "examples/tiny/index/widget/Widget/python.md",
+		},
+		// An entity without examples produces no files.
+		"tiny:index/gadget:Gadget": {},
+	}, files)
+
+	contents, err := afero.ReadFile(root, "examples/tiny/index/widget/Widget/python.md")
+	assert.NoError(t, err)
+	assert.Equal(t, "### Basic\n\n```python\n// python: resource \"tiny_widget\" \"basic\" {}\n```\n\n"+
+		"### Named\n\n```python\n// python: resource \"tiny_widget\" \"named\" {\n```\n", string(contents))
+
+	// An unknown token fails the call before any examples are written.
+	root = afero.NewMemMapFs()
+	g.root = root
+	_, err = g.WriteExamples([]string{"tiny:index/widget:Widget", "tiny:index/gizmo:Gizmo"}, fileName)
+	assert.EqualError(t, err, `no resource or function has the token "tiny:index/gizmo:Gizmo"`)
+	exists, err := afero.DirExists(root, "examples")
+	assert.NoError(t, err)
+	assert.False(t, exists)
+}